@@ -0,0 +1,52 @@
+/*
+Package options contains a set of common CLI options and helper functions to use them.
+*/
+package options
+
+import (
+	"context"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
+	"github.com/urfave/cli"
+)
+
+var (
+	// RPCFlag is a flag for RPC node address.
+	RPCFlag = cli.StringFlag{
+		Name:  "rpc, r",
+		Usage: "RPC node address",
+	}
+	// TimeoutFlag is a flag for operation timeout.
+	TimeoutFlag = cli.DurationFlag{
+		Name:  "timeout, t",
+		Usage: "Timeout for the operation",
+	}
+)
+
+// RPC is a set of flags used for RPC connections (endpoint and timeout).
+var RPC = []cli.Flag{RPCFlag, TimeoutFlag}
+
+// GetTimeoutContext returns a context.Context with default or user-set timeout
+// and a function to cancel it.
+func GetTimeoutContext(ctx *cli.Context) (context.Context, func()) {
+	if dur := ctx.Duration("timeout"); dur != 0 {
+		return context.WithTimeout(context.Background(), dur)
+	}
+	return context.Background(), func() {}
+}
+
+// GetRPCClient returns an RPC client instance for the node given in command
+// flags along with a function to cancel its context.
+func GetRPCClient(ctx *cli.Context) (*client.Client, func(), error) {
+	endpoint := ctx.String("rpc")
+	if endpoint == "" {
+		return nil, nil, cli.NewExitError("no RPC endpoint specified, use option '--rpc' or '-r'", 1)
+	}
+	gctx, cancel := GetTimeoutContext(ctx)
+	c, err := client.New(gctx, endpoint, client.Options{})
+	if err != nil {
+		cancel()
+		return nil, nil, cli.NewExitError(err, 1)
+	}
+	return c, cancel, nil
+}
@@ -0,0 +1,95 @@
+package query
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/urfave/cli"
+)
+
+// NewCommands returns 'query' command.
+func NewCommands() []cli.Command {
+	return []cli.Command{{
+		Name:  "query",
+		Usage: "query data from RPC node",
+		Subcommands: []cli.Command{
+			{
+				Name:      "candidates",
+				Usage:     "get candidates and votes",
+				UsageText: "neo-go query candidates -r endpoint [-t timeout]",
+				Action:    queryCandidates,
+				Flags:     options.RPC,
+			},
+			{
+				Name:      "committee",
+				Usage:     "get committee list",
+				UsageText: "neo-go query committee -r endpoint [-t timeout]",
+				Action:    queryCommittee,
+				Flags:     options.RPC,
+			},
+			{
+				Name:      "validators",
+				Usage:     "get next block validators and their votes",
+				UsageText: "neo-go query validators -r endpoint [-t timeout]",
+				Action:    queryValidators,
+				Flags:     options.RPC,
+			},
+		},
+	}}
+}
+
+func queryCandidates(ctx *cli.Context) error {
+	c, cancel, err := options.GetRPCClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	candidates, err := c.GetCandidates()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "Key\tVotes\tActive")
+	for _, cand := range candidates {
+		fmt.Fprintf(w, "%x\t%d\t%t\n", cand.PublicKey.Bytes(), cand.Votes, cand.Active)
+	}
+	return w.Flush()
+}
+
+func queryCommittee(ctx *cli.Context) error {
+	c, cancel, err := options.GetRPCClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	committee, err := c.GetCommittee()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	for _, k := range committee {
+		fmt.Fprintf(ctx.App.Writer, "%x\n", k.Bytes())
+	}
+	return nil
+}
+
+func queryValidators(ctx *cli.Context) error {
+	c, cancel, err := options.GetRPCClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+
+	validators, err := c.GetNextBlockValidators()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "Key\tVotes")
+	for _, v := range validators {
+		fmt.Fprintf(w, "%x\t%d\n", v.PublicKey.Bytes(), v.Votes)
+	}
+	return w.Flush()
+}
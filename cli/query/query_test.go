@@ -0,0 +1,84 @@
+package query
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+const (
+	key1 = "02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"
+	key2 = "02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"
+)
+
+var testResponses = map[string]string{
+	"getcandidates":          `[{"publickey":"` + key1 + `","votes":"100","active":true},{"publickey":"` + key2 + `","votes":"5","active":false}]`,
+	"getcommittee":           `["` + key1 + `","` + key2 + `"]`,
+	"getnextblockvalidators": `[{"publickey":"` + key1 + `","votes":"100","active":true}]`,
+}
+
+// initTestServer creates an RPC server returning canned responses from
+// testResponses.
+func initTestServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := request.NewIn()
+		require.NoError(t, r.DecodeData(req.Body))
+		res, ok := testResponses[r.Method]
+		require.True(t, ok, "unexpected method %s", r.Method)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, err := w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":` + res + `}`))
+		require.NoError(t, err)
+	}))
+}
+
+func runQuery(t *testing.T, args ...string) (string, error) {
+	buf := new(bytes.Buffer)
+	app := cli.NewApp()
+	app.Writer = buf
+	app.Commands = NewCommands()
+	err := app.Run(append([]string{"neo-go", "query"}, args...))
+	return buf.String(), err
+}
+
+func TestQuery(t *testing.T) {
+	srv := initTestServer(t)
+	defer srv.Close()
+
+	// Key column is padded to the key length plus 4 spaces.
+	header := "Key" + strings.Repeat(" ", len(key1)+4-len("Key"))
+
+	t.Run("Candidates", func(t *testing.T) {
+		out, err := runQuery(t, "candidates", "-r", srv.URL)
+		require.NoError(t, err)
+		require.Equal(t, header+"Votes    Active\n"+
+			key1+"    100      true\n"+
+			key2+"    5        false\n", out)
+	})
+	t.Run("Committee", func(t *testing.T) {
+		out, err := runQuery(t, "committee", "--rpc", srv.URL, "--timeout", "10s")
+		require.NoError(t, err)
+		require.Equal(t, key1+"\n"+key2+"\n", out)
+	})
+	t.Run("Validators", func(t *testing.T) {
+		out, err := runQuery(t, "validators", "-r", srv.URL)
+		require.NoError(t, err)
+		require.Equal(t, header+"Votes\n"+
+			key1+"    100\n", out)
+	})
+	t.Run("NoEndpoint", func(t *testing.T) {
+		exiter := cli.OsExiter
+		cli.OsExiter = func(int) {}
+		defer func() { cli.OsExiter = exiter }()
+
+		for _, cmd := range []string{"candidates", "committee", "validators"} {
+			_, err := runQuery(t, cmd)
+			require.Error(t, err, cmd)
+		}
+	})
+}
@@ -21,6 +21,18 @@ import (
 	"go.uber.org/zap/zapcore"
 )
 
+// rpcExtensions are applied to the node's RPC server before it's started.
+var rpcExtensions []func(*server.Server) error
+
+// RegisterRPCExtension adds a function that is called with the node's RPC
+// server before it's started, so that it can register additional handlers,
+// events and hooks there. It allows to build custom node binaries with RPC
+// extensions kept in separate packages, it must be called before the node
+// command is run.
+func RegisterRPCExtension(f func(*server.Server) error) {
+	rpcExtensions = append(rpcExtensions, f)
+}
+
 // NewCommands returns 'node' command.
 func NewCommands() []cli.Command {
 	var cfgFlags = []cli.Flag{
@@ -359,6 +371,16 @@ func startServer(ctx *cli.Context) error {
 		return cli.NewExitError(fmt.Errorf("failed to create network server: %v", err), 1)
 	}
 	rpcServer := server.New(chain, cfg.ApplicationConfiguration.RPC, serv, log)
+	if cfg.ApplicationConfiguration.RPC.EnableWalletMethods && cfg.ApplicationConfiguration.UnlockWallet.Path != "" {
+		if err := rpcServer.OpenWallet(cfg.ApplicationConfiguration.UnlockWallet); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to open wallet for RPC server: %v", err), 1)
+		}
+	}
+	for _, f := range rpcExtensions {
+		if err := f(&rpcServer); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to register RPC extension: %v", err), 1)
+		}
+	}
 	errChan := make(chan error)
 
 	go serv.Start(errChan)
@@ -424,6 +446,9 @@ func initBlockChain(cfg config.Config, log *zap.Logger) (*core.Blockchain, error
 	if err != nil {
 		return nil, cli.NewExitError(fmt.Errorf("could not initialize blockchain: %s", err), 1)
 	}
+	if err := chain.SetRemoveUntraceableBlocks(cfg.ApplicationConfiguration.RemoveUntraceableBlocks); err != nil {
+		return nil, cli.NewExitError(fmt.Errorf("could not initialize blockchain: %s", err), 1)
+	}
 	if cfg.ProtocolConfiguration.AddressVersion != 0 {
 		address.Prefix = cfg.ProtocolConfiguration.AddressVersion
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 
+	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
@@ -21,8 +22,8 @@ func newMultisigCommands() []cli.Command {
 			Action:    signMultisig,
 			Flags: []cli.Flag{
 				walletPathFlag,
-				rpcFlag,
-				timeoutFlag,
+				options.RPCFlag,
+				options.TimeoutFlag,
 				outFlag,
 				inFlag,
 				cli.StringFlag{
@@ -82,7 +83,7 @@ func signMultisig(ctx *cli.Context) error {
 		}
 		tx.Scripts = append(tx.Scripts, *w)
 
-		gctx, cancel := getGoContext(ctx)
+		gctx, cancel := options.GetTimeoutContext(ctx)
 		defer cancel()
 
 		c, err := client.New(gctx, ctx.String("rpc"), client.Options{})
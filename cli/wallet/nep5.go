@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
@@ -24,8 +25,8 @@ func newNEP5Commands() []cli.Command {
 			Action:    getNEP5Balance,
 			Flags: []cli.Flag{
 				walletPathFlag,
-				rpcFlag,
-				timeoutFlag,
+				options.RPCFlag,
+				options.TimeoutFlag,
 				cli.StringFlag{
 					Name:  "addr",
 					Usage: "Address to use",
@@ -43,7 +44,7 @@ func newNEP5Commands() []cli.Command {
 			Action:    importNEP5Token,
 			Flags: []cli.Flag{
 				walletPathFlag,
-				rpcFlag,
+				options.RPCFlag,
 				cli.StringFlag{
 					Name:  "token",
 					Usage: "Token contract hash in LE",
@@ -84,9 +85,9 @@ func newNEP5Commands() []cli.Command {
 			Action:    transferNEP5,
 			Flags: []cli.Flag{
 				walletPathFlag,
-				rpcFlag,
+				options.RPCFlag,
 				outFlag,
-				timeoutFlag,
+				options.TimeoutFlag,
 				fromAddrFlag,
 				toAddrFlag,
 				cli.StringFlag{
@@ -123,7 +124,7 @@ func getNEP5Balance(ctx *cli.Context) error {
 		return cli.NewExitError(fmt.Errorf("can't find account for the address: %s", addr), 1)
 	}
 
-	gctx, cancel := getGoContext(ctx)
+	gctx, cancel := options.GetTimeoutContext(ctx)
 	defer cancel()
 
 	c, err := client.New(gctx, ctx.String("rpc"), client.Options{})
@@ -218,7 +219,7 @@ func importNEP5Token(ctx *cli.Context) error {
 		}
 	}
 
-	gctx, cancel := getGoContext(ctx)
+	gctx, cancel := options.GetTimeoutContext(ctx)
 	defer cancel()
 
 	c, err := client.New(gctx, ctx.String("rpc"), client.Options{})
@@ -314,7 +315,7 @@ func transferNEP5(ctx *cli.Context) error {
 		return cli.NewExitError(fmt.Errorf("can't find account for the address: %s", fromFlag), 1)
 	}
 
-	gctx, cancel := getGoContext(ctx)
+	gctx, cancel := options.GetTimeoutContext(ctx)
 	defer cancel()
 	c, err := client.New(gctx, ctx.String("rpc"), client.Options{})
 	if err != nil {
@@ -2,7 +2,6 @@ package wallet
 
 import (
 	"bufio"
-	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -11,6 +10,7 @@ import (
 	"syscall"
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/client"
@@ -38,14 +38,6 @@ var (
 		Name:  "decrypt, d",
 		Usage: "Decrypt encrypted keys.",
 	}
-	rpcFlag = cli.StringFlag{
-		Name:  "rpc, r",
-		Usage: "RPC node address",
-	}
-	timeoutFlag = cli.DurationFlag{
-		Name:  "timeout, t",
-		Usage: "Timeout for the operation",
-	}
 	outFlag = cli.StringFlag{
 		Name:  "out",
 		Usage: "file to put JSON transaction to",
@@ -80,8 +72,8 @@ func NewCommands() []cli.Command {
 				Action: claimGas,
 				Flags: []cli.Flag{
 					walletPathFlag,
-					rpcFlag,
-					timeoutFlag,
+					options.RPCFlag,
+					options.TimeoutFlag,
 					flags.AddressFlag{
 						Name:  "address, a",
 						Usage: "Address to claim GAS for",
@@ -211,7 +203,7 @@ func claimGas(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	gctx, cancel := getGoContext(ctx)
+	gctx, cancel := options.GetTimeoutContext(ctx)
 	defer cancel()
 
 	c, err := client.New(gctx, ctx.String("rpc"), client.Options{})
@@ -420,13 +412,6 @@ func askForConsent() bool {
 	return false
 }
 
-func getGoContext(ctx *cli.Context) (context.Context, func()) {
-	if dur := ctx.Duration("timeout"); dur != 0 {
-		return context.WithTimeout(context.Background(), dur)
-	}
-	return context.Background(), func() {}
-}
-
 func dumpWallet(ctx *cli.Context) error {
 	wall, err := openWallet(ctx.String("path"))
 	if err != nil {
@@ -2,6 +2,7 @@ package compiler
 
 import (
 	"errors"
+	"fmt"
 	"go/ast"
 	"go/types"
 	"strings"
@@ -13,12 +14,13 @@ import (
 
 var (
 	// Go language builtin functions.
-	goBuiltins = []string{"len", "append", "panic"}
+	goBuiltins = []string{"len", "append", "panic", "recover"}
 	// Custom builtin utility functions.
 	customBuiltins = []string{
 		"SHA256", "AppCall",
 		"FromAddress", "Equals",
 		"ToBool", "ToByteArray", "ToInteger",
+		"Pow", "Sqrt", "ModMul", "ModPow",
 	}
 )
 
@@ -27,9 +29,15 @@ func (c *codegen) newGlobal(name string) {
 	c.globals[name] = len(c.globals)
 }
 
-// traverseGlobals visits and initializes global variables.
-func (c *codegen) traverseGlobals(f ast.Node) {
+// traverseGlobals visits and initializes global variables. If needException
+// is true, an additional static slot is allocated for the exception processed
+// by deferred calls.
+func (c *codegen) traverseGlobals(f ast.Node, needException bool) {
 	n := countGlobals(f)
+	if needException {
+		c.exceptionIndex = n
+		n++
+	}
 	if n != 0 {
 		if n > 255 {
 			c.prog.BinWriter.Err = errors.New("too many global variables")
@@ -58,6 +66,68 @@ func countGlobals(f ast.Node) (i int) {
 	return
 }
 
+// hasDefer checks whether there is any `defer` statement in the program.
+func hasDefer(pkgs map[*types.Package]*loader.PackageInfo) bool {
+	var found bool
+	for _, pkg := range pkgs {
+		for _, f := range pkg.Files {
+			ast.Inspect(f, func(node ast.Node) bool {
+				_, ok := node.(*ast.DeferStmt)
+				found = found || ok
+				return !found
+			})
+		}
+	}
+	return found
+}
+
+// checkDefers checks that all `defer` statements of the function body are
+// located at its top level, deferring calls in loops or conditional blocks
+// is not supported.
+func (c *codegen) checkDefers(body *ast.BlockStmt) error {
+	var err error
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.FuncLit:
+			// Function literals are converted separately.
+			return false
+		case *ast.DeferStmt:
+			for _, stmt := range body.List {
+				if stmt == n {
+					err = c.checkDeferredCall(n.Call)
+					return false
+				}
+			}
+			err = errors.New("defer is supported only at the top level of function body")
+		}
+		return err == nil
+	})
+	return err
+}
+
+// checkDeferredCall checks that deferred call doesn't depend on values that
+// can change before it's executed. Go evaluates function value and arguments
+// at `defer` statement, but deferred calls are emitted at function exit, so
+// only constant arguments of declared functions are supported.
+func (c *codegen) checkDeferredCall(call *ast.CallExpr) error {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if _, ok := c.funcs[fun.Name]; !ok && !isGoBuiltin(fun.Name) {
+			return fmt.Errorf("deferred call of function variable %s is not supported", fun.Name)
+		}
+	case *ast.SelectorExpr:
+		if c.typeInfo.Selections[fun] != nil {
+			return fmt.Errorf("deferred method call %s is not supported", fun.Sel.Name)
+		}
+	}
+	for _, arg := range call.Args {
+		if c.typeAndValueOf(arg).Value == nil {
+			return errors.New("only constant arguments are supported in deferred calls")
+		}
+	}
+	return nil
+}
+
 // isExprNil looks if the given expression is a `nil`.
 func isExprNil(e ast.Expr) bool {
 	v, ok := e.(*ast.Ident)
@@ -197,6 +197,146 @@ var binaryExprTestCases = []testCase{
 		`,
 		big.NewInt(1),
 	},
+	{
+		"compare not equal bools with neq",
+		`
+		package testcase
+		func Main() int {
+			a := true
+			b := false
+			if a != b {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(1),
+	},
+	{
+		"compare equal bools with neq",
+		`
+		package testcase
+		func Main() int {
+			a := true
+			b := true
+			if a != b {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(0),
+	},
+	{
+		"compare nil byte slice with neq",
+		`
+		package testcase
+		func Main() int {
+			a := []byte{1, 2, 3}
+			a = nil
+			if a != nil {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(0),
+	},
+	{
+		"compare non-nil byte slice with neq",
+		`
+		package testcase
+		func Main() int {
+			a := []byte("this byte slice is longer than 32 bytes")
+			if a != nil {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(1),
+	},
+	{
+		"compare nil interface with neq",
+		`
+		package testcase
+		func Main() int {
+			var a interface{}
+			if a != nil {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(0),
+	},
+	{
+		"compare non-nil interface with neq",
+		`
+		package testcase
+		func Main() int {
+			var a interface{}
+			a = 42
+			if a != nil {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(1),
+	},
+	{
+		"compare interfaces with neq",
+		`
+		package testcase
+		func Main() int {
+			var a, b interface{}
+			a = "one"
+			b = "two"
+			if a != b {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(1),
+	},
+	{
+		"compare equal structs with neq",
+		`
+		package testcase
+		type pair struct {
+			a, b int
+		}
+		func Main() int {
+			x := pair{a: 1, b: 2}
+			y := pair{a: 1, b: 2}
+			if x != y {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(0),
+	},
+	{
+		"compare not equal structs with neq",
+		`
+		package testcase
+		type pair struct {
+			a, b int
+		}
+		func Main() int {
+			x := pair{a: 1, b: 2}
+			y := pair{a: 1, b: 3}
+			if x != y {
+				return 1
+			}
+			return 0
+		}
+		`,
+		big.NewInt(1),
+	},
 	{
 		"simple add and assign",
 		`
@@ -24,6 +24,10 @@ import (
 // The identifier of the entry function. Default set to Main.
 const mainIdent = "Main"
 
+// deferDepthVar is the name of the local variable holding evaluation stack
+// depth for functions with deferred calls.
+const deferDepthVar = "defer@depth"
+
 type codegen struct {
 	// Information about the program with all its dependencies.
 	buildInfo *buildInfo
@@ -45,6 +49,10 @@ type codegen struct {
 
 	globals map[string]int
 
+	// exceptionIndex is the index of static slot where exception is stored
+	// during deferred calls processing, -1 if there are no defers.
+	exceptionIndex int
+
 	// A mapping from label's names to their ids.
 	labels map[labelWithType]uint16
 	// A list of nested label names together with evaluation stack depth.
@@ -195,6 +203,11 @@ func getBaseOpcode(t varType) (opcode.Opcode, opcode.Opcode) {
 // emitLoadVar loads specified variable to the evaluation stack.
 func (c *codegen) emitLoadVar(name string) {
 	t, i := c.getVarIndex(name)
+	c.emitLoadByIndex(t, i)
+}
+
+// emitLoadByIndex loads specified variable type with index i.
+func (c *codegen) emitLoadByIndex(t varType, i int) {
 	base, _ := getBaseOpcode(t)
 	if i < 7 {
 		emit.Opcode(c.prog.BinWriter, base+opcode.Opcode(i))
@@ -210,6 +223,11 @@ func (c *codegen) emitStoreVar(name string) {
 		return
 	}
 	t, i := c.getVarIndex(name)
+	c.emitStoreByIndex(t, i)
+}
+
+// emitStoreByIndex stores top value in the specified variable type with index i.
+func (c *codegen) emitStoreByIndex(t varType, i int) {
 	_, base := getBaseOpcode(t)
 	if i < 7 {
 		emit.Opcode(c.prog.BinWriter, base+opcode.Opcode(i))
@@ -332,15 +350,22 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl) {
 		}
 	}
 
+	if err := c.checkDefers(decl.Body); err != nil {
+		c.prog.Err = err
+		return
+	}
+
 	ast.Walk(c, decl.Body)
 
 	// If we have reached the end of the function without encountering `return` statement,
 	// we should clean alt.stack manually.
 	// This can be the case with void and named-return functions.
 	if !lastStmtIsReturn(decl) {
+		c.processDefers(len(c.scope.deferStack))
 		c.saveSequencePoint(decl.Body)
 		emit.Opcode(c.prog.BinWriter, opcode.RET)
 	}
+	c.emitCatchBlocks()
 
 	f.rng.End = uint16(c.prog.Len() - 1)
 
@@ -475,27 +500,43 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		c.dropItems(cnt)
 
 		if len(n.Results) == 0 {
-			results := c.scope.decl.Type.Results
-			if results.NumFields() != 0 {
-				// function with named returns
-				for i := len(results.List) - 1; i >= 0; i-- {
-					names := results.List[i].Names
-					for j := len(names) - 1; j >= 0; j-- {
-						c.emitLoadVar(names[j].Name)
-					}
-				}
-			}
+			// Deferred calls can change named results.
+			c.processDefers(len(c.scope.deferStack))
+			c.emitLoadNamedResults()
 		} else {
 			// first result should be on top of the stack
 			for i := len(n.Results) - 1; i >= 0; i-- {
 				ast.Walk(c, n.Results[i])
 			}
+			c.processDefers(len(c.scope.deferStack))
 		}
 
 		c.saveSequencePoint(n)
 		emit.Opcode(c.prog.BinWriter, opcode.RET)
 		return nil
 
+	case *ast.DeferStmt:
+		if len(c.scope.deferStack) == 0 {
+			// Save stack depth to be able to clean the stack on exception.
+			emit.Opcode(c.prog.BinWriter, opcode.DEPTH)
+			c.emitStoreVar(deferDepthVar)
+		}
+		d := deferInfo{
+			catchLabel: c.newLabel(),
+			expr:       n.Call,
+		}
+		if lit, ok := n.Call.Fun.(*ast.FuncLit); ok {
+			d.isLambda = true
+			d.lambdaLabel = c.newLabel()
+			c.newLambda(d.lambdaLabel, lit)
+		}
+		// Only catch block is used, finally offset is left zero.
+		param := make([]byte, 8)
+		binary.LittleEndian.PutUint16(param, d.catchLabel)
+		emit.Instruction(c.prog.BinWriter, opcode.TRYL, param)
+		c.scope.deferStack = append(c.scope.deferStack, d)
+		return nil
+
 	case *ast.IfStmt:
 		lIf := c.newLabel()
 		lElse := c.newLabel()
@@ -674,10 +715,10 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 				emit.Opcode(c.prog.BinWriter, op)
 			case n.Op == token.NEQ:
 				// VM has separate opcodes for number and string equality
-				if isString(c.typeOf(n.X)) {
-					emit.Opcode(c.prog.BinWriter, opcode.NOTEQUAL)
-				} else {
+				if c.getEqualityOpcode(n.X) == opcode.NUMEQUAL {
 					emit.Opcode(c.prog.BinWriter, opcode.NUMNOTEQUAL)
+				} else {
+					emit.Opcode(c.prog.BinWriter, opcode.NOTEQUAL)
 				}
 			default:
 				c.convertToken(n.Op)
@@ -974,6 +1015,96 @@ func (c *codegen) dropItems(n int) {
 	emit.Opcode(c.prog.BinWriter, opcode.DROP)
 }
 
+// processDefers leaves TRY blocks of the first n deferred calls and executes
+// these calls in reverse order.
+func (c *codegen) processDefers(n int) {
+	for i := n - 1; i >= 0; i-- {
+		after := c.newLabel()
+		emit.Jmp(c.prog.BinWriter, opcode.ENDTRYL, after)
+		c.setLabel(after)
+		c.emitDeferredCall(c.scope.deferStack[i])
+	}
+}
+
+// emitDeferredCall emits deferred call and drops its results.
+func (c *codegen) emitDeferredCall(d deferInfo) {
+	if d.isLambda {
+		for _, arg := range d.expr.Args {
+			ast.Walk(c, arg)
+		}
+		c.emitReverse(len(d.expr.Args))
+		emit.Call(c.prog.BinWriter, opcode.CALLL, d.lambdaLabel)
+	} else {
+		ast.Walk(c, d.expr)
+	}
+	if tuple, ok := c.typeOf(d.expr).(*types.Tuple); ok {
+		c.dropItems(tuple.Len())
+	} else {
+		c.dropItems(1)
+	}
+}
+
+// emitCatchBlocks emits catch blocks for all deferred calls of the current
+// function. Catch block saves an exception, cleans the stack and executes
+// deferred calls. If the exception wasn't recovered by the deferred call it
+// is thrown again, otherwise function returns named results or default values.
+func (c *codegen) emitCatchBlocks() {
+	for i := len(c.scope.deferStack) - 1; i >= 0; i-- {
+		d := c.scope.deferStack[i]
+		c.setLabel(d.catchLabel)
+		c.emitStoreByIndex(varGlobal, c.exceptionIndex)
+
+		loop := c.newLabel()
+		done := c.newLabel()
+		c.setLabel(loop)
+		emit.Opcode(c.prog.BinWriter, opcode.DEPTH)
+		c.emitLoadVar(deferDepthVar)
+		emit.Jmp(c.prog.BinWriter, opcode.JMPLEL, done)
+		emit.Opcode(c.prog.BinWriter, opcode.DROP)
+		emit.Jmp(c.prog.BinWriter, opcode.JMPL, loop)
+		c.setLabel(done)
+
+		after := c.newLabel()
+		emit.Jmp(c.prog.BinWriter, opcode.ENDTRYL, after)
+		c.setLabel(after)
+		c.emitDeferredCall(d)
+
+		recovered := c.newLabel()
+		c.emitLoadByIndex(varGlobal, c.exceptionIndex)
+		emit.Opcode(c.prog.BinWriter, opcode.ISNULL)
+		emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, recovered)
+		c.emitLoadByIndex(varGlobal, c.exceptionIndex)
+		emit.Opcode(c.prog.BinWriter, opcode.THROW)
+		c.setLabel(recovered)
+
+		c.processDefers(i)
+		results := c.scope.decl.Type.Results
+		if results.NumFields() != 0 && len(results.List[0].Names) != 0 {
+			c.emitLoadNamedResults()
+		} else {
+			for j := results.NumFields() - 1; j >= 0; j-- {
+				c.emitDefault(c.typeOf(results.List[j].Type))
+			}
+		}
+		emit.Opcode(c.prog.BinWriter, opcode.RET)
+	}
+}
+
+// emitLoadNamedResults loads named results of the current function on stack,
+// the first result is put on top of the stack.
+func (c *codegen) emitLoadNamedResults() {
+	results := c.scope.decl.Type.Results
+	if results.NumFields() == 0 {
+		return
+	}
+	for i := len(results.List) - 1; i >= 0; i-- {
+		names := results.List[i].Names
+		for j := len(names) - 1; j >= 0; j-- {
+			c.emitLoadVar(names[j].Name)
+		}
+	}
+}
+
 // emitReverse reverses top num items of the stack.
 func (c *codegen) emitReverse(num int) {
 	switch num {
@@ -1084,15 +1215,28 @@ func (c *codegen) convertBuiltin(expr *ast.CallExpr) {
 	case "panic":
 		arg := expr.Args[0]
 		if isExprNil(arg) {
-			emit.Opcode(c.prog.BinWriter, opcode.DROP)
+			emit.Opcode(c.prog.BinWriter, opcode.PUSHNULL)
 			emit.Opcode(c.prog.BinWriter, opcode.THROW)
 		} else if isString(c.typeInfo.Types[arg].Type) {
 			ast.Walk(c, arg)
+			emit.Opcode(c.prog.BinWriter, opcode.DUP)
 			emit.Syscall(c.prog.BinWriter, "Neo.Runtime.Log")
 			emit.Opcode(c.prog.BinWriter, opcode.THROW)
 		} else {
 			c.prog.Err = errors.New("panic should have string or nil argument")
 		}
+	case "recover":
+		if c.exceptionIndex < 0 {
+			if !c.scope.voidCalls[expr] {
+				emit.Opcode(c.prog.BinWriter, opcode.PUSHNULL)
+			}
+			return
+		}
+		if !c.scope.voidCalls[expr] {
+			c.emitLoadByIndex(varGlobal, c.exceptionIndex)
+		}
+		emit.Opcode(c.prog.BinWriter, opcode.PUSHNULL)
+		c.emitStoreByIndex(varGlobal, c.exceptionIndex)
 	case "ToInteger", "ToByteArray", "ToBool":
 		typ := stackitem.IntegerT
 		switch name {
@@ -1113,6 +1257,14 @@ func (c *codegen) convertBuiltin(expr *ast.CallExpr) {
 		emit.Syscall(c.prog.BinWriter, "System.Contract.Call")
 	case "Equals":
 		emit.Opcode(c.prog.BinWriter, opcode.EQUAL)
+	case "Pow":
+		emit.Opcode(c.prog.BinWriter, opcode.POW)
+	case "Sqrt":
+		emit.Opcode(c.prog.BinWriter, opcode.SQRT)
+	case "ModMul":
+		emit.Opcode(c.prog.BinWriter, opcode.MODMUL)
+	case "ModPow":
+		emit.Opcode(c.prog.BinWriter, opcode.MODPOW)
 	case "FromAddress":
 		// We can be sure that this is a ast.BasicLit just containing a simple
 		// address string. Note that the string returned from calling Value will
@@ -1315,7 +1467,7 @@ func (c *codegen) compile(info *buildInfo, pkg *loader.PackageInfo) error {
 		}
 	}
 
-	c.traverseGlobals(mainFile)
+	c.traverseGlobals(mainFile, hasDefer(info.program.AllPackages))
 
 	// convert the entry point first.
 	c.convertFuncDecl(mainFile, main)
@@ -1360,6 +1512,8 @@ func newCodegen(info *buildInfo, pkg *loader.PackageInfo) *codegen {
 		labels:    map[labelWithType]uint16{},
 		typeInfo:  &pkg.Info,
 
+		exceptionIndex: -1,
+
 		sequencePoints: make(map[string][]DebugSeqPoint),
 	}
 }
@@ -1392,35 +1546,60 @@ func (c *codegen) resolveFuncDecls(f *ast.File, pkg *types.Package) {
 	}
 }
 
+// getRelativeOffset returns offset of the label with index stored in arg
+// relative to the instruction at ip.
+func (c *codegen) getRelativeOffset(arg []byte, ip int) (int, error) {
+	index := binary.LittleEndian.Uint16(arg)
+	if int(index) > len(c.l) {
+		return 0, fmt.Errorf("unexpected label number: %d (max %d)", index, len(c.l))
+	}
+	offset := c.l[index] - ip
+	if offset > math.MaxInt32 || offset < math.MinInt32 {
+		return 0, fmt.Errorf("label offset is too big at the instruction %d: %d (max %d, min %d)",
+			ip, offset, math.MaxInt32, math.MinInt32)
+	}
+	return offset, nil
+}
+
 func (c *codegen) writeJumps(b []byte) error {
 	ctx := vm.NewContext(b)
 	for op, _, err := ctx.Next(); err == nil && ctx.NextIP() < len(b); op, _, err = ctx.Next() {
 		switch op {
 		case opcode.JMP, opcode.JMPIFNOT, opcode.JMPIF, opcode.CALL,
 			opcode.JMPEQ, opcode.JMPNE,
-			opcode.JMPGT, opcode.JMPGE, opcode.JMPLE, opcode.JMPLT:
+			opcode.JMPGT, opcode.JMPGE, opcode.JMPLE, opcode.JMPLT,
+			opcode.TRY, opcode.ENDTRY:
 			panic("short jumps are not yet supported")
+		case opcode.TRYL:
+			// Compiler emits TRY_L with catch block only, finally offset is left zero.
+			nextIP := ctx.NextIP()
+			arg := b[nextIP-8:]
+			offset, err := c.getRelativeOffset(arg, nextIP-9)
+			if err != nil {
+				return err
+			}
+			binary.LittleEndian.PutUint32(arg, uint32(offset))
 		case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL,
 			opcode.JMPEQL, opcode.JMPNEL,
 			opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLEL, opcode.JMPLTL,
-			opcode.CALLL, opcode.PUSHA:
+			opcode.CALLL, opcode.PUSHA, opcode.ENDTRYL:
 			// we can't use arg returned by ctx.Next() because it is copied
 			nextIP := ctx.NextIP()
 			arg := b[nextIP-4:]
 
-			index := binary.LittleEndian.Uint16(arg)
-			if int(index) > len(c.l) {
-				return fmt.Errorf("unexpected label number: %d (max %d)", index, len(c.l))
-			}
 			var offset int
 			if op == opcode.PUSHA {
+				index := binary.LittleEndian.Uint16(arg)
+				if int(index) > len(c.l) {
+					return fmt.Errorf("unexpected label number: %d (max %d)", index, len(c.l))
+				}
 				offset = c.l[index]
 			} else {
-				offset = c.l[index] - nextIP + 5
-			}
-			if offset > math.MaxInt32 || offset < math.MinInt32 {
-				return fmt.Errorf("label offset is too big at the instruction %d: %d (max %d, min %d)",
-					nextIP-5, offset, math.MaxInt32, math.MinInt32)
+				var err error
+				offset, err = c.getRelativeOffset(arg, nextIP-5)
+				if err != nil {
+					return err
+				}
 			}
 			binary.LittleEndian.PutUint32(arg, uint32(offset))
 		}
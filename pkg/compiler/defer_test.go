@@ -0,0 +1,285 @@
+package compiler_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefer(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			return h() + a
+		}
+		func h() int {
+			defer f()
+			a = 2
+			return 1
+		}
+		func f() { a += 10 }`
+		eval(t, src, big.NewInt(13))
+	})
+	t.Run("ValueUnchanged", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			a = 10
+			return h()
+		}
+		func h() int {
+			defer f()
+			return a
+		}
+		func f() { a += 1 }`
+		eval(t, src, big.NewInt(10))
+	})
+	t.Run("MultipleDefers", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			h()
+			return a
+		}
+		func h() {
+			a = 1
+			defer f()
+			defer g()
+		}
+		func f() { a *= 2 }
+		func g() { a += 3 }`
+		eval(t, src, big.NewInt(8))
+	})
+	t.Run("EarlyReturn", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			return h(true) + a
+		}
+		func h(early bool) int {
+			defer f()
+			if early {
+				return 1
+			}
+			defer g()
+			return 2
+		}
+		func f() { a += 10 }
+		func g() { a += 20 }`
+		eval(t, src, big.NewInt(11))
+	})
+	t.Run("DeferredFunctionResult", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			return h()
+		}
+		func h() int {
+			defer f()
+			return 3
+		}
+		func f() int { a = 5; return a }`
+		eval(t, src, big.NewInt(3))
+	})
+	t.Run("Lambda", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			h()
+			return a
+		}
+		func h() {
+			defer func(x int) { a = x }(42)
+		}`
+		eval(t, src, big.NewInt(42))
+	})
+	t.Run("NotTopLevel", func(t *testing.T) {
+		src := `package main
+		func Main() int {
+			if true {
+				defer f()
+			}
+			return 1
+		}
+		func f() {}`
+		_, err := compiler.Compile(strings.NewReader(src))
+		require.Error(t, err)
+	})
+	t.Run("ConstantArguments", func(t *testing.T) {
+		src := `package main
+		var a int
+		const c = 3
+		func Main() int {
+			h()
+			return a
+		}
+		func h() {
+			defer f(c)
+			defer f(10)
+		}
+		func f(x int) { a = a*x + 1 }`
+		eval(t, src, big.NewInt(4))
+	})
+	t.Run("NonConstantArguments", func(t *testing.T) {
+		srcs := map[string]string{
+			"variable": `package main
+			func Main() int {
+				x := 1
+				defer f(x)
+				x = 2
+				return x
+			}
+			func f(x int) {}`,
+			"lambda": `package main
+			func Main() int {
+				x := 1
+				defer func(x int) {}(x)
+				return x
+			}`,
+			"method": `package main
+			type T struct{ a int }
+			func (t T) f() {}
+			func Main() int {
+				t := T{a: 1}
+				defer t.f()
+				return 1
+			}`,
+			"function variable": `package main
+			func Main() int {
+				f := func() {}
+				defer f()
+				return 1
+			}`,
+		}
+		for name, src := range srcs {
+			_, err := compiler.Compile(strings.NewReader(src))
+			require.Error(t, err, name)
+		}
+	})
+}
+
+func TestRecover(t *testing.T) {
+	t.Run("Recovered", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			return h() + a
+		}
+		func h() int {
+			defer func() {
+				recover()
+				a = 3
+			}()
+			a = 1
+			panic("oops")
+		}`
+		eval(t, src, big.NewInt(3))
+	})
+	t.Run("NamedResults", func(t *testing.T) {
+		src := `package main
+		func Main() int {
+			return h()
+		}
+		func h() (r int) {
+			defer recover()
+			r = 7
+			panic("oops")
+		}`
+		eval(t, src, big.NewInt(7))
+	})
+	t.Run("PanicMessage", func(t *testing.T) {
+		src := `package main
+		var msg interface{}
+		func Main() interface{} {
+			h()
+			return msg
+		}
+		func h() {
+			defer func() { msg = recover() }()
+			panic("oops")
+		}`
+		eval(t, src, []byte("oops"))
+	})
+	t.Run("NestedCall", func(t *testing.T) {
+		src := `package main
+		func Main() int {
+			return 1 + h()
+		}
+		func h() int {
+			defer recover()
+			return 2 + g()
+		}
+		func g() int {
+			panic("oops")
+		}`
+		eval(t, src, big.NewInt(1))
+	})
+	t.Run("NoPanic", func(t *testing.T) {
+		src := `package main
+		var msg interface{}
+		func Main() interface{} {
+			msg = 1
+			h()
+			return msg
+		}
+		func h() {
+			defer func() { msg = recover() }()
+		}`
+		eval(t, src, nil)
+	})
+	t.Run("CompareWithNil", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			h(true)
+			h(false)
+			return a
+		}
+		func h(fail bool) {
+			defer func() {
+				if recover() != nil {
+					a += 1
+				} else {
+					a += 10
+				}
+			}()
+			if fail {
+				panic("oops")
+			}
+		}`
+		eval(t, src, big.NewInt(11))
+	})
+	t.Run("NotRecovered", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			return h()
+		}
+		func h() int {
+			defer recover()
+			defer f()
+			panic("oops")
+		}
+		func f() { a = 1 }`
+		eval(t, src, big.NewInt(0))
+	})
+	t.Run("Unhandled", func(t *testing.T) {
+		src := `package main
+		var a int
+		func Main() int {
+			return h()
+		}
+		func h() int {
+			defer f()
+			panic("oops")
+		}
+		func f() { a = 1 }`
+		v := vmAndCompile(t, src)
+		require.Error(t, v.Run())
+		require.True(t, v.HasFailed())
+	})
+}
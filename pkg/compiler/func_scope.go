@@ -42,10 +42,24 @@ type funcScope struct {
 	// return value to the stack size.
 	voidCalls map[*ast.CallExpr]bool
 
+	// deferStack is a stack containing encountered `defer` statements.
+	deferStack []deferInfo
+
 	// Local variable counter.
 	i int
 }
 
+// deferInfo describes single `defer` statement of the function.
+type deferInfo struct {
+	// catchLabel is a label of the catch block for the corresponding TRY.
+	catchLabel uint16
+	// expr is the deferred call.
+	expr *ast.CallExpr
+	// lambdaLabel is a label of the deferred function literal if any.
+	lambdaLabel uint16
+	isLambda    bool
+}
+
 func newFuncScope(decl *ast.FuncDecl, label uint16) *funcScope {
 	var name string
 	if decl.Name != nil {
@@ -83,6 +97,9 @@ func (c *funcScope) analyzeVoidCalls(node ast.Node) bool {
 		}
 	case *ast.BinaryExpr:
 		return false
+	case *ast.DeferStmt:
+		// Results of deferred calls are dropped explicitly.
+		return false
 	case *ast.CallExpr:
 		c.voidCalls[n] = true
 		return false
@@ -92,8 +109,15 @@ func (c *funcScope) analyzeVoidCalls(node ast.Node) bool {
 
 func (c *funcScope) countLocals() int {
 	size := 0
+	hasDefer := false
 	ast.Inspect(c.decl, func(n ast.Node) bool {
 		switch n := n.(type) {
+		case *ast.DeferStmt:
+			// Single local is used to store stack depth for all defers.
+			if !hasDefer {
+				hasDefer = true
+				size++
+			}
 		case *ast.FuncType:
 			num := n.Results.NumFields()
 			if num != 0 && len(n.Results.List[0].Names) != 0 {
@@ -163,6 +163,23 @@ func TestBuiltinDoesNotCompile(t *testing.T) {
 	require.Equal(t, 1, retCount)
 }
 
+func TestMathBuiltins(t *testing.T) {
+	runMath := func(t *testing.T, expr string, result int64) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/util"
+		func Main() int {
+			return ` + expr + `
+		}`
+		eval(t, src, big.NewInt(result))
+	}
+
+	t.Run("Pow", func(t *testing.T) { runMath(t, "util.Pow(3, 4)", 81) })
+	t.Run("Sqrt", func(t *testing.T) { runMath(t, "util.Sqrt(17)", 4) })
+	t.Run("ModMul", func(t *testing.T) { runMath(t, "util.ModMul(7, 8, 10)", 6) })
+	t.Run("ModPow", func(t *testing.T) { runMath(t, "util.ModPow(5, 3, 11)", 4) })
+	t.Run("ModInverse", func(t *testing.T) { runMath(t, "util.ModPow(5, -1, 11)", 9) })
+}
+
 func TestInteropPackage(t *testing.T) {
 	src := `package foo
 	import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/block"
@@ -19,12 +19,18 @@ type ApplicationConfiguration struct {
 	MaxPeers          int                     `yaml:"MaxPeers"`
 	MinPeers          int                     `yaml:"MinPeers"`
 	NodePort          uint16                  `yaml:"NodePort"`
+	Notary            NotaryConfiguration     `yaml:"Notary"`
+	Oracle            OracleConfiguration     `yaml:"Oracle"`
 	PingInterval      time.Duration           `yaml:"PingInterval"`
 	PingTimeout       time.Duration           `yaml:"PingTimeout"`
 	Pprof             metrics.Config          `yaml:"Pprof"`
 	Prometheus        metrics.Config          `yaml:"Prometheus"`
 	ProtoTickInterval time.Duration           `yaml:"ProtoTickInterval"`
 	Relay             bool                    `yaml:"Relay"`
-	RPC               rpc.Config              `yaml:"RPC"`
-	UnlockWallet      wallet.Config           `yaml:"UnlockWallet"`
+	// RemoveUntraceableBlocks enables removal of transactions and
+	// application logs of blocks older than MaxTraceableBlocks, only
+	// headers are kept for them.
+	RemoveUntraceableBlocks bool          `yaml:"RemoveUntraceableBlocks"`
+	RPC                     rpc.Config    `yaml:"RPC"`
+	UnlockWallet            wallet.Config `yaml:"UnlockWallet"`
 }
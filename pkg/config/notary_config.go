@@ -0,0 +1,6 @@
+package config
+
+// NotaryConfiguration is a config for the notary service.
+type NotaryConfiguration struct {
+	Enabled bool `yaml:"Enabled"`
+}
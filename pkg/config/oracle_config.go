@@ -0,0 +1,19 @@
+package config
+
+import (
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// OracleConfiguration is a config for the oracle service.
+type OracleConfiguration struct {
+	Enabled bool `yaml:"Enabled"`
+	// AllowPrivateHost allows requests to loopback and private network
+	// addresses. It's intended to be used in test setups only.
+	AllowPrivateHost bool `yaml:"AllowPrivateHost"`
+	// RequestTimeout is a timeout for a single request in seconds.
+	RequestTimeout time.Duration `yaml:"RequestTimeout"`
+	// UnlockWallet is a wallet with oracle node key.
+	UnlockWallet wallet.Config `yaml:"UnlockWallet"`
+}
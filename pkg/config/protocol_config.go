@@ -23,15 +23,27 @@ type (
 		// transactions exceeding the MaxFreeTransactionSize.
 		FeePerExtraByte float64 `yaml:"FeePerExtraByte"`
 		// FreeGasLimit is an amount of GAS which can be spent for free.
-		FreeGasLimit            util.Fixed8 `yaml:"FreeGasLimit"`
-		LowPriorityThreshold    float64     `yaml:"LowPriorityThreshold"`
-		Magic                   NetMode     `yaml:"Magic"`
-		MaxTransactionsPerBlock int         `yaml:"MaxTransactionsPerBlock"`
+		FreeGasLimit         util.Fixed8 `yaml:"FreeGasLimit"`
+		LowPriorityThreshold float64     `yaml:"LowPriorityThreshold"`
+		Magic                NetMode     `yaml:"Magic"`
+		// MaxTransactionsPerBlock is the initial value for the Policy
+		// contract setting, it can be changed later by the committee. 0
+		// means there is no limit.
+		MaxTransactionsPerBlock int `yaml:"MaxTransactionsPerBlock"`
 		// Maximum size of low priority transaction in bytes.
 		MaxFreeTransactionSize int `yaml:"MaxFreeTransactionSize"`
 		// Maximum number of low priority transactions accepted into block.
 		MaxFreeTransactionsPerBlock int `yaml:"MaxFreeTransactionsPerBlock"`
-		MemPoolSize                 int `yaml:"MemPoolSize"`
+		// MaxTraceableBlocks is the length of the chain accessible to smart
+		// contracts, 0 means unlimited.
+		MaxTraceableBlocks uint32 `yaml:"MaxTraceableBlocks"`
+		MemPoolSize        int    `yaml:"MemPoolSize"`
+		// AccountTransactionIndex enables indexing of transactions by their
+		// sender and cosigners (see Blockchain.GetAccountTransactions).
+		AccountTransactionIndex bool `yaml:"AccountTransactionIndex"`
+		// NotificationIndex enables indexing of notifications by contract
+		// and event name (see Blockchain.GetContractNotifications).
+		NotificationIndex bool `yaml:"NotificationIndex"`
 		// SaveStorageBatch enables storage batch saving before every persist.
 		SaveStorageBatch  bool     `yaml:"SaveStorageBatch"`
 		SecondsPerBlock   int      `yaml:"SecondsPerBlock"`
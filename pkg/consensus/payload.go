@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -214,8 +215,8 @@ func (p *Payload) Verify(scriptHash util.Uint160) bool {
 
 	v := vm.New()
 	v.RegisterInteropGetter(crypto.GetInterop(&interop.Context{Container: p}))
-	v.LoadScript(verification)
-	v.LoadScript(p.Witness.InvocationScript)
+	v.LoadScriptWithFlags(verification, smartcontract.ReadOnly)
+	v.LoadScriptWithFlags(p.Witness.InvocationScript, smartcontract.NoneFlag)
 
 	err = v.Run()
 	if err != nil || v.HasFailed() || v.Estack().Len() != 1 {
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"sort"
@@ -13,17 +14,22 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -32,9 +38,13 @@ import (
 // Tuning parameters.
 const (
 	headerBatchCount = 2000
-	version          = "0.1.0"
+	version          = "0.1.1"
 
 	defaultMemPoolSize = 50000
+
+	// maxVerificationGAS is the maximum amount of GAS which can be spent
+	// on a single witness verification when calculating network fee.
+	maxVerificationGAS util.Fixed8 = 50000000 // 0.5 GAS
 )
 
 var (
@@ -51,6 +61,12 @@ var (
 	// ErrInvalidBlockIndex is returned when trying to add block with index
 	// other than expected height of the blockchain.
 	ErrInvalidBlockIndex error = errors.New("invalid block index")
+	// ErrNotificationIndexDisabled is returned when trying to get indexed
+	// notifications with NotificationIndex setting disabled.
+	ErrNotificationIndexDisabled = errors.New("notification index is disabled")
+	// ErrAccountTransactionIndexDisabled is returned when trying to get
+	// account transactions with AccountTransactionIndex setting disabled.
+	ErrAccountTransactionIndexDisabled = errors.New("account transaction index is disabled")
 )
 var (
 	genAmount         = []int{8, 7, 6, 5, 4, 3, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}
@@ -64,6 +80,10 @@ var (
 type Blockchain struct {
 	config config.ProtocolConfiguration
 
+	// removeUntraceable enables removal of blocks older than
+	// MaxTraceableBlocks, see SetRemoveUntraceableBlocks.
+	removeUntraceable bool
+
 	// The only way chain state changes is by adding blocks, so we can't
 	// allow concurrent block additions. It differs from the next lock in
 	// that it's only for AddBlock method itself, the chain state is
@@ -123,9 +143,10 @@ type Blockchain struct {
 	contracts native.Contracts
 
 	// Notification subsystem.
-	events  chan bcEvent
-	subCh   chan interface{}
-	unsubCh chan interface{}
+	events       chan bcEvent
+	headerEvents chan []*block.Header
+	subCh        chan interface{}
+	unsubCh      chan interface{}
 }
 
 // bcEvent is an internal event generated by the Blockchain and then
@@ -178,6 +199,7 @@ func NewBlockchain(s storage.Store, cfg config.ProtocolConfiguration, log *zap.L
 		keyCache:      make(map[util.Uint160]map[string]*keys.PublicKey),
 		log:           log,
 		events:        make(chan bcEvent),
+		headerEvents:  make(chan []*block.Header),
 		subCh:         make(chan interface{}),
 		unsubCh:       make(chan interface{}),
 
@@ -283,6 +305,24 @@ func (bc *Blockchain) init() error {
 	return nil
 }
 
+// SetRemoveUntraceableBlocks enables or disables removal of transactions and
+// application logs of blocks older than MaxTraceableBlocks (only headers are
+// kept for them). It's a node setting rather than a protocol one, so it's not
+// a part of ProtocolConfiguration. It must be called before Run.
+func (bc *Blockchain) SetRemoveUntraceableBlocks(enable bool) error {
+	if enable && bc.config.MaxTraceableBlocks == 0 {
+		return errors.New("RemoveUntraceableBlocks is enabled, but MaxTraceableBlocks is not set")
+	}
+	bc.removeUntraceable = enable
+	return nil
+}
+
+// RemovesUntraceableBlocks returns true if transactions and application logs
+// of untraceable blocks are removed (see SetRemoveUntraceableBlocks).
+func (bc *Blockchain) RemovesUntraceableBlocks() bool {
+	return bc.removeUntraceable
+}
+
 // Run runs chain loop, it needs to be run as goroutine and executing it is
 // critical for correct Blockchain operation.
 func (bc *Blockchain) Run() {
@@ -298,6 +338,8 @@ func (bc *Blockchain) Run() {
 		close(bc.runToExitCh)
 	}()
 	go bc.notificationDispatcher()
+	bc.memPool.RunSubscriptions()
+	defer bc.memPool.StopSubscriptions()
 	for {
 		select {
 		case <-bc.stopCh:
@@ -324,6 +366,7 @@ func (bc *Blockchain) notificationDispatcher() {
 		// for ease of management (not a lot of subscriptions is really
 		// expected, but maps are convenient for adding/deleting elements).
 		blockFeed        = make(map[chan<- *block.Block]bool)
+		headerFeed       = make(map[chan<- *block.Header]bool)
 		txFeed           = make(map[chan<- *transaction.Transaction]bool)
 		notificationFeed = make(map[chan<- *state.NotificationEvent]bool)
 		executionFeed    = make(map[chan<- *state.AppExecResult]bool)
@@ -336,6 +379,8 @@ func (bc *Blockchain) notificationDispatcher() {
 			switch ch := sub.(type) {
 			case chan<- *block.Block:
 				blockFeed[ch] = true
+			case chan<- *block.Header:
+				headerFeed[ch] = true
 			case chan<- *transaction.Transaction:
 				txFeed[ch] = true
 			case chan<- *state.NotificationEvent:
@@ -349,6 +394,8 @@ func (bc *Blockchain) notificationDispatcher() {
 			switch ch := unsub.(type) {
 			case chan<- *block.Block:
 				delete(blockFeed, ch)
+			case chan<- *block.Header:
+				delete(headerFeed, ch)
 			case chan<- *transaction.Transaction:
 				delete(txFeed, ch)
 			case chan<- *state.NotificationEvent:
@@ -387,6 +434,12 @@ func (bc *Blockchain) notificationDispatcher() {
 			for ch := range blockFeed {
 				ch <- event.block
 			}
+		case headers := <-bc.headerEvents:
+			for _, h := range headers {
+				for ch := range headerFeed {
+					ch <- h
+				}
+			}
 		}
 	}
 }
@@ -474,6 +527,7 @@ func (bc *Blockchain) addHeaders(verify bool, headers ...*block.Header) (err err
 		}
 	}
 
+	var added []*block.Header
 	bc.headersOp <- func(headerList *HeaderHashList) {
 		oldlen := headerList.Len()
 		for _, h := range headers {
@@ -494,11 +548,13 @@ func (bc *Blockchain) addHeaders(verify bool, headers ...*block.Header) (err err
 			if err = bc.processHeader(h, batch, headerList); err != nil {
 				return
 			}
+			added = append(added, h)
 		}
 
 		if oldlen != headerList.Len() {
 			updateHeaderHeightMetric(headerList.Len() - 1)
 			if err = bc.dao.Store.PutBatch(batch); err != nil {
+				added = nil
 				return
 			}
 			bc.log.Debug("done processing headers",
@@ -508,6 +564,12 @@ func (bc *Blockchain) addHeaders(verify bool, headers ...*block.Header) (err err
 		}
 	}
 	<-bc.headersOpDone
+	if len(added) != 0 {
+		select {
+		case bc.headerEvents <- added:
+		case <-bc.stopCh:
+		}
+	}
 	return err
 }
 
@@ -545,8 +607,10 @@ func (bc *Blockchain) processHeader(h *block.Header, batch storage.Batch, header
 // is happening here, quite allot as you can see :). If things are wired together
 // and all tests are in place, we can make a more optimized and cleaner implementation.
 func (bc *Blockchain) storeBlock(block *block.Block) error {
-	cache := dao.NewCached(bc.dao)
+	blockDAO := dao.NewSimple(bc.dao.Store)
+	cache := dao.NewCached(blockDAO)
 	appExecResults := make([]*state.AppExecResult, 0, len(block.Transactions))
+	var notificationNum uint32
 	if err := cache.StoreAsBlock(block); err != nil {
 		return err
 	}
@@ -555,10 +619,15 @@ func (bc *Blockchain) storeBlock(block *block.Block) error {
 		return err
 	}
 
-	for _, tx := range block.Transactions {
+	for i, tx := range block.Transactions {
 		if err := cache.StoreAsTransaction(tx, block.Index); err != nil {
 			return err
 		}
+		if bc.config.AccountTransactionIndex {
+			if err := storeAccountTransactions(cache, tx, block.Index, uint32(i)); err != nil {
+				return errors.Wrap(err, "failed to index transaction")
+			}
+		}
 
 		systemInterop := bc.newInteropContext(trigger.Application, cache, block, tx)
 		v := SpawnVM(systemInterop)
@@ -576,6 +645,19 @@ func (bc *Blockchain) storeBlock(block *block.Block) error {
 			}
 			for _, note := range systemInterop.Notifications {
 				arr, ok := note.Item.Value().([]stackitem.Item)
+				if bc.config.NotificationIndex && ok && len(arr) != 0 {
+					if name, ok := arr[0].Value().([]byte); ok {
+						err := cache.PutContractNotification(string(name), notificationNum, &state.ContractNotification{
+							NotificationEvent: note,
+							BlockIndex:        block.Index,
+							TxHash:            tx.Hash(),
+						})
+						if err != nil {
+							return errors.Wrap(err, "failed to index notification")
+						}
+						notificationNum++
+					}
+				}
 				if !ok || len(arr) != 4 {
 					continue
 				}
@@ -639,12 +721,27 @@ func (bc *Blockchain) storeBlock(block *block.Block) error {
 		}
 	}
 
+	if bc.removeUntraceable && block.Index >= bc.config.MaxTraceableBlocks {
+		hash := bc.GetHeaderHash(int(block.Index - bc.config.MaxTraceableBlocks))
+		if err := cache.DeleteBlock(hash); err != nil {
+			return errors.Wrap(err, "failed to remove untraceable block")
+		}
+	}
+
 	if bc.config.SaveStorageBatch {
 		bc.lastBatch = cache.DAO.GetBatch()
 	}
 
-	bc.lock.Lock()
 	_, err := cache.Persist()
+	if err != nil {
+		return err
+	}
+	if err := bc.updateStateRoot(blockDAO, block.Index); err != nil {
+		return errors.Wrap(err, "failed to update state root")
+	}
+
+	bc.lock.Lock()
+	_, err = blockDAO.Persist()
 	if err != nil {
 		bc.lock.Unlock()
 		return err
@@ -664,6 +761,60 @@ func (bc *Blockchain) storeBlock(block *block.Block) error {
 	return nil
 }
 
+// updateStateRoot applies all state changes accumulated in d to the MPT built
+// for the previous block and stores the resulting root for the given height.
+func (bc *Blockchain) updateStateRoot(d *dao.Simple, index uint32) error {
+	var root mpt.Node
+	if index > 0 {
+		prev, err := d.GetStateRoot(index - 1)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get state root for block %d", index-1)
+		}
+		if !prev.Root.Equals(util.Uint256{}) {
+			root = mpt.NewHashNode(prev.Root)
+		}
+	}
+	tr := mpt.NewTrie(root, d.Store)
+	batch := d.GetBatch()
+	for _, kv := range batch.Put {
+		if !isStateKey(kv.Key) {
+			continue
+		}
+		if err := tr.Put(kv.Key, kv.Value); err != nil {
+			return err
+		}
+	}
+	for _, kv := range batch.Deleted {
+		if !kv.Exists || !isStateKey(kv.Key) {
+			continue
+		}
+		if err := tr.Delete(kv.Key); err != nil && err != mpt.ErrNotFound {
+			return err
+		}
+	}
+	if err := tr.Flush(); err != nil {
+		return err
+	}
+	return d.PutStateRoot(&state.MPTRoot{
+		Index: index,
+		Root:  tr.StateRoot(),
+	})
+}
+
+// isStateKey checks whether the given DB key belongs to the part of the state
+// covered by the state root (contract storage, contracts and accounts).
+func isStateKey(key []byte) bool {
+	if len(key) == 0 {
+		return false
+	}
+	switch storage.KeyPrefix(key[0]) {
+	case storage.STStorage, storage.STContract, storage.STAccount:
+		return true
+	default:
+		return false
+	}
+}
+
 func parseUint160(addr []byte) util.Uint160 {
 	if u, err := util.Uint160DecodeBytesBE(addr); err == nil {
 		return u
@@ -745,6 +896,70 @@ func (bc *Blockchain) GetNEP5TransferLog(acc util.Uint160) *state.NEP5TransferLo
 	return result
 }
 
+// ForEachNEP5Transfer executes f for each NEP5 transfer of the acc starting
+// from the latest one (walking transfer log batches backwards) until f returns
+// false or an error.
+func (bc *Blockchain) ForEachNEP5Transfer(acc util.Uint160, f func(*state.NEP5Transfer) (bool, error)) error {
+	balances, err := bc.dao.GetNEP5Balances(acc)
+	if err != nil {
+		return err
+	}
+	for i := int64(balances.NextTransferBatch); i >= 0; i-- {
+		lg, err := bc.dao.GetNEP5TransferLog(acc, uint32(i))
+		if err != nil {
+			return err
+		}
+		cont, err := lg.ForEachReverse(f)
+		if err != nil || !cont {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeAccountTransactions adds the transaction which is n-th in the block
+// with the given index to the account transactions index of its sender and
+// cosigners.
+func storeAccountTransactions(d dao.DAO, tx *transaction.Transaction, index uint32, n uint32) error {
+	at := &state.AccountTransaction{
+		BlockIndex: index,
+		TxHash:     tx.Hash(),
+	}
+	if err := d.PutAccountTransaction(tx.Sender, n, at); err != nil {
+		return err
+	}
+	for i := range tx.Cosigners {
+		if tx.Cosigners[i].Account == tx.Sender {
+			continue
+		}
+		if err := d.PutAccountTransaction(tx.Cosigners[i].Account, n, at); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetAccountTransactions returns transactions the account was a sender or a
+// cosigner of (newest first), see dao.GetAccountTransactions for details. It
+// only works with AccountTransactionIndex setting enabled.
+func (bc *Blockchain) GetAccountTransactions(acc util.Uint160, skip, limit int) ([]state.AccountTransaction, bool, error) {
+	if !bc.config.AccountTransactionIndex {
+		return nil, false, ErrAccountTransactionIndexDisabled
+	}
+	return bc.dao.GetAccountTransactions(acc, skip, limit)
+}
+
+// GetContractNotifications returns notifications with the given event name
+// emitted by the contract in blocks from `from` to `to` (inclusive), see
+// dao.GetContractNotifications for details. It only works with
+// NotificationIndex setting enabled.
+func (bc *Blockchain) GetContractNotifications(contract util.Uint160, name string, from, to uint32, skip, limit int) ([]state.ContractNotification, bool, error) {
+	if !bc.config.NotificationIndex {
+		return nil, false, ErrNotificationIndexDisabled
+	}
+	return bc.dao.GetContractNotifications(contract, name, from, to, skip, limit)
+}
+
 // GetNEP5Balances returns NEP5 balances for the acc.
 func (bc *Blockchain) GetNEP5Balances(acc util.Uint160) *state.NEP5Balances {
 	bs, err := bc.dao.GetNEP5Balances(acc)
@@ -839,6 +1054,17 @@ func (bc *Blockchain) GetAppExecResult(hash util.Uint256) (*state.AppExecResult,
 	return bc.dao.GetAppExecResult(hash)
 }
 
+// GetStateRoot returns state root for the given height.
+func (bc *Blockchain) GetStateRoot(height uint32) (*state.MPTRoot, error) {
+	return bc.dao.GetStateRoot(height)
+}
+
+// GetStateProof returns proof of having key in the MPT with the specified root.
+func (bc *Blockchain) GetStateProof(root util.Uint256, key []byte) ([][]byte, error) {
+	tr := mpt.NewTrie(mpt.NewHashNode(root), storage.NewMemCachedStore(bc.dao.Store))
+	return tr.GetProof(key)
+}
+
 // GetStorageItem returns an item from storage.
 func (bc *Blockchain) GetStorageItem(scripthash util.Uint160, key []byte) *state.StorageItem {
 	return bc.dao.GetStorageItem(scripthash, key)
@@ -849,7 +1075,15 @@ func (bc *Blockchain) GetStorageItems(hash util.Uint160) (map[string]*state.Stor
 	return bc.dao.GetStorageItems(hash)
 }
 
-// GetBlock returns a Block by the given hash.
+// SeekStorageItems calls f for every storage item of the given contract with
+// the given key prefix starting from the prefix+start key in the ascending key
+// order until f returns false. Keys passed to f include the prefix.
+func (bc *Blockchain) SeekStorageItems(hash util.Uint160, prefix, start []byte, f func(k []byte, si *state.StorageItem) bool) error {
+	return bc.dao.SeekStorageItems(hash, prefix, start, f)
+}
+
+// GetBlock returns a Block by the given hash. dao.ErrHeaderOnly is returned
+// for blocks removed because of SetRemoveUntraceableBlocks.
 func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	topBlock := bc.topBlock.Load()
 	if topBlock != nil {
@@ -880,11 +1114,7 @@ func (bc *Blockchain) GetHeader(hash util.Uint256) (*block.Header, error) {
 			return tb.Header(), nil
 		}
 	}
-	block, err := bc.dao.GetBlock(hash)
-	if err != nil {
-		return nil, err
-	}
-	return block.Header(), nil
+	return bc.dao.GetHeader(hash)
 }
 
 // HasTransaction returns true if the blockchain contains he given
@@ -971,6 +1201,15 @@ func (bc *Blockchain) SubscribeForBlocks(ch chan<- *block.Block) {
 	bc.subCh <- ch
 }
 
+// SubscribeForHeaders adds given channel to new header event broadcasting, so
+// when there is a new header added to the chain you'll receive it via this
+// channel. Headers are usually added before their blocks. Make sure it's read
+// from regularly as not reading these events might affect other Blockchain
+// functions.
+func (bc *Blockchain) SubscribeForHeaders(ch chan<- *block.Header) {
+	bc.subCh <- ch
+}
+
 // SubscribeForTransactions adds given channel to new transaction event
 // broadcasting, so when there is a new transaction added to the chain (in a
 // block) you'll receive it via this channel. Make sure it's read from regularly
@@ -1004,6 +1243,13 @@ func (bc *Blockchain) UnsubscribeFromBlocks(ch chan<- *block.Block) {
 	bc.unsubCh <- ch
 }
 
+// UnsubscribeFromHeaders unsubscribes given channel from new header
+// notifications, you can close it afterwards. Passing non-subscribed channel is
+// a no-op.
+func (bc *Blockchain) UnsubscribeFromHeaders(ch chan<- *block.Header) {
+	bc.unsubCh <- ch
+}
+
 // UnsubscribeFromTransactions unsubscribes given channel from new transaction
 // notifications, you can close it afterwards. Passing non-subscribed channel is
 // a no-op.
@@ -1058,10 +1304,10 @@ func (bc *Blockchain) CalculateClaimable(value int64, startHeight, endHeight uin
 	return amount * util.Fixed8(value)
 }
 
-// FeePerByte returns transaction network fee per byte.
-// TODO: should be implemented as part of PolicyContract
+// FeePerByte returns transaction network fee per byte as set in the Policy
+// contract.
 func (bc *Blockchain) FeePerByte() util.Fixed8 {
-	return util.Fixed8(1000)
+	return util.Fixed8(bc.contracts.Policy.GetFeePerByteInternal(bc.dao))
 }
 
 // IsLowPriority checks given fee for being less than configured
@@ -1076,11 +1322,31 @@ func (bc *Blockchain) GetMemPool() *mempool.Pool {
 }
 
 // ApplyPolicyToTxSet applies configured policies to given transaction set. It
-// expects slice to be ordered by fee and returns a subslice of it.
+// expects slice to be ordered by fee and returns a new slice with transactions
+// that can be included into block (the one given is not modified). Transaction
+// count and block size limits as well as blocked accounts are taken from the
+// Policy contract.
 func (bc *Blockchain) ApplyPolicyToTxSet(txes []*transaction.Transaction) []*transaction.Transaction {
-	if bc.config.MaxTransactionsPerBlock != 0 && len(txes) > bc.config.MaxTransactionsPerBlock {
-		txes = txes[:bc.config.MaxTransactionsPerBlock]
+	maxTx := int(bc.contracts.Policy.GetMaxTransactionsPerBlockInternal(bc.dao))
+	if maxTx != 0 && len(txes) > maxTx {
+		txes = txes[:maxTx]
+	}
+	maxBlockSize := int(bc.contracts.Policy.GetMaxBlockSizeInternal(bc.dao))
+	// Approximate header size, it doesn't really matter much as transactions
+	// take most of the space.
+	blockSize := io.GetVarSize(new(block.Block)) + io.GetVarSize(len(txes))
+	result := make([]*transaction.Transaction, 0, len(txes))
+	for _, tx := range txes {
+		if bc.checkPolicy(tx) != nil {
+			continue
+		}
+		blockSize += io.GetVarSize(tx)
+		if blockSize > maxBlockSize {
+			break
+		}
+		result = append(result, tx)
 	}
+	txes = result
 	maxFree := bc.config.MaxFreeTransactionsPerBlock
 	if maxFree != 0 {
 		lowStart := sort.Search(len(txes), func(i int) bool {
@@ -1093,6 +1359,23 @@ func (bc *Blockchain) ApplyPolicyToTxSet(txes []*transaction.Transaction) []*tra
 	return txes
 }
 
+// checkPolicy checks transaction's sender and cosigners against the list of
+// blocked accounts from the Policy contract.
+func (bc *Blockchain) checkPolicy(t *transaction.Transaction) error {
+	cosigners := make([]util.Uint160, len(t.Cosigners))
+	for i := range t.Cosigners {
+		cosigners[i] = t.Cosigners[i].Account
+	}
+	ok, err := bc.contracts.Policy.CheckPolicy(bc.dao, t.Sender, cosigners)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrPolicy
+	}
+	return nil
+}
+
 func (bc *Blockchain) verifyHeader(currHeader, prevHeader *block.Header) error {
 	if prevHeader.Hash() != currHeader.PrevHash {
 		return errors.New("previous header hash doesn't match")
@@ -1109,7 +1392,7 @@ func (bc *Blockchain) verifyHeader(currHeader, prevHeader *block.Header) error {
 // verifyTx verifies whether a transaction is bonafide or not.
 func (bc *Blockchain) verifyTx(t *transaction.Transaction, block *block.Block) error {
 	height := bc.BlockHeight()
-	if t.ValidUntilBlock <= height || t.ValidUntilBlock > height+transaction.MaxValidUntilBlockIncrement {
+	if t.ValidUntilBlock <= height || t.ValidUntilBlock > height+bc.maxValidUntilBlockIncrement() {
 		return errors.Errorf("transaction has expired. ValidUntilBlock = %d, current height = %d", t.ValidUntilBlock, height)
 	}
 	balance := bc.GetUtilityTokenBalance(t.Sender)
@@ -1126,9 +1409,12 @@ func (bc *Blockchain) verifyTx(t *transaction.Transaction, block *block.Block) e
 	if netFee < 0 {
 		return errors.Errorf("insufficient funds: net fee is %v, need %v", t.NetworkFee, needNetworkFee)
 	}
+	if err := bc.checkPolicy(t); err != nil {
+		return err
+	}
 	if block == nil {
 		if ok := bc.memPool.Verify(t, bc); !ok {
-			return errors.New("invalid transaction due to conflicts with the memory pool")
+			return mempool.ErrConflict
 		}
 	}
 
@@ -1136,15 +1422,33 @@ func (bc *Blockchain) verifyTx(t *transaction.Transaction, block *block.Block) e
 		if a.Usage == transaction.ECDH02 || a.Usage == transaction.ECDH03 {
 			return errors.Errorf("invalid attribute's usage = %s ", a.Usage)
 		}
+		if a.Usage == transaction.OracleResponse {
+			if err := bc.contracts.Oracle.VerifyResponse(bc.dao, t); err != nil {
+				return errors.Wrap(err, "invalid oracle response")
+			}
+			break
+		}
 	}
 
 	return bc.verifyTxWitnesses(t, block)
 }
 
+// maxValidUntilBlockIncrement returns the maximum ValidUntilBlock increment.
+// It's limited by MaxTraceableBlocks so that transactions can't outlive the
+// information about them stored in the chain.
+func (bc *Blockchain) maxValidUntilBlockIncrement() uint32 {
+	max := uint32(transaction.MaxValidUntilBlockIncrement)
+	if bc.config.MaxTraceableBlocks != 0 && bc.config.MaxTraceableBlocks < max {
+		max = bc.config.MaxTraceableBlocks
+	}
+	return max
+}
+
 // isTxStillRelevant is a callback for mempool transaction filtering after the
 // new block addition. It returns false for transactions already present in the
 // chain (added by the new block), transactions using some inputs that are
-// already used (double spends) and does witness reverification for non-standard
+// already used (double spends), transactions not satisfying current Policy
+// contract settings and does witness reverification for non-standard
 // contracts. It operates under the assumption that full transaction verification
 // was already done so we don't need to check basic things like size, input/output
 // correctness, etc.
@@ -1154,6 +1458,11 @@ func (bc *Blockchain) isTxStillRelevant(t *transaction.Transaction) bool {
 	if bc.dao.HasTransaction(t.Hash()) {
 		return false
 	}
+	// Policy settings could have been changed by the new block.
+	if t.NetworkFee < util.Fixed8(int64(io.GetVarSize(t))*int64(bc.FeePerByte())) ||
+		bc.checkPolicy(t) != nil {
+		return false
+	}
 	for i := range t.Scripts {
 		if !vm.IsStandardContract(t.Scripts[i].VerificationScript) {
 			recheckWitness = true
@@ -1177,7 +1486,9 @@ func (bc *Blockchain) VerifyTx(t *transaction.Transaction, block *block.Block) e
 	return bc.verifyTx(t, block)
 }
 
-// PoolTx verifies and tries to add given transaction into the mempool.
+// PoolTx verifies and tries to add given transaction into the mempool. Apart
+// from ErrAlreadyExists, ErrOOM, ErrPolicy and verification errors it can
+// return mempool.ErrConflict and mempool.ErrDup.
 func (bc *Blockchain) PoolTx(t *transaction.Transaction) error {
 	bc.lock.RLock()
 	defer bc.lock.RUnlock()
@@ -1201,8 +1512,6 @@ func (bc *Blockchain) PoolTx(t *transaction.Transaction) error {
 		switch err {
 		case mempool.ErrOOM:
 			return ErrOOM
-		case mempool.ErrConflict:
-			return ErrAlreadyExists
 		default:
 			return err
 		}
@@ -1220,6 +1529,22 @@ func (bc *Blockchain) GetValidators() ([]*keys.PublicKey, error) {
 	return bc.contracts.NEO.GetNextBlockValidatorsInternal(bc, bc.dao)
 }
 
+// GetOracleNodes returns public keys of the designated oracle nodes.
+func (bc *Blockchain) GetOracleNodes() (keys.PublicKeys, error) {
+	return bc.contracts.Oracle.GetOracleNodesInternal(bc.dao)
+}
+
+// GetOracleRequests returns all pending oracle requests indexed by their ids.
+func (bc *Blockchain) GetOracleRequests() (map[uint64]*state.OracleRequest, error) {
+	return bc.contracts.Oracle.GetRequestsInternal(bc.dao)
+}
+
+// GetOracleResponseScript returns script to be used in oracle response
+// transactions.
+func (bc *Blockchain) GetOracleResponseScript() []byte {
+	return bc.contracts.Oracle.GetResponseScript()
+}
+
 // GetEnrollments returns all registered validators.
 func (bc *Blockchain) GetEnrollments() ([]state.Validator, error) {
 	return bc.contracts.NEO.GetRegisteredValidators(bc.dao)
@@ -1252,6 +1577,188 @@ func (bc *Blockchain) GetTestVM() *vm.VM {
 	return vm
 }
 
+// TestInvoke runs the given script in a test VM against the current state
+// with the given GAS limit (no limit if it's 0) and returns the execution
+// result. tx is used as the script container and it can be nil, it allows
+// to pass signers for witness checks.
+func (bc *Blockchain) TestInvoke(script []byte, tx *transaction.Transaction, gasLimit util.Fixed8) *state.TestExecResult {
+	return bc.testInvoke(bc.dao, script, tx, gasLimit)
+}
+
+// TestInvokeAt is similar to TestInvoke, but uses the state as it was after
+// processing the block with the given index (see GetTestVMAt).
+func (bc *Blockchain) TestInvokeAt(height uint32, script []byte, tx *transaction.Transaction, gasLimit util.Fixed8) (*state.TestExecResult, error) {
+	d, err := bc.getStateDAO(height)
+	if err != nil {
+		return nil, err
+	}
+	return bc.testInvoke(d, script, tx, gasLimit), nil
+}
+
+func (bc *Blockchain) testInvoke(d dao.DAO, script []byte, tx *transaction.Transaction, gasLimit util.Fixed8) *state.TestExecResult {
+	systemInterop := bc.newInteropContext(trigger.Application, d, nil, tx)
+	v := SpawnVM(systemInterop)
+	v.SetPriceGetter(getPrice)
+	v.SetGasLimit(gasLimit)
+	v.LoadScript(script)
+	err := v.Run()
+
+	res := &state.TestExecResult{
+		AppExecResult: state.AppExecResult{
+			Trigger:     trigger.Application,
+			VMState:     v.State(),
+			GasConsumed: v.GasConsumed(),
+			Stack:       v.Estack().ToContractParameters(),
+			Events:      systemInterop.Notifications,
+		},
+		Logs:           systemInterop.Logs,
+		StorageChanges: getStorageChanges(systemInterop.DAO.GetBatch()),
+	}
+	var i int
+	v.Estack().IterBack(func(e *vm.Element) {
+		if item := e.Item(); vm.IsIterator(item) {
+			if res.Iterators == nil {
+				res.Iterators = make(map[int]stackitem.Item)
+			}
+			res.Iterators[i] = item
+		}
+		i++
+	})
+	if tx != nil {
+		res.TxHash = tx.Hash()
+	}
+	if err != nil {
+		res.FaultException = err.Error()
+	}
+	return res
+}
+
+// getStorageChanges returns all contract storage changes from the batch.
+func getStorageChanges(batch *storage.MemBatch) []state.StorageChange {
+	var res []state.StorageChange
+	add := func(k, v []byte) {
+		if len(k) < 1+util.Uint160Size || storage.KeyPrefix(k[0]) != storage.STStorage {
+			return
+		}
+		h, err := util.Uint160DecodeBytesLE(k[1 : 1+util.Uint160Size])
+		if err != nil {
+			return
+		}
+		res = append(res, state.StorageChange{ScriptHash: h, Key: k[1+util.Uint160Size:], Value: v})
+	}
+	for _, kv := range batch.Put {
+		var si state.StorageItem
+		r := io.NewBinReaderFromBuf(kv.Value)
+		si.DecodeBinary(r)
+		if r.Err == nil {
+			add(kv.Key, si.Value)
+		}
+	}
+	for _, kv := range batch.Deleted {
+		if kv.Exists {
+			add(kv.Key, nil)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if c := bytes.Compare(res[i].ScriptHash[:], res[j].ScriptHash[:]); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(res[i].Key, res[j].Key) < 0
+	})
+	return res
+}
+
+// GetTestVMAt returns a VM setup for a test run of some sort of code against
+// the state as it was after processing the block with the given index. Only
+// the state covered by the state root is historical, everything else
+// (including chain height) is the current one.
+func (bc *Blockchain) GetTestVMAt(height uint32) (*vm.VM, error) {
+	d, err := bc.getStateDAO(height)
+	if err != nil {
+		return nil, err
+	}
+	systemInterop := bc.newInteropContext(trigger.Application, d, nil, nil)
+	vm := SpawnVM(systemInterop)
+	vm.SetPriceGetter(getPrice)
+	return vm, nil
+}
+
+// GetStorageItemAt returns an item from storage as it was after processing
+// the block with the given index. Nil item is returned if there was no
+// such item at that height.
+func (bc *Blockchain) GetStorageItemAt(height uint32, scripthash util.Uint160, key []byte) (*state.StorageItem, error) {
+	d, err := bc.getStateDAO(height)
+	if err != nil {
+		return nil, err
+	}
+	return d.GetStorageItem(scripthash, key), nil
+}
+
+// TraceTransaction executes the transaction with the given hash once again
+// against the state of its block (taking into account all the transactions
+// preceding it in the block) and returns the execution result. Every
+// instruction executed is passed to the given tracer. Nothing is stored.
+func (bc *Blockchain) TraceTransaction(hash util.Uint256, tracer vm.Tracer) (*state.AppExecResult, error) {
+	_, height, err := bc.dao.GetTransaction(hash)
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return nil, errors.New("genesis block transactions can't be traced")
+	}
+	block, err := bc.GetBlock(bc.GetHeaderHash(int(height)))
+	if err != nil {
+		return nil, err
+	}
+	d, err := bc.getStateDAO(height - 1)
+	if err != nil {
+		return nil, err
+	}
+	cache := dao.NewCached(d)
+	for _, tx := range block.Transactions {
+		systemInterop := bc.newInteropContext(trigger.Application, cache, block, tx)
+		v := SpawnVM(systemInterop)
+		v.LoadScript(tx.Script)
+		v.SetPriceGetter(getPrice)
+		if bc.config.FreeGasLimit > 0 {
+			v.SetGasLimit(bc.config.FreeGasLimit + tx.SystemFee)
+		}
+		if !tx.Hash().Equals(hash) {
+			_ = v.Run()
+			if !v.HasFailed() {
+				if _, err := systemInterop.DAO.Persist(); err != nil {
+					return nil, errors.Wrap(err, "failed to persist invocation results")
+				}
+			}
+			continue
+		}
+		v.SetTracer(tracer)
+		_ = v.Run()
+		return &state.AppExecResult{
+			TxHash:      hash,
+			Trigger:     trigger.Application,
+			VMState:     v.State(),
+			GasConsumed: v.GasConsumed(),
+			Stack:       v.Estack().ToContractParameters(),
+			Events:      systemInterop.Notifications,
+		}, nil
+	}
+	return nil, fmt.Errorf("transaction %s is not found in block %d", hash.StringLE(), height)
+}
+
+// getStateDAO returns read-only DAO providing the state as it was after
+// processing the block with the given index.
+func (bc *Blockchain) getStateDAO(height uint32) (*dao.Simple, error) {
+	if height > bc.BlockHeight() {
+		return nil, fmt.Errorf("no state for height %d, current height is %d", height, bc.BlockHeight())
+	}
+	root, err := bc.dao.GetStateRoot(height)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get state root for block %d", height)
+	}
+	return dao.NewSimple(newStateStore(root.Root, bc.dao.Store)), nil
+}
+
 // ScriptFromWitness returns verification script for provided witness.
 // If hash is not equal to the witness script hash, error is returned.
 func ScriptFromWitness(hash util.Uint160, witness *transaction.Witness) ([]byte, error) {
@@ -1268,6 +1775,88 @@ func ScriptFromWitness(hash util.Uint160, witness *transaction.Witness) ([]byte,
 	return verification, nil
 }
 
+// CalculateTxNetworkFee returns the network fee needed for the given
+// (unsigned) transaction. It's the sum of the transaction size fee (with
+// all the witnesses added) and verification costs of all of its witnesses.
+// Standard signature and multisignature witnesses are priced without running
+// them (as they need signatures), so only verification scripts are needed for
+// them in t.Scripts. Any other verification scripts are run in the VM with
+// the invocation scripts given. Witnesses for deployed contracts can be
+// omitted in which case contract's verify method is called without arguments.
+// Every witness run can spend at most 0.5 GAS, an error is returned if it
+// needs more.
+func (bc *Blockchain) CalculateTxNetworkFee(t *transaction.Transaction) (util.Fixed8, error) {
+	hashes, err := bc.GetScriptHashesForVerifying(t)
+	if err != nil {
+		return 0, err
+	}
+	unsigned := *t
+	unsigned.Scripts = nil
+	size := io.GetVarSize(&unsigned)
+
+	var netFee util.Fixed8
+	for _, h := range hashes {
+		var w transaction.Witness
+		for i := range t.Scripts {
+			if t.Scripts[i].ScriptHash() == h {
+				w = t.Scripts[i]
+				break
+			}
+		}
+		if len(w.VerificationScript) == 0 {
+			if cs, err := bc.dao.GetContractState(h); err != nil || cs == nil {
+				return 0, errors.Errorf("no verification script for %s", address.Uint160ToString(h))
+			}
+			if len(w.InvocationScript) == 0 {
+				bw := io.NewBufBinWriter()
+				emit.Opcode(bw.BinWriter, opcode.NEWARRAY0)
+				emit.String(bw.BinWriter, "verify")
+				w.InvocationScript = bw.Bytes()
+			}
+		} else if vm.IsStandardContract(w.VerificationScript) {
+			fee, sizeDelta := CalculateNetworkFee(w.VerificationScript)
+			netFee += fee
+			size += sizeDelta
+			continue
+		}
+		interopCtx := bc.newInteropContext(trigger.Verification, bc.dao, nil, t)
+		gas, err := bc.getVerificationGas(h, &w, interopCtx)
+		if err != nil {
+			return 0, errors.Wrapf(err, "witness for %s", address.Uint160ToString(h))
+		}
+		netFee += gas
+		size += io.GetVarSize(&w)
+	}
+	return netFee + util.Fixed8(int64(size)*int64(bc.FeePerByte())), nil
+}
+
+// getVerificationGas runs verification of the given witness against hash and
+// returns the amount of GAS consumed by it. Verification fails if it needs
+// more than maxVerificationGAS.
+func (bc *Blockchain) getVerificationGas(hash util.Uint160, witness *transaction.Witness, interopCtx *interop.Context) (util.Fixed8, error) {
+	verification, err := ScriptFromWitness(hash, witness)
+	if err != nil {
+		return 0, err
+	}
+	v := SpawnVM(interopCtx)
+	v.SetPriceGetter(getPrice)
+	v.SetGasLimit(maxVerificationGAS)
+	v.LoadScriptWithFlags(verification, smartcontract.ReadOnly)
+	v.LoadScriptWithFlags(witness.InvocationScript, smartcontract.NoneFlag)
+	err = v.Run()
+	if v.HasFailed() {
+		return 0, errors.Errorf("vm failed to execute the script with error: %s", err)
+	}
+	resEl := v.Estack().Pop()
+	if resEl == nil {
+		return 0, errors.New("no result returned from the script")
+	}
+	if !resEl.Bool() {
+		return 0, errors.New("verification failed")
+	}
+	return v.GasConsumed(), nil
+}
+
 // verifyHashAgainstScript verifies given hash against the given witness.
 func (bc *Blockchain) verifyHashAgainstScript(hash util.Uint160, witness *transaction.Witness, interopCtx *interop.Context, useKeys bool) error {
 	verification, err := ScriptFromWitness(hash, witness)
@@ -1276,8 +1865,8 @@ func (bc *Blockchain) verifyHashAgainstScript(hash util.Uint160, witness *transa
 	}
 
 	vm := SpawnVM(interopCtx)
-	vm.LoadScript(verification)
-	vm.LoadScript(witness.InvocationScript)
+	vm.LoadScriptWithFlags(verification, smartcontract.ReadOnly)
+	vm.LoadScriptWithFlags(witness.InvocationScript, smartcontract.NoneFlag)
 	if useKeys {
 		bc.keyCacheLock.RLock()
 		if bc.keyCache[hash] != nil {
@@ -1340,6 +1929,15 @@ func (bc *Blockchain) verifyTxWitnesses(t *transaction.Transaction, block *block
 	return nil
 }
 
+// VerifyWitness checks that w is a correct witness for c signed by h.
+func (bc *Blockchain) VerifyWitness(h util.Uint160, c crypto.Verifiable, w *transaction.Witness) error {
+	bc.lock.RLock()
+	defer bc.lock.RUnlock()
+	interopCtx := bc.newInteropContext(trigger.Verification, bc.dao, nil, nil)
+	interopCtx.Container = c
+	return bc.verifyHashAgainstScript(h, w, interopCtx, false)
+}
+
 // verifyHeaderWitnesses is a block-specific implementation of VerifyWitnesses logic.
 func (bc *Blockchain) verifyHeaderWitnesses(currHeader, prevHeader *block.Header) error {
 	var hash util.Uint160
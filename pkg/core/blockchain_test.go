@@ -1,20 +1,31 @@
 package core
 
 import (
+	"bytes"
 	"testing"
 	"time"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
 )
 
 func TestAddHeaders(t *testing.T) {
@@ -100,6 +111,78 @@ func TestScriptFromWitness(t *testing.T) {
 	require.Nil(t, res)
 }
 
+func TestVerifyWitness(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	priv := testchain.PrivateKeyByID(0)
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	w := io.NewBufBinWriter()
+	emit.Bytes(w.BinWriter, priv.Sign(tx.GetSignedPart()))
+	witness := &transaction.Witness{
+		InvocationScript:   w.Bytes(),
+		VerificationScript: priv.PublicKey().GetVerificationScript(),
+	}
+	require.NoError(t, bc.VerifyWitness(priv.GetScriptHash(), tx, witness))
+
+	other := transaction.New([]byte{byte(opcode.PUSH2)}, 0)
+	require.Error(t, bc.VerifyWitness(priv.GetScriptHash(), other, witness))
+	require.Error(t, bc.VerifyWitness(util.Uint160{1, 2, 3}, tx, witness))
+}
+
+func TestRemoveUntraceable(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.ProtocolConfiguration) {
+		c.MaxTraceableBlocks = 2
+	})
+	defer bc.Close()
+	require.NoError(t, bc.SetRemoveUntraceableBlocks(true))
+	require.True(t, bc.RemovesUntraceableBlocks())
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.ValidUntilBlock = bc.BlockHeight() + 1
+	require.NoError(t, addSender(tx))
+	require.NoError(t, signTx(bc, tx))
+	b1 := bc.newBlock(tx)
+	require.NoError(t, bc.AddBlock(b1))
+
+	_, err := bc.genBlocks(1)
+	require.NoError(t, err)
+	_, err = bc.GetBlock(b1.Hash())
+	require.NoError(t, err)
+	_, err = bc.GetAppExecResult(tx.Hash())
+	require.NoError(t, err)
+
+	_, err = bc.genBlocks(1)
+	require.NoError(t, err)
+	_, err = bc.GetBlock(b1.Hash())
+	require.Equal(t, dao.ErrHeaderOnly, err)
+	_, _, err = bc.GetTransaction(tx.Hash())
+	require.Error(t, err)
+	_, err = bc.GetAppExecResult(tx.Hash())
+	require.Error(t, err)
+
+	h, err := bc.GetHeader(b1.Hash())
+	require.NoError(t, err)
+	require.Equal(t, b1.Header(), h)
+	require.True(t, bc.HasBlock(b1.Hash()))
+
+	t.Run("ValidUntilBlock", func(t *testing.T) {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = bc.BlockHeight() + 3
+		require.NoError(t, addSender(tx))
+		require.NoError(t, signTx(bc, tx))
+		require.Error(t, bc.VerifyTx(tx, nil))
+	})
+	t.Run("InvalidConfig", func(t *testing.T) {
+		cfg := bc.config
+		cfg.MaxTraceableBlocks = 0
+		chain, err := NewBlockchain(storage.NewMemoryStore(), cfg, zaptest.NewLogger(t))
+		require.NoError(t, err)
+		require.Error(t, chain.SetRemoveUntraceableBlocks(true))
+		require.False(t, chain.RemovesUntraceableBlocks())
+	})
+}
+
 func TestGetHeader(t *testing.T) {
 	bc := newTestChain(t)
 	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
@@ -124,6 +207,427 @@ func TestGetHeader(t *testing.T) {
 	}
 }
 
+func TestGetContractNotifications(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.ProtocolConfiguration) {
+		c.NotificationIndex = true
+	})
+	defer bc.Close()
+
+	tx := newNEP5Transfer(bc.contracts.NEO.Hash, neoOwner, util.Uint160{1, 2, 3}, 1000)
+	tx.ValidUntilBlock = bc.BlockHeight() + 1
+	tx.Sender = neoOwner
+	tx.Cosigners = []transaction.Cosigner{{
+		Account: neoOwner,
+		Scopes:  transaction.CalledByEntry,
+	}}
+	require.NoError(t, signTx(bc, tx))
+	require.NoError(t, bc.AddBlock(bc.newBlock(tx)))
+
+	// Genesis block mints NEO.
+	res, more, err := bc.GetContractNotifications(bc.contracts.NEO.Hash, "Transfer", 0, bc.BlockHeight(), 0, 10)
+	require.NoError(t, err)
+	require.False(t, more)
+	require.Equal(t, 2, len(res))
+	require.Equal(t, uint32(0), res[0].BlockIndex)
+
+	res, more, err = bc.GetContractNotifications(bc.contracts.NEO.Hash, "Transfer", 1, bc.BlockHeight(), 0, 10)
+	require.NoError(t, err)
+	require.False(t, more)
+	require.Equal(t, 1, len(res))
+	require.Equal(t, tx.Hash(), res[0].TxHash)
+	require.Equal(t, bc.BlockHeight(), res[0].BlockIndex)
+	require.Equal(t, bc.contracts.NEO.Hash, res[0].ScriptHash)
+
+	res, _, err = bc.GetContractNotifications(bc.contracts.NEO.Hash, "Unknown", 0, bc.BlockHeight(), 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(res))
+
+	t.Run("disabled", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, func(c *config.ProtocolConfiguration) {
+			c.NotificationIndex = false
+		})
+		defer bc.Close()
+		_, _, err := bc.GetContractNotifications(bc.contracts.NEO.Hash, "Transfer", 0, 0, 0, 10)
+		require.Equal(t, ErrNotificationIndexDisabled, err)
+	})
+}
+
+func TestGetAccountTransactions(t *testing.T) {
+	bc := newTestChainWithCustomCfg(t, func(c *config.ProtocolConfiguration) {
+		c.AccountTransactionIndex = true
+	})
+	defer bc.Close()
+
+	txs := make([]*transaction.Transaction, 2)
+	for i := range txs {
+		txs[i] = newNEP5Transfer(bc.contracts.NEO.Hash, neoOwner, util.Uint160{1, 2, 3}, 1000)
+		txs[i].ValidUntilBlock = bc.BlockHeight() + 2
+		txs[i].Nonce = uint32(i)
+		txs[i].Sender = neoOwner
+		txs[i].Cosigners = []transaction.Cosigner{{
+			Account: neoOwner,
+			Scopes:  transaction.CalledByEntry,
+		}}
+		require.NoError(t, signTx(bc, txs[i]))
+	}
+	require.NoError(t, bc.AddBlock(bc.newBlock(txs[0])))
+	require.NoError(t, bc.AddBlock(bc.newBlock(txs[1])))
+
+	res, more, err := bc.GetAccountTransactions(neoOwner, 0, 10)
+	require.NoError(t, err)
+	require.False(t, more)
+	require.Equal(t, 2, len(res))
+	require.Equal(t, state.AccountTransaction{BlockIndex: 2, TxHash: txs[1].Hash()}, res[0])
+	require.Equal(t, state.AccountTransaction{BlockIndex: 1, TxHash: txs[0].Hash()}, res[1])
+
+	res, more, err = bc.GetAccountTransactions(neoOwner, 0, 1)
+	require.NoError(t, err)
+	require.True(t, more)
+	require.Equal(t, 1, len(res))
+	require.Equal(t, txs[1].Hash(), res[0].TxHash)
+
+	// Transfer recipient is neither a sender nor a cosigner.
+	res, _, err = bc.GetAccountTransactions(util.Uint160{1, 2, 3}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(res))
+
+	t.Run("disabled", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, func(c *config.ProtocolConfiguration) {
+			c.AccountTransactionIndex = false
+		})
+		defer bc.Close()
+		_, _, err := bc.GetAccountTransactions(neoOwner, 0, 10)
+		require.Equal(t, ErrAccountTransactionIndexDisabled, err)
+	})
+}
+
+func TestGetStateRoot(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	genesisRoot, err := bc.GetStateRoot(0)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), genesisRoot.Index)
+	require.NotEqual(t, util.Uint256{}, genesisRoot.Root)
+
+	tx := newNEP5Transfer(bc.contracts.NEO.Hash, neoOwner, util.Uint160{1, 2, 3}, 1000)
+	tx.ValidUntilBlock = bc.BlockHeight() + 1
+	tx.Sender = neoOwner
+	tx.Cosigners = []transaction.Cosigner{{
+		Account: neoOwner,
+		Scopes:  transaction.CalledByEntry,
+	}}
+	require.NoError(t, signTx(bc, tx))
+	require.NoError(t, bc.AddBlock(bc.newBlock(tx)))
+
+	// NEO balances have changed.
+	root, err := bc.GetStateRoot(1)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), root.Index)
+	require.NotEqual(t, genesisRoot.Root, root.Root)
+
+	_, err = bc.GetStateRoot(2)
+	require.Error(t, err)
+
+	// State root must match the trie built from scratch.
+	tr := mpt.NewTrie(nil, storage.NewMemCachedStore(storage.NewMemoryStore()))
+	for _, p := range []storage.KeyPrefix{storage.STAccount, storage.STContract, storage.STStorage} {
+		require.NoError(t, bc.dao.Store.Seek(p.Bytes(), func(k, v []byte) {
+			require.NoError(t, tr.Put(k, v))
+		}))
+	}
+	require.Equal(t, root.Root, tr.StateRoot())
+}
+
+func TestGetTestVMAt(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	avm := []byte{byte(opcode.RET)}
+	res := invokeByOwner(t, bc, contractCreateScript(t, avm), util.Fixed8FromInt64(100))
+	require.Equal(t, "HALT", res.VMState)
+	height := bc.BlockHeight()
+
+	getContract := func(t *testing.T, height uint32) stackitem.Item {
+		v, err := bc.GetTestVMAt(height)
+		require.NoError(t, err)
+		v.LoadScript(getContractScript(t, hash.Hash160(avm)))
+		require.NoError(t, v.Run())
+		return v.Estack().Pop().Item()
+	}
+	require.Equal(t, stackitem.NewByteArray([]byte{}), getContract(t, height-1))
+	require.IsType(t, &stackitem.Interop{}, getContract(t, height))
+
+	_, err := bc.GetTestVMAt(height + 1)
+	require.Error(t, err)
+}
+
+// contractCreateScript returns script deploying contract with the given script.
+func contractCreateScript(t *testing.T, avm []byte) []byte {
+	w := io.NewBufBinWriter()
+	for _, s := range []string{"description", "email", "author", "version", "name"} {
+		emit.String(w.BinWriter, s)
+	}
+	emit.Int(w.BinWriter, 0)
+	emit.Int(w.BinWriter, int64(smartcontract.VoidType))
+	emit.Bytes(w.BinWriter, nil)
+	emit.Bytes(w.BinWriter, avm)
+	emit.Syscall(w.BinWriter, "Neo.Contract.Create")
+	require.NoError(t, w.Err)
+	return w.Bytes()
+}
+
+// getContractScript returns script getting contract with the given hash.
+func getContractScript(t *testing.T, h util.Uint160) []byte {
+	w := io.NewBufBinWriter()
+	emit.Bytes(w.BinWriter, h.BytesBE())
+	emit.Syscall(w.BinWriter, "System.Blockchain.GetContract")
+	require.NoError(t, w.Err)
+	return w.Bytes()
+}
+
+func TestTraceTransaction(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	// The second transaction depends on the first one from the same block.
+	avm := []byte{byte(opcode.PUSH1), byte(opcode.RET)}
+	txDeploy := transaction.New(contractCreateScript(t, avm), util.Fixed8FromInt64(100))
+	txGet := transaction.New(getContractScript(t, hash.Hash160(avm)), 0)
+	for _, tx := range []*transaction.Transaction{txDeploy, txGet} {
+		tx.ValidUntilBlock = bc.blockHeight + 1
+		require.NoError(t, addSender(tx))
+		require.NoError(t, signTx(bc, tx))
+	}
+	require.NoError(t, bc.AddBlock(bc.newBlock(txDeploy, txGet)))
+
+	for _, tx := range []*transaction.Transaction{txDeploy, txGet} {
+		expected, err := bc.GetAppExecResult(tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, "HALT", expected.VMState)
+
+		var steps []vm.TraceStep
+		res, err := bc.TraceTransaction(tx.Hash(), func(s vm.TraceStep) { steps = append(steps, s) })
+		require.NoError(t, err)
+		require.Equal(t, expected, res)
+
+		require.True(t, len(steps) > 0)
+		require.Equal(t, 0, steps[0].IP)
+		require.Equal(t, hash.Hash160(tx.Script), steps[0].ScriptHash)
+		require.EqualValues(t, 0, steps[0].GasConsumed)
+		require.Equal(t, opcode.SYSCALL, steps[len(steps)-2].Opcode)
+		require.Equal(t, opcode.RET, steps[len(steps)-1].Opcode)
+	}
+
+	t.Run("UnknownTransaction", func(t *testing.T) {
+		_, err := bc.TraceTransaction(util.Uint256{1, 2, 3}, func(vm.TraceStep) {})
+		require.Error(t, err)
+	})
+}
+
+func TestTestInvoke(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	acc := random.Uint160()
+	w := io.NewBufBinWriter()
+	emit.String(w.BinWriter, "transfer")
+	emit.Syscall(w.BinWriter, "System.Runtime.Log")
+	emit.AppCallWithOperationAndArgs(w.BinWriter, bc.contracts.GAS.Hash, "transfer",
+		neoOwner, acc, int64(util.Fixed8FromInt64(1)))
+	emit.Opcode(w.BinWriter, opcode.ASSERT)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+	scriptHash := hash.Hash160(script)
+
+	t.Run("NoSigners", func(t *testing.T) {
+		res := bc.TestInvoke(script, nil, 0)
+		require.Equal(t, "FAULT", res.VMState)
+		require.NotEmpty(t, res.FaultException)
+		require.Equal(t, []state.LogEvent{{ScriptHash: scriptHash, Message: "transfer"}}, res.Logs)
+		require.Empty(t, res.Events)
+	})
+	t.Run("WithSigner", func(t *testing.T) {
+		tx := transaction.New(script, 0)
+		tx.Sender = neoOwner
+		tx.Cosigners = []transaction.Cosigner{{Account: neoOwner, Scopes: transaction.CalledByEntry}}
+		res := bc.TestInvoke(script, tx, 0)
+		require.Equal(t, "HALT", res.VMState)
+		require.Empty(t, res.FaultException)
+		require.Equal(t, tx.Hash(), res.TxHash)
+		require.Equal(t, 1, len(res.Logs))
+		require.Equal(t, 1, len(res.Events))
+		require.Equal(t, bc.contracts.GAS.Hash, res.Events[0].ScriptHash)
+
+		key := append([]byte{20}, acc.BytesBE()...)
+		var found bool
+		for _, c := range res.StorageChanges {
+			require.Equal(t, bc.contracts.GAS.Hash, c.ScriptHash)
+			if bytes.Equal(key, c.Key) {
+				found = true
+				require.NotNil(t, c.Value)
+			}
+		}
+		require.True(t, found)
+
+		// Nothing is persisted.
+		require.Nil(t, bc.GetStorageItem(bc.contracts.GAS.Hash, key))
+
+		height := bc.BlockHeight()
+		res, err := bc.TestInvokeAt(height, script, tx, 0)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.VMState)
+		_, err = bc.TestInvokeAt(height+1, script, tx, 0)
+		require.Error(t, err)
+	})
+	t.Run("GasLimit", func(t *testing.T) {
+		res := bc.TestInvoke(script, nil, 1)
+		require.Equal(t, "FAULT", res.VMState)
+		require.Empty(t, res.Logs)
+	})
+	t.Run("Iterators", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Int(w.BinWriter, 1)
+		emit.Int(w.BinWriter, 2)
+		emit.Int(w.BinWriter, 2)
+		emit.Opcode(w.BinWriter, opcode.PACK)
+		emit.Syscall(w.BinWriter, "Neo.Iterator.Create")
+		emit.Int(w.BinWriter, 5)
+		require.NoError(t, w.Err)
+
+		res := bc.TestInvoke(w.Bytes(), nil, 0)
+		require.Equal(t, "HALT", res.VMState)
+		require.Equal(t, 2, len(res.Stack))
+		require.Equal(t, 1, len(res.Iterators))
+		for i, item := range res.Iterators {
+			require.Equal(t, smartcontract.InteropInterfaceType, res.Stack[i].Type)
+			require.True(t, vm.IsIterator(item))
+		}
+	})
+}
+
+func TestCalculateTxNetworkFee(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	t.Run("Multisig", func(t *testing.T) {
+		validators, err := getValidators(bc.config)
+		require.NoError(t, err)
+		rawScript, err := smartcontract.CreateMultiSigRedeemScript(len(bc.config.StandbyValidators)/2+1, validators)
+		require.NoError(t, err)
+
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = bc.BlockHeight() + 1
+		require.NoError(t, addSender(tx))
+		tx.Scripts = []transaction.Witness{{VerificationScript: rawScript}}
+		fee, err := bc.CalculateTxNetworkFee(tx)
+		require.NoError(t, err)
+
+		tx.Scripts = nil
+		require.NoError(t, signTx(bc, tx))
+		require.Equal(t, tx.NetworkFee, fee)
+		require.NoError(t, bc.VerifyTx(tx, nil))
+	})
+
+	sizeFee := func(tx *transaction.Transaction) util.Fixed8 {
+		return util.Fixed8(int64(io.GetVarSize(tx)) * int64(bc.FeePerByte()))
+	}
+	deploy := func(t *testing.T, avm []byte) util.Uint160 {
+		res := invokeByOwner(t, bc, contractCreateScript(t, avm), util.Fixed8FromInt64(100))
+		require.Equal(t, "HALT", res.VMState)
+		return hash.Hash160(avm)
+	}
+	t.Run("Contract", func(t *testing.T) {
+		h := deploy(t, []byte{byte(opcode.DROP), byte(opcode.DROP), byte(opcode.PUSH1), byte(opcode.RET)})
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Sender = h
+		fee, err := bc.CalculateTxNetworkFee(tx)
+		require.NoError(t, err)
+
+		w := io.NewBufBinWriter()
+		emit.Opcode(w.BinWriter, opcode.NEWARRAY0)
+		emit.String(w.BinWriter, "verify")
+		tx.Scripts = []transaction.Witness{{InvocationScript: w.Bytes()}}
+		require.True(t, fee > sizeFee(tx))
+
+		// The same witness can be passed explicitly.
+		explicitFee, err := bc.CalculateTxNetworkFee(tx)
+		require.NoError(t, err)
+		require.Equal(t, fee, explicitFee)
+	})
+	t.Run("ContractFails", func(t *testing.T) {
+		h := deploy(t, []byte{byte(opcode.DROP), byte(opcode.DROP), byte(opcode.PUSH0), byte(opcode.RET)})
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Sender = h
+		_, err := bc.CalculateTxNetworkFee(tx)
+		require.Error(t, err)
+	})
+	t.Run("NonStandardScript", func(t *testing.T) {
+		verif := []byte{byte(opcode.PUSH1), byte(opcode.PUSH1), byte(opcode.EQUAL)}
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Sender = hash.Hash160(verif)
+		tx.Scripts = []transaction.Witness{{VerificationScript: verif}}
+		fee, err := bc.CalculateTxNetworkFee(tx)
+		require.NoError(t, err)
+		require.True(t, fee > sizeFee(tx))
+	})
+	t.Run("InfiniteLoop", func(t *testing.T) {
+		verif := []byte{byte(opcode.JMP), 0}
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Sender = hash.Hash160(verif)
+		tx.Scripts = []transaction.Witness{{VerificationScript: verif}}
+		_, err := bc.CalculateTxNetworkFee(tx)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "gas limit")
+	})
+	t.Run("UnknownAccount", func(t *testing.T) {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Sender = random.Uint160()
+		_, err := bc.CalculateTxNetworkFee(tx)
+		require.Error(t, err)
+	})
+}
+
+func TestGetStorageItemAt(t *testing.T) {
+	bc := newTestChain(t)
+	defer bc.Close()
+
+	acc := random.Uint160()
+	w := io.NewBufBinWriter()
+	emit.AppCallWithOperationAndArgs(w.BinWriter, bc.contracts.GAS.Hash, "transfer",
+		neoOwner, acc, int64(util.Fixed8FromInt64(1)))
+	emit.Opcode(w.BinWriter, opcode.ASSERT)
+	res := invokeByOwner(t, bc, w.Bytes(), 0)
+	require.Equal(t, "HALT", res.VMState)
+	height := bc.BlockHeight()
+
+	// Native NEP5 account key (prefixAccount + script hash).
+	key := append([]byte{20}, acc.BytesBE()...)
+	si, err := bc.GetStorageItemAt(height-1, bc.contracts.GAS.Hash, key)
+	require.NoError(t, err)
+	require.Nil(t, si)
+	si, err = bc.GetStorageItemAt(height, bc.contracts.GAS.Hash, key)
+	require.NoError(t, err)
+	require.NotNil(t, si)
+	require.Equal(t, bc.GetStorageItem(bc.contracts.GAS.Hash, key), si)
+
+	_, err = bc.GetStorageItemAt(height+1, bc.contracts.GAS.Hash, key)
+	require.Error(t, err)
+}
+
+func TestStateStoreSeekMissingNode(t *testing.T) {
+	s := newStateStore(random.Uint256(), storage.NewMemoryStore())
+	require.Error(t, s.Seek([]byte{byte(storage.STStorage)}, func(k, v []byte) {}))
+	require.Error(t, s.SeekFrom([]byte{byte(storage.STStorage)}, nil, func(k, v []byte) bool { return true }))
+
+	// Errors are returned through the DAO used by the VM.
+	d := dao.NewSimple(s)
+	_, err := d.GetStorageItemsWithPrefix(random.Uint160(), nil)
+	require.Error(t, err)
+	err = d.SeekStorageItems(random.Uint160(), nil, nil, func(k []byte, si *state.StorageItem) bool { return true })
+	require.Error(t, err)
+}
+
 func TestGetBlock(t *testing.T) {
 	bc := newTestChain(t)
 	blocks, err := bc.genBlocks(100)
@@ -240,12 +744,16 @@ func TestSubscriptions(t *testing.T) {
 	txCh := make(chan *transaction.Transaction, chBufSize)
 	notificationCh := make(chan *state.NotificationEvent, chBufSize)
 	executionCh := make(chan *state.AppExecResult, chBufSize)
+	headerCh := make(chan *block.Header, chBufSize)
+	mempoolCh := make(chan mempool.Event, chBufSize)
 
 	bc := newTestChain(t)
 	bc.SubscribeForBlocks(blockCh)
 	bc.SubscribeForTransactions(txCh)
 	bc.SubscribeForNotifications(notificationCh)
 	bc.SubscribeForExecutions(executionCh)
+	bc.SubscribeForHeaders(headerCh)
+	bc.GetMemPool().SubscribeForTransactions(mempoolCh)
 
 	assert.Empty(t, notificationCh)
 	assert.Empty(t, executionCh)
@@ -262,6 +770,8 @@ func TestSubscriptions(t *testing.T) {
 	b := <-blockCh
 	assert.Equal(t, blocks[0], b)
 	assert.Empty(t, blockCh)
+	require.Equal(t, blocks[0].Header(), <-headerCh)
+	assert.Empty(t, headerCh)
 
 	script := io.NewBufBinWriter()
 	emit.Bytes(script.BinWriter, []byte("yay!"))
@@ -295,6 +805,9 @@ func TestSubscriptions(t *testing.T) {
 	txGood2.ValidUntilBlock = 100500
 	require.NoError(t, signTx(bc, txGood2))
 
+	require.NoError(t, bc.PoolTx(txGood1))
+	require.Equal(t, mempool.Event{Type: mempool.TransactionAdded, Tx: txGood1}, <-mempoolCh)
+
 	invBlock := newBlock(bc.config, bc.BlockHeight()+1, bc.CurrentHeaderHash(), txGood1, txBad, txGood2)
 	require.NoError(t, bc.AddBlock(invBlock))
 
@@ -306,6 +819,10 @@ func TestSubscriptions(t *testing.T) {
 	b = <-blockCh
 	require.Equal(t, invBlock, b)
 	assert.Empty(t, blockCh)
+	require.Equal(t, invBlock.Header(), <-headerCh)
+	// Included transaction is removed from the pool.
+	require.Equal(t, mempool.Event{Type: mempool.TransactionRemoved, Tx: txGood1}, <-mempoolCh)
+	assert.Empty(t, mempoolCh)
 
 	// Follow in-block transaction order.
 	for _, txExpected := range invBlock.Transactions {
@@ -326,6 +843,8 @@ func TestSubscriptions(t *testing.T) {
 	bc.UnsubscribeFromTransactions(txCh)
 	bc.UnsubscribeFromNotifications(notificationCh)
 	bc.UnsubscribeFromExecutions(executionCh)
+	bc.UnsubscribeFromHeaders(headerCh)
+	bc.GetMemPool().UnsubscribeFromTransactions(mempoolCh)
 
 	// Ensure that new blocks are processed correctly after unsubscription.
 	_, err = bc.genBlocks(2 * chBufSize)
@@ -6,6 +6,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -33,26 +34,46 @@ type Blockchainer interface {
 	HasBlock(util.Uint256) bool
 	HasTransaction(util.Uint256) bool
 	GetAccountState(util.Uint160) *state.Account
+	GetAccountTransactions(acc util.Uint160, skip, limit int) ([]state.AccountTransaction, bool, error)
 	GetAppExecResult(util.Uint256) (*state.AppExecResult, error)
 	GetNEP5TransferLog(util.Uint160) *state.NEP5TransferLog
+	GetContractNotifications(contract util.Uint160, name string, from, to uint32, skip, limit int) ([]state.ContractNotification, bool, error)
+	ForEachNEP5Transfer(util.Uint160, func(*state.NEP5Transfer) (bool, error)) error
 	GetNEP5Balances(util.Uint160) *state.NEP5Balances
+	GetOracleNodes() (keys.PublicKeys, error)
+	GetOracleRequests() (map[uint64]*state.OracleRequest, error)
+	GetOracleResponseScript() []byte
 	GetValidators() ([]*keys.PublicKey, error)
 	GetStandByValidators() (keys.PublicKeys, error)
 	GetScriptHashesForVerifying(*transaction.Transaction) ([]util.Uint160, error)
+	GetStateProof(root util.Uint256, key []byte) ([][]byte, error)
+	GetStateRoot(height uint32) (*state.MPTRoot, error)
 	GetStorageItem(scripthash util.Uint160, key []byte) *state.StorageItem
+	GetStorageItemAt(height uint32, scripthash util.Uint160, key []byte) (*state.StorageItem, error)
 	GetStorageItems(hash util.Uint160) (map[string]*state.StorageItem, error)
 	GetTestVM() *vm.VM
+	GetTestVMAt(height uint32) (*vm.VM, error)
 	GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
 	mempool.Feer // fee interface
+	CalculateTxNetworkFee(t *transaction.Transaction) (util.Fixed8, error)
 	PoolTx(*transaction.Transaction) error
+	RemovesUntraceableBlocks() bool
+	SeekStorageItems(hash util.Uint160, prefix, start []byte, f func(k []byte, si *state.StorageItem) bool) error
 	SubscribeForBlocks(ch chan<- *block.Block)
+	TestInvoke(script []byte, tx *transaction.Transaction, gasLimit util.Fixed8) *state.TestExecResult
+	TestInvokeAt(height uint32, script []byte, tx *transaction.Transaction, gasLimit util.Fixed8) (*state.TestExecResult, error)
+	TraceTransaction(util.Uint256, vm.Tracer) (*state.AppExecResult, error)
+	UtilityTokenHash() util.Uint160
 	SubscribeForExecutions(ch chan<- *state.AppExecResult)
+	SubscribeForHeaders(ch chan<- *block.Header)
 	SubscribeForNotifications(ch chan<- *state.NotificationEvent)
 	SubscribeForTransactions(ch chan<- *transaction.Transaction)
 	VerifyTx(*transaction.Transaction, *block.Block) error
+	VerifyWitness(util.Uint160, crypto.Verifiable, *transaction.Witness) error
 	GetMemPool() *mempool.Pool
 	UnsubscribeFromBlocks(ch chan<- *block.Block)
 	UnsubscribeFromExecutions(ch chan<- *state.AppExecResult)
+	UnsubscribeFromHeaders(ch chan<- *block.Header)
 	UnsubscribeFromNotifications(ch chan<- *state.NotificationEvent)
 	UnsubscribeFromTransactions(ch chan<- *transaction.Transaction)
 }
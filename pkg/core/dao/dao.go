@@ -3,6 +3,7 @@ package dao
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"sort"
 
@@ -14,37 +15,50 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
+// ErrHeaderOnly is returned by GetBlock for blocks removed with DeleteBlock,
+// only headers are kept for such blocks.
+var ErrHeaderOnly = errors.New("only header is available")
+
 // DAO is a data access object.
 type DAO interface {
 	AppendNEP5Transfer(acc util.Uint160, index uint32, tr *state.NEP5Transfer) (bool, error)
+	DeleteBlock(hash util.Uint256) error
 	DeleteContractState(hash util.Uint160) error
 	DeleteStorageItem(scripthash util.Uint160, key []byte) error
 	GetAccountState(hash util.Uint160) (*state.Account, error)
 	GetAccountStateOrNew(hash util.Uint160) (*state.Account, error)
 	GetAndDecode(entity io.Serializable, key []byte) error
+	GetAccountTransactions(acc util.Uint160, skip, limit int) ([]state.AccountTransaction, bool, error)
 	GetAppExecResult(hash util.Uint256) (*state.AppExecResult, error)
 	GetBatch() *storage.MemBatch
 	GetBlock(hash util.Uint256) (*block.Block, error)
+	GetContractNotifications(contract util.Uint160, name string, from, to uint32, skip, limit int) ([]state.ContractNotification, bool, error)
 	GetContractState(hash util.Uint160) (*state.Contract, error)
 	GetCurrentBlockHeight() (uint32, error)
 	GetCurrentHeaderHeight() (i uint32, h util.Uint256, err error)
+	GetHeader(hash util.Uint256) (*block.Header, error)
 	GetHeaderHashes() ([]util.Uint256, error)
 	GetNEP5Balances(acc util.Uint160) (*state.NEP5Balances, error)
 	GetNEP5TransferLog(acc util.Uint160, index uint32) (*state.NEP5TransferLog, error)
+	GetStateRoot(height uint32) (*state.MPTRoot, error)
 	GetStorageItem(scripthash util.Uint160, key []byte) *state.StorageItem
 	GetStorageItems(hash util.Uint160) (map[string]*state.StorageItem, error)
 	GetStorageItemsWithPrefix(hash util.Uint160, prefix []byte) (map[string]*state.StorageItem, error)
+	SeekStorageItems(hash util.Uint160, prefix, start []byte, f func(k []byte, si *state.StorageItem) bool) error
 	GetTransaction(hash util.Uint256) (*transaction.Transaction, uint32, error)
 	GetVersion() (string, error)
 	GetWrapped() DAO
 	HasTransaction(hash util.Uint256) bool
 	Persist() (int, error)
 	PutAccountState(as *state.Account) error
+	PutAccountTransaction(acc util.Uint160, n uint32, at *state.AccountTransaction) error
 	PutAppExecResult(aer *state.AppExecResult) error
+	PutContractNotification(name string, n uint32, cn *state.ContractNotification) error
 	PutContractState(cs *state.Contract) error
 	PutCurrentHeader(hashAndIndex []byte) error
 	PutNEP5Balances(acc util.Uint160, bs *state.NEP5Balances) error
 	PutNEP5TransferLog(acc util.Uint160, index uint32, lg *state.NEP5TransferLog) error
+	PutStateRoot(r *state.MPTRoot) error
 	PutStorageItem(scripthash util.Uint160, key []byte, si *state.StorageItem) error
 	PutVersion(v string) error
 	StoreAsBlock(block *block.Block) error
@@ -268,11 +282,156 @@ func (dao *Simple) PutAppExecResult(aer *state.AppExecResult) error {
 
 // -- end notification event.
 
+// -- start notification index.
+
+// makeNotificationIndexPrefix returns a prefix of notification index keys for
+// the given contract and event name.
+func makeNotificationIndexPrefix(contract util.Uint160, name string) []byte {
+	w := io.NewBufBinWriter()
+	w.WriteB(byte(storage.IXNotifications))
+	w.WriteBytes(contract.BytesBE())
+	w.WriteString(name)
+	return w.Bytes()
+}
+
+// PutContractNotification adds notification with the given event name into
+// the notification index. n is a position of the notification among all
+// notifications of the block it was emitted in.
+func (dao *Simple) PutContractNotification(name string, n uint32, cn *state.ContractNotification) error {
+	key := makeNotificationIndexPrefix(cn.ScriptHash, name)
+	key = append(key, make([]byte, 8)...)
+	binary.BigEndian.PutUint32(key[len(key)-8:], cn.BlockIndex)
+	binary.BigEndian.PutUint32(key[len(key)-4:], n)
+	return dao.Put(cn, key)
+}
+
+// GetContractNotifications returns notifications with the given event name
+// emitted by the contract in blocks from `from` to `to` (inclusive) sorted by
+// block and position in it. skip notifications are skipped and at most limit
+// are returned, the second return value is true if there are more of them.
+func (dao *Simple) GetContractNotifications(contract util.Uint160, name string, from, to uint32, skip, limit int) ([]state.ContractNotification, bool, error) {
+	start := make([]byte, 4)
+	binary.BigEndian.PutUint32(start, from)
+	res := []state.ContractNotification{}
+	more, err := dao.seekPage(makeNotificationIndexPrefix(contract, name), start, skip, limit,
+		func(k []byte) bool {
+			return binary.BigEndian.Uint32(k) > to
+		},
+		func(r *io.BinReader) {
+			var cn state.ContractNotification
+			cn.DecodeBinary(r)
+			res = append(res, cn)
+		})
+	if err != nil {
+		return nil, false, err
+	}
+	return res, more, nil
+}
+
+// seekPage iterates over the items with the given key prefix in the ascending
+// key order starting from the prefix+start key. It skips the first skip items
+// and passes at most limit next ones to f, iteration also stops at the first
+// key (with the prefix cut) for which end returns true (if end is not nil).
+// The result is true if there are more items after the ones passed to f.
+func (dao *Simple) seekPage(prefix, start []byte, skip, limit int, end func(k []byte) bool, f func(r *io.BinReader)) (bool, error) {
+	var (
+		err  error
+		more bool
+		n    int
+	)
+	serr := dao.Store.SeekFrom(prefix, start, func(k, v []byte) bool {
+		if end != nil && end(k[len(prefix):]) {
+			return false
+		}
+		if skip > 0 {
+			skip--
+			return true
+		}
+		if n == limit {
+			more = true
+			return false
+		}
+		n++
+		r := io.NewBinReaderFromBuf(v)
+		f(r)
+		err = r.Err
+		return err == nil
+	})
+	if serr != nil {
+		return false, serr
+	}
+	return more, err
+}
+
+// -- end notification index.
+
+// -- start account transactions index.
+
+// makeAccountTransactionKey returns account transactions index key. Block
+// index and position in it are inverted, so that the newest transactions go
+// first.
+func makeAccountTransactionKey(acc util.Uint160, index, n uint32) []byte {
+	key := make([]byte, 1+util.Uint160Size+8)
+	key[0] = byte(storage.IXAccountTxs)
+	copy(key[1:], acc.BytesBE())
+	binary.BigEndian.PutUint32(key[len(key)-8:], ^index)
+	binary.BigEndian.PutUint32(key[len(key)-4:], ^n)
+	return key
+}
+
+// PutAccountTransaction adds transaction reference into the account
+// transactions index. n is a position of the transaction in its block.
+func (dao *Simple) PutAccountTransaction(acc util.Uint160, n uint32, at *state.AccountTransaction) error {
+	return dao.Put(at, makeAccountTransactionKey(acc, at.BlockIndex, n))
+}
+
+// GetAccountTransactions returns transactions the account was a sender or a
+// cosigner of, newest first. skip transactions are skipped and at most limit
+// are returned, the second return value is true if there are more of them.
+func (dao *Simple) GetAccountTransactions(acc util.Uint160, skip, limit int) ([]state.AccountTransaction, bool, error) {
+	prefix := append(storage.IXAccountTxs.Bytes(), acc.BytesBE()...)
+	res := []state.AccountTransaction{}
+	more, err := dao.seekPage(prefix, nil, skip, limit, nil, func(r *io.BinReader) {
+		var at state.AccountTransaction
+		at.DecodeBinary(r)
+		res = append(res, at)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return res, more, nil
+}
+
+// -- end account transactions index.
+
+// -- start state root.
+
+// GetStateRoot returns state root of the block with the given index.
+func (dao *Simple) GetStateRoot(height uint32) (*state.MPTRoot, error) {
+	r := &state.MPTRoot{}
+	err := dao.GetAndDecode(r, makeStateRootKey(height))
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// PutStateRoot puts given state root into the given store.
+func (dao *Simple) PutStateRoot(r *state.MPTRoot) error {
+	return dao.Put(r, makeStateRootKey(r.Index))
+}
+
+func makeStateRootKey(height uint32) []byte {
+	return storage.AppendPrefixInt(storage.DataStateRoot, int(height))
+}
+
+// -- end state root.
+
 // -- start storage item.
 
 // GetStorageItem returns StorageItem if it exists in the given store.
 func (dao *Simple) GetStorageItem(scripthash util.Uint160, key []byte) *state.StorageItem {
-	b, err := dao.Store.Get(makeStorageItemKey(scripthash, key))
+	b, err := dao.Store.Get(MakeStorageItemKey(scripthash, key))
 	if err != nil {
 		return nil
 	}
@@ -290,13 +449,13 @@ func (dao *Simple) GetStorageItem(scripthash util.Uint160, key []byte) *state.St
 // PutStorageItem puts given StorageItem for given script with given
 // key into the given store.
 func (dao *Simple) PutStorageItem(scripthash util.Uint160, key []byte, si *state.StorageItem) error {
-	return dao.Put(si, makeStorageItemKey(scripthash, key))
+	return dao.Put(si, MakeStorageItemKey(scripthash, key))
 }
 
 // DeleteStorageItem drops storage item for the given script with the
 // given key from the store.
 func (dao *Simple) DeleteStorageItem(scripthash util.Uint160, key []byte) error {
-	return dao.Store.Delete(makeStorageItemKey(scripthash, key))
+	return dao.Store.Delete(MakeStorageItemKey(scripthash, key))
 }
 
 // GetStorageItems returns all storage items for a given scripthash.
@@ -329,15 +488,43 @@ func (dao *Simple) GetStorageItemsWithPrefix(hash util.Uint160, prefix []byte) (
 		// Cut prefix and hash.
 		siMap[string(k[len(lookupKey):])] = si
 	}
-	dao.Store.Seek(lookupKey, saveToMap)
+	serr := dao.Store.Seek(lookupKey, saveToMap)
+	if serr != nil {
+		return nil, serr
+	}
 	if err != nil {
 		return nil, err
 	}
 	return siMap, nil
 }
 
-// makeStorageItemKey returns a key used to store StorageItem in the DB.
-func makeStorageItemKey(scripthash util.Uint160, key []byte) []byte {
+// SeekStorageItems calls f for every storage item of the given contract with
+// the given key prefix starting from the prefix+start key in the ascending key
+// order until f returns false. Keys passed to f include the prefix.
+func (dao *Simple) SeekStorageItems(hash util.Uint160, prefix, start []byte, f func(k []byte, si *state.StorageItem) bool) error {
+	var err error
+
+	lookupKey := storage.AppendPrefix(storage.STStorage, hash.BytesLE())
+	serr := dao.Store.SeekFrom(append(lookupKey, prefix...), start, func(k, v []byte) bool {
+		r := io.NewBinReaderFromBuf(v)
+		si := &state.StorageItem{}
+		si.DecodeBinary(r)
+		if r.Err != nil {
+			err = r.Err
+			return false
+		}
+		// Cut the hash.
+		return f(k[len(lookupKey):], si)
+	})
+	if serr != nil {
+		return serr
+	}
+	return err
+}
+
+// MakeStorageItemKey returns a key used to store StorageItem in the DB (and
+// in the state MPT).
+func MakeStorageItemKey(scripthash util.Uint160, key []byte) []byte {
 	return storage.AppendPrefix(storage.STStorage, append(scripthash.BytesLE(), key...))
 }
 
@@ -346,18 +533,71 @@ func makeStorageItemKey(scripthash util.Uint160, key []byte) []byte {
 // -- other.
 
 // GetBlock returns Block by the given hash if it exists in the store.
+// ErrHeaderOnly is returned if the block was removed with DeleteBlock.
 func (dao *Simple) GetBlock(hash util.Uint256) (*block.Block, error) {
+	block, size, err := dao.getTrimmedBlock(hash)
+	if err != nil {
+		return nil, err
+	}
+	if size == io.GetVarSize(block.Header()) {
+		return nil, ErrHeaderOnly
+	}
+	return block, nil
+}
+
+// GetHeader returns block header by the given hash if it exists in the
+// store. Headers are available even for the blocks removed with DeleteBlock.
+func (dao *Simple) GetHeader(hash util.Uint256) (*block.Header, error) {
+	block, _, err := dao.getTrimmedBlock(hash)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+// getTrimmedBlock returns trimmed block by the given hash and the size of
+// its serialized form.
+func (dao *Simple) getTrimmedBlock(hash util.Uint256) (*block.Block, int, error) {
 	key := storage.AppendPrefix(storage.DataBlock, hash.BytesLE())
 	b, err := dao.Store.Get(key)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	block, err := block.NewBlockFromTrimmedBytes(b)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return block, nil
+	return block, len(b), nil
+}
+
+// DeleteBlock removes transactions and application execution results of the
+// block with the given hash leaving only its header in the store.
+func (dao *Simple) DeleteBlock(hash util.Uint256) error {
+	block, err := dao.GetBlock(hash)
+	if err != nil {
+		if err == ErrHeaderOnly {
+			return nil
+		}
+		return err
+	}
+	for _, tx := range block.Transactions {
+		h := tx.Hash()
+		if err := dao.Store.Delete(storage.AppendPrefix(storage.DataTransaction, h.BytesLE())); err != nil {
+			return err
+		}
+		if err := dao.Store.Delete(storage.AppendPrefix(storage.STNotification, h.BytesBE())); err != nil {
+			return err
+		}
+	}
+
+	key := storage.AppendPrefix(storage.DataBlock, hash.BytesLE())
+	buf := io.NewBufBinWriter()
+	block.Header().EncodeBinary(buf.BinWriter)
+	if buf.Err != nil {
+		return buf.Err
+	}
+	return dao.Store.Put(key, buf.Bytes())
 }
 
 // GetVersion attempts to get the current version stored in the
@@ -394,7 +634,7 @@ func (dao *Simple) GetCurrentHeaderHeight() (i uint32, h util.Uint256, err error
 // the given underlying store.
 func (dao *Simple) GetHeaderHashes() ([]util.Uint256, error) {
 	hashMap := make(map[uint32][]util.Uint256)
-	dao.Store.Seek(storage.IXHeaderHashList.Bytes(), func(k, v []byte) {
+	err := dao.Store.Seek(storage.IXHeaderHashList.Bytes(), func(k, v []byte) {
 		storedCount := binary.LittleEndian.Uint32(k[1:])
 		hashes, err := read2000Uint256Hashes(v)
 		if err != nil {
@@ -402,6 +642,9 @@ func (dao *Simple) GetHeaderHashes() ([]util.Uint256, error) {
 		}
 		hashMap[storedCount] = hashes
 	})
+	if err != nil {
+		return nil, err
+	}
 
 	var (
 		hashes     = make([]util.Uint256, 0, len(hashMap))
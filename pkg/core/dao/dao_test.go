@@ -11,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
@@ -97,6 +98,100 @@ func TestPutGetAppExecResult(t *testing.T) {
 	require.Equal(t, appExecResult, gotAppExecResult)
 }
 
+func TestPutGetContractNotifications(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore())
+	contract := random.Uint160()
+	newNotification := func(name string, height uint32) *state.ContractNotification {
+		return &state.ContractNotification{
+			NotificationEvent: state.NotificationEvent{
+				ScriptHash: contract,
+				Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(name)}),
+			},
+			BlockIndex: height,
+			TxHash:     random.Uint256(),
+		}
+	}
+	// Heights and positions are intentionally unordered.
+	expected := []*state.ContractNotification{
+		newNotification("Transfer", 1),
+		newNotification("Transfer", 1),
+		newNotification("Transfer", 3),
+		newNotification("Transfer", 300),
+	}
+	require.NoError(t, dao.PutContractNotification("Transfer", 0, expected[3]))
+	require.NoError(t, dao.PutContractNotification("Transfer", 1, expected[1]))
+	require.NoError(t, dao.PutContractNotification("Transfer", 0, expected[0]))
+	require.NoError(t, dao.PutContractNotification("Transfer", 0, expected[2]))
+	require.NoError(t, dao.PutContractNotification("Transfe", 0, newNotification("Transfe", 2)))
+	require.NoError(t, dao.PutContractNotification("TransferX", 0, newNotification("TransferX", 2)))
+
+	check := func(t *testing.T, from, to uint32, skip, limit int, more bool, exp ...*state.ContractNotification) {
+		res, m, err := dao.GetContractNotifications(contract, "Transfer", from, to, skip, limit)
+		require.NoError(t, err)
+		require.Equal(t, more, m)
+		require.Equal(t, len(exp), len(res))
+		for i := range exp {
+			require.Equal(t, *exp[i], res[i])
+		}
+	}
+	check(t, 0, 1000, 0, 10, false, expected...)
+	check(t, 0, 1000, 0, 4, false, expected...)
+	check(t, 0, 1, 0, 10, false, expected[:2]...)
+	check(t, 2, 300, 0, 10, false, expected[2:]...)
+	check(t, 0, 1000, 1, 2, true, expected[1:3]...)
+	check(t, 0, 1000, 4, 2, false)
+
+	res, _, err := dao.GetContractNotifications(random.Uint160(), "Transfer", 0, 1000, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(res))
+}
+
+func TestPutGetAccountTransactions(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore())
+	acc := random.Uint160()
+	newTx := func(height uint32) *state.AccountTransaction {
+		return &state.AccountTransaction{
+			BlockIndex: height,
+			TxHash:     random.Uint256(),
+		}
+	}
+	// Newest first.
+	expected := []*state.AccountTransaction{newTx(300), newTx(3), newTx(1), newTx(1)}
+	require.NoError(t, dao.PutAccountTransaction(acc, 0, expected[3]))
+	require.NoError(t, dao.PutAccountTransaction(acc, 0, expected[1]))
+	require.NoError(t, dao.PutAccountTransaction(acc, 1, expected[2]))
+	require.NoError(t, dao.PutAccountTransaction(acc, 5, expected[0]))
+	require.NoError(t, dao.PutAccountTransaction(random.Uint160(), 0, newTx(2)))
+
+	check := func(t *testing.T, skip, limit int, more bool, exp ...*state.AccountTransaction) {
+		res, m, err := dao.GetAccountTransactions(acc, skip, limit)
+		require.NoError(t, err)
+		require.Equal(t, more, m)
+		require.Equal(t, len(exp), len(res))
+		for i := range exp {
+			require.Equal(t, *exp[i], res[i])
+		}
+	}
+	check(t, 0, 10, false, expected...)
+	check(t, 1, 2, true, expected[1:3]...)
+	check(t, 2, 2, false, expected[2:]...)
+	check(t, 4, 2, false)
+}
+
+func TestPutGetStateRoot(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore())
+	root := &state.MPTRoot{
+		Index: 17,
+		Root:  random.Uint256(),
+	}
+	_, err := dao.GetStateRoot(root.Index)
+	require.Error(t, err)
+	require.NoError(t, dao.PutStateRoot(root))
+	gotRoot, err := dao.GetStateRoot(root.Index)
+	require.NoError(t, err)
+	require.Equal(t, root, gotRoot)
+}
+
 func TestPutGetStorageItem(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore())
 	hash := random.Uint160()
@@ -121,6 +216,24 @@ func TestDeleteStorageItem(t *testing.T) {
 	require.Nil(t, gotStorageItem)
 }
 
+func TestSeekStorageItems(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore())
+	hash := random.Uint160()
+	for _, k := range []string{"aa", "ab", "ac", "b"} {
+		require.NoError(t, dao.PutStorageItem(hash, []byte(k), &state.StorageItem{Value: []byte(k)}))
+	}
+	require.NoError(t, dao.PutStorageItem(random.Uint160(), []byte("ab"), &state.StorageItem{Value: []byte{}}))
+
+	var keys []string
+	err := dao.SeekStorageItems(hash, []byte("a"), []byte("b"), func(k []byte, si *state.StorageItem) bool {
+		require.Equal(t, k, si.Value)
+		keys = append(keys, string(k))
+		return len(keys) < 1
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ab"}, keys)
+}
+
 func TestGetBlock_NotExists(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore())
 	hash := random.Uint256()
@@ -33,8 +33,17 @@ var neoOwner = testchain.MultisigScriptHash()
 // newTestChain should be called before newBlock invocation to properly setup
 // global state.
 func newTestChain(t *testing.T) *Blockchain {
+	return newTestChainWithCustomCfg(t, nil)
+}
+
+// newTestChainWithCustomCfg returns test chain with the unit test network
+// configuration modified by f.
+func newTestChainWithCustomCfg(t *testing.T, f func(*config.ProtocolConfiguration)) *Blockchain {
 	unitTestNetCfg, err := config.Load("../../config", config.ModeUnitTestNet)
 	require.NoError(t, err)
+	if f != nil {
+		f(&unitTestNetCfg.ProtocolConfiguration)
+	}
 	chain, err := NewBlockchain(storage.NewMemoryStore(), unitTestNetCfg.ProtocolConfiguration, zaptest.NewLogger(t))
 	require.NoError(t, err)
 	go chain.Run()
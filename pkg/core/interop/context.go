@@ -30,7 +30,9 @@ type Context struct {
 	DAO           *dao.Cached
 	LowerDAO      dao.DAO
 	Notifications []state.NotificationEvent
+	Logs          []state.LogEvent
 	Log           *zap.Logger
+	VM            *vm.VM
 }
 
 // NewContext returns new interop context.
@@ -58,6 +60,9 @@ type Function struct {
 	Name  string
 	Func  func(*Context, *vm.VM) error
 	Price int
+	// RequiredFlags is a set of flags which must be set during script invocations.
+	// Default value is NoneFlag i.e. no flags are required.
+	RequiredFlags smartcontract.CallFlag
 }
 
 // Method is a signature for a native method.
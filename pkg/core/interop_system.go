@@ -7,7 +7,6 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
-	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -49,6 +48,14 @@ func getBlockHashFromElement(bc blockchainer.Blockchainer, element *vm.Element)
 	return hash, nil
 }
 
+// isTraceableBlock checks whether block with the given index is available to
+// contracts (it's not older than MaxTraceableBlocks).
+func isTraceableBlock(ic *interop.Context, index uint32) bool {
+	height := ic.Chain.BlockHeight()
+	max := ic.Chain.GetConfig().MaxTraceableBlocks
+	return index <= height && (max == 0 || index+max > height)
+}
+
 // bcGetBlock returns current block.
 func bcGetBlock(ic *interop.Context, v *vm.VM) error {
 	hash, err := getBlockHashFromElement(ic.Chain, v.Estack().Pop())
@@ -56,7 +63,7 @@ func bcGetBlock(ic *interop.Context, v *vm.VM) error {
 		return err
 	}
 	block, err := ic.Chain.GetBlock(hash)
-	if err != nil {
+	if err != nil || !isTraceableBlock(ic, block.Index) {
 		v.Estack().PushVal([]byte{})
 	} else {
 		v.Estack().PushVal(stackitem.NewInterop(block))
@@ -102,19 +109,27 @@ func bcGetHeight(ic *interop.Context, v *vm.VM) error {
 }
 
 // getTransactionAndHeight gets parameter from the vm evaluation stack and
-// returns transaction and its height if it's present in the blockchain.
-func getTransactionAndHeight(cd *dao.Cached, v *vm.VM) (*transaction.Transaction, uint32, error) {
+// returns transaction and its height if it's present in the blockchain and
+// is traceable.
+func getTransactionAndHeight(ic *interop.Context, v *vm.VM) (*transaction.Transaction, uint32, error) {
 	hashbytes := v.Estack().Pop().Bytes()
 	hash, err := util.Uint256DecodeBytesBE(hashbytes)
 	if err != nil {
 		return nil, 0, err
 	}
-	return cd.GetTransaction(hash)
+	tx, h, err := ic.DAO.GetTransaction(hash)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !isTraceableBlock(ic, h) {
+		return nil, 0, errors.New("transaction is not traceable")
+	}
+	return tx, h, nil
 }
 
 // bcGetTransaction returns transaction.
 func bcGetTransaction(ic *interop.Context, v *vm.VM) error {
-	tx, _, err := getTransactionAndHeight(ic.DAO, v)
+	tx, _, err := getTransactionAndHeight(ic, v)
 	if err != nil {
 		return err
 	}
@@ -124,7 +139,7 @@ func bcGetTransaction(ic *interop.Context, v *vm.VM) error {
 
 // bcGetTransactionHeight returns transaction height.
 func bcGetTransactionHeight(ic *interop.Context, v *vm.VM) error {
-	_, h, err := getTransactionAndHeight(ic.DAO, v)
+	_, h, err := getTransactionAndHeight(ic, v)
 	if err != nil {
 		return err
 	}
@@ -301,7 +316,9 @@ func runtimeNotify(ic *interop.Context, v *vm.VM) error {
 
 // runtimeLog logs the message passed.
 func runtimeLog(ic *interop.Context, v *vm.VM) error {
-	msg := fmt.Sprintf("%q", v.Estack().Pop().Bytes())
+	b := v.Estack().Pop().Bytes()
+	ic.Logs = append(ic.Logs, state.LogEvent{ScriptHash: v.GetCurrentScriptHash(), Message: string(b)})
+	msg := fmt.Sprintf("%q", b)
 	ic.Log.Info("runtime log",
 		zap.Stringer("script", v.GetCurrentScriptHash()),
 		zap.String("logs", msg))
@@ -489,17 +506,20 @@ func contractCallEx(ic *interop.Context, v *vm.VM) error {
 	return contractCallExInternal(ic, v, h, method, args, flags)
 }
 
-func contractCallExInternal(ic *interop.Context, v *vm.VM, h []byte, method stackitem.Item, args stackitem.Item, _ smartcontract.CallFlag) error {
+func contractCallExInternal(ic *interop.Context, v *vm.VM, h []byte, method stackitem.Item, args stackitem.Item, f smartcontract.CallFlag) error {
 	u, err := util.Uint160DecodeBytesBE(h)
 	if err != nil {
 		return errors.New("invalid contract hash")
 	}
+	if f&^smartcontract.All != 0 {
+		return errors.New("call flags out of range")
+	}
 	script, _ := ic.GetContract(u)
 	if script == nil {
 		return errors.New("contract not found")
 	}
-	// TODO perform flags checking after #923
-	v.LoadScript(script)
+	// Called contract can't have more permissions than the caller has.
+	v.LoadScriptWithFlags(script, v.Context().GetCallFlags()&f)
 	v.Estack().PushVal(args)
 	v.Estack().PushVal(method)
 	return nil
@@ -16,6 +16,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/iterator"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 )
@@ -29,6 +30,7 @@ func SpawnVM(ic *interop.Context) *vm.VM {
 	if ic.Chain != nil {
 		vm.RegisterInteropGetter(ic.Chain.(*Blockchain).contracts.GetNativeInterop(ic))
 	}
+	ic.VM = vm
 	return vm
 }
 
@@ -52,9 +54,13 @@ func getInteropFromSlice(ic *interop.Context, slice []interop.Function) func(uin
 			return slice[i].ID >= id
 		})
 		if n < len(slice) && slice[n].ID == id {
-			return &vm.InteropFuncPrice{Func: func(v *vm.VM) error {
-				return slice[n].Func(ic, v)
-			}, Price: slice[n].Price}
+			return &vm.InteropFuncPrice{
+				Func: func(v *vm.VM) error {
+					return slice[n].Func(ic, v)
+				},
+				Price:         slice[n].Price,
+				RequiredFlags: slice[n].RequiredFlags,
+			}
 		}
 		return nil
 	}
@@ -71,9 +77,9 @@ var systemInterops = []interop.Function{
 	{Name: "System.Blockchain.GetHeight", Func: bcGetHeight, Price: 1},
 	{Name: "System.Blockchain.GetTransaction", Func: bcGetTransaction, Price: 200},
 	{Name: "System.Blockchain.GetTransactionHeight", Func: bcGetTransactionHeight, Price: 100},
-	{Name: "System.Contract.Call", Func: contractCall, Price: 1},
-	{Name: "System.Contract.CallEx", Func: contractCallEx, Price: 1},
-	{Name: "System.Contract.Destroy", Func: contractDestroy, Price: 1},
+	{Name: "System.Contract.Call", Func: contractCall, Price: 1, RequiredFlags: smartcontract.AllowCall},
+	{Name: "System.Contract.CallEx", Func: contractCallEx, Price: 1, RequiredFlags: smartcontract.AllowCall},
+	{Name: "System.Contract.Destroy", Func: contractDestroy, Price: 1, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "System.Contract.GetStorageContext", Func: contractGetStorageContext, Price: 1},
 	{Name: "System.ExecutionEngine.GetCallingScriptHash", Func: engineGetCallingScriptHash, Price: 1},
 	{Name: "System.ExecutionEngine.GetEntryScriptHash", Func: engineGetEntryScriptHash, Price: 1},
@@ -87,16 +93,16 @@ var systemInterops = []interop.Function{
 	{Name: "System.Runtime.Deserialize", Func: runtimeDeserialize, Price: 1},
 	{Name: "System.Runtime.GetTime", Func: runtimeGetTime, Price: 1},
 	{Name: "System.Runtime.GetTrigger", Func: runtimeGetTrigger, Price: 1},
-	{Name: "System.Runtime.Log", Func: runtimeLog, Price: 1},
-	{Name: "System.Runtime.Notify", Func: runtimeNotify, Price: 1},
+	{Name: "System.Runtime.Log", Func: runtimeLog, Price: 1, RequiredFlags: smartcontract.AllowNotify},
+	{Name: "System.Runtime.Notify", Func: runtimeNotify, Price: 1, RequiredFlags: smartcontract.AllowNotify},
 	{Name: "System.Runtime.Platform", Func: runtimePlatform, Price: 1},
 	{Name: "System.Runtime.Serialize", Func: runtimeSerialize, Price: 1},
-	{Name: "System.Storage.Delete", Func: storageDelete, Price: 100},
+	{Name: "System.Storage.Delete", Func: storageDelete, Price: 100, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "System.Storage.Get", Func: storageGet, Price: 100},
 	{Name: "System.Storage.GetContext", Func: storageGetContext, Price: 1},
 	{Name: "System.Storage.GetReadOnlyContext", Func: storageGetReadOnlyContext, Price: 1},
-	{Name: "System.Storage.Put", Func: storagePut, Price: 0}, // These don't have static price in C# code.
-	{Name: "System.Storage.PutEx", Func: storagePutEx, Price: 0},
+	{Name: "System.Storage.Put", Func: storagePut, Price: 0, RequiredFlags: smartcontract.AllowModifyStates}, // These don't have static price in C# code.
+	{Name: "System.Storage.PutEx", Func: storagePutEx, Price: 0, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "System.StorageContext.AsReadOnly", Func: storageContextAsReadOnly, Price: 1},
 	{Name: "System.Transaction.GetHash", Func: txGetHash, Price: 1},
 }
@@ -117,12 +123,12 @@ var neoInterops = []interop.Function{
 	{Name: "Neo.Blockchain.GetHeight", Func: bcGetHeight, Price: 1},
 	{Name: "Neo.Blockchain.GetTransaction", Func: bcGetTransaction, Price: 100},
 	{Name: "Neo.Blockchain.GetTransactionHeight", Func: bcGetTransactionHeight, Price: 100},
-	{Name: "Neo.Contract.Create", Func: contractCreate, Price: 0},
-	{Name: "Neo.Contract.Destroy", Func: contractDestroy, Price: 1},
+	{Name: "Neo.Contract.Create", Func: contractCreate, Price: 0, RequiredFlags: smartcontract.AllowModifyStates},
+	{Name: "Neo.Contract.Destroy", Func: contractDestroy, Price: 1, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "Neo.Contract.GetScript", Func: contractGetScript, Price: 1},
 	{Name: "Neo.Contract.GetStorageContext", Func: contractGetStorageContext, Price: 1},
 	{Name: "Neo.Contract.IsPayable", Func: contractIsPayable, Price: 1},
-	{Name: "Neo.Contract.Migrate", Func: contractMigrate, Price: 0},
+	{Name: "Neo.Contract.Migrate", Func: contractMigrate, Price: 0, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "Neo.Crypto.ECDsaVerify", Func: crypto.ECDSAVerify, Price: 1},
 	{Name: "Neo.Crypto.ECDsaCheckMultiSig", Func: crypto.ECDSACheckMultisig, Price: 1},
 	{Name: "Neo.Crypto.SHA256", Func: crypto.Sha256, Price: 1},
@@ -147,15 +153,15 @@ var neoInterops = []interop.Function{
 	{Name: "Neo.Runtime.Deserialize", Func: runtimeDeserialize, Price: 1},
 	{Name: "Neo.Runtime.GetTime", Func: runtimeGetTime, Price: 1},
 	{Name: "Neo.Runtime.GetTrigger", Func: runtimeGetTrigger, Price: 1},
-	{Name: "Neo.Runtime.Log", Func: runtimeLog, Price: 1},
-	{Name: "Neo.Runtime.Notify", Func: runtimeNotify, Price: 1},
+	{Name: "Neo.Runtime.Log", Func: runtimeLog, Price: 1, RequiredFlags: smartcontract.AllowNotify},
+	{Name: "Neo.Runtime.Notify", Func: runtimeNotify, Price: 1, RequiredFlags: smartcontract.AllowNotify},
 	{Name: "Neo.Runtime.Serialize", Func: runtimeSerialize, Price: 1},
-	{Name: "Neo.Storage.Delete", Func: storageDelete, Price: 100},
+	{Name: "Neo.Storage.Delete", Func: storageDelete, Price: 100, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "Neo.Storage.Find", Func: storageFind, Price: 1},
 	{Name: "Neo.Storage.Get", Func: storageGet, Price: 100},
 	{Name: "Neo.Storage.GetContext", Func: storageGetContext, Price: 1},
 	{Name: "Neo.Storage.GetReadOnlyContext", Func: storageGetReadOnlyContext, Price: 1},
-	{Name: "Neo.Storage.Put", Func: storagePut, Price: 0},
+	{Name: "Neo.Storage.Put", Func: storagePut, Price: 0, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "Neo.StorageContext.AsReadOnly", Func: storageContextAsReadOnly, Price: 1},
 	{Name: "Neo.Transaction.GetAttributes", Func: txGetAttributes, Price: 1},
 	{Name: "Neo.Transaction.GetHash", Func: txGetHash, Price: 1},
@@ -180,11 +186,11 @@ var neoInterops = []interop.Function{
 	{Name: "AntShares.Blockchain.GetHeader", Func: bcGetHeader, Price: 100},
 	{Name: "AntShares.Blockchain.GetHeight", Func: bcGetHeight, Price: 1},
 	{Name: "AntShares.Blockchain.GetTransaction", Func: bcGetTransaction, Price: 100},
-	{Name: "AntShares.Contract.Create", Func: contractCreate, Price: 0},
-	{Name: "AntShares.Contract.Destroy", Func: contractDestroy, Price: 1},
+	{Name: "AntShares.Contract.Create", Func: contractCreate, Price: 0, RequiredFlags: smartcontract.AllowModifyStates},
+	{Name: "AntShares.Contract.Destroy", Func: contractDestroy, Price: 1, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "AntShares.Contract.GetScript", Func: contractGetScript, Price: 1},
 	{Name: "AntShares.Contract.GetStorageContext", Func: contractGetStorageContext, Price: 1},
-	{Name: "AntShares.Contract.Migrate", Func: contractMigrate, Price: 0},
+	{Name: "AntShares.Contract.Migrate", Func: contractMigrate, Price: 0, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "AntShares.Header.GetHash", Func: headerGetHash, Price: 1},
 	{Name: "AntShares.Header.GetMerkleRoot", Func: headerGetMerkleRoot, Price: 1},
 	{Name: "AntShares.Header.GetNextConsensus", Func: headerGetNextConsensus, Price: 1},
@@ -192,12 +198,12 @@ var neoInterops = []interop.Function{
 	{Name: "AntShares.Header.GetTimestamp", Func: headerGetTimestamp, Price: 1},
 	{Name: "AntShares.Header.GetVersion", Func: headerGetVersion, Price: 1},
 	{Name: "AntShares.Runtime.CheckWitness", Func: runtime.CheckWitness, Price: 200},
-	{Name: "AntShares.Runtime.Log", Func: runtimeLog, Price: 1},
-	{Name: "AntShares.Runtime.Notify", Func: runtimeNotify, Price: 1},
-	{Name: "AntShares.Storage.Delete", Func: storageDelete, Price: 100},
+	{Name: "AntShares.Runtime.Log", Func: runtimeLog, Price: 1, RequiredFlags: smartcontract.AllowNotify},
+	{Name: "AntShares.Runtime.Notify", Func: runtimeNotify, Price: 1, RequiredFlags: smartcontract.AllowNotify},
+	{Name: "AntShares.Storage.Delete", Func: storageDelete, Price: 100, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "AntShares.Storage.Get", Func: storageGet, Price: 100},
 	{Name: "AntShares.Storage.GetContext", Func: storageGetContext, Price: 1},
-	{Name: "AntShares.Storage.Put", Func: storagePut, Price: 0},
+	{Name: "AntShares.Storage.Put", Func: storagePut, Price: 0, RequiredFlags: smartcontract.AllowModifyStates},
 	{Name: "AntShares.Transaction.GetAttributes", Func: txGetAttributes, Price: 1},
 	{Name: "AntShares.Transaction.GetHash", Func: txGetHash, Price: 1},
 }
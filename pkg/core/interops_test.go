@@ -7,9 +7,15 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
 )
 
@@ -69,3 +75,67 @@ func TestUnexpectedNonInterops(t *testing.T) {
 		}
 	}
 }
+
+func TestContractCallExFlags(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.Close()
+
+	ic := chain.newInteropContext(trigger.Application, chain.dao, nil, nil)
+
+	w := io.NewBufBinWriter()
+	emit.Opcode(w.BinWriter, opcode.DROP) // method
+	emit.Opcode(w.BinWriter, opcode.DROP) // args
+	emit.String(w.BinWriter, "event")
+	emit.Syscall(w.BinWriter, "System.Runtime.Notify")
+	emit.Opcode(w.BinWriter, opcode.PUSH1)
+	require.NoError(t, w.Err)
+	cs := &state.Contract{Script: w.Bytes()}
+	require.NoError(t, ic.DAO.PutContractState(cs))
+
+	getScript := func(h util.Uint160, method string, f smartcontract.CallFlag) []byte {
+		w := io.NewBufBinWriter()
+		emit.Int(w.BinWriter, int64(f))
+		emit.Opcode(w.BinWriter, opcode.NEWARRAY0)
+		emit.String(w.BinWriter, method)
+		emit.Bytes(w.BinWriter, h.BytesBE())
+		emit.Syscall(w.BinWriter, "System.Contract.CallEx")
+		require.NoError(t, w.Err)
+		return w.Bytes()
+	}
+	checkCall := func(t *testing.T, script []byte, entryFlags smartcontract.CallFlag, ok bool) {
+		v := SpawnVM(ic)
+		v.LoadScriptWithFlags(script, entryFlags)
+		err := v.Run()
+		if ok {
+			require.NoError(t, err)
+			require.False(t, v.HasFailed())
+		} else {
+			require.Error(t, err)
+			require.True(t, v.HasFailed())
+		}
+	}
+
+	t.Run("All", func(t *testing.T) {
+		checkCall(t, getScript(cs.ScriptHash(), "any", smartcontract.All), smartcontract.All, true)
+	})
+	t.Run("ReadOnly", func(t *testing.T) {
+		checkCall(t, getScript(cs.ScriptHash(), "any", smartcontract.ReadOnly), smartcontract.All, true)
+	})
+	t.Run("MissingAllowNotify", func(t *testing.T) {
+		checkCall(t, getScript(cs.ScriptHash(), "any", smartcontract.AllowCall), smartcontract.All, false)
+	})
+	t.Run("NarrowedByCaller", func(t *testing.T) {
+		checkCall(t, getScript(cs.ScriptHash(), "any", smartcontract.All), smartcontract.AllowCall, false)
+	})
+	t.Run("MissingAllowCall", func(t *testing.T) {
+		checkCall(t, getScript(cs.ScriptHash(), "any", smartcontract.All), smartcontract.AllowNotify, false)
+	})
+	t.Run("InvalidFlags", func(t *testing.T) {
+		checkCall(t, getScript(cs.ScriptHash(), "any", smartcontract.CallFlag(0xFF)), smartcontract.All, false)
+	})
+	t.Run("NativeReadOnly", func(t *testing.T) {
+		h := chain.contracts.Policy.Hash
+		checkCall(t, getScript(h, "getFeePerByte", smartcontract.NoneFlag), smartcontract.All, true)
+		checkCall(t, getScript(h, "setFeePerByte", smartcontract.ReadOnly), smartcontract.All, false)
+	})
+}
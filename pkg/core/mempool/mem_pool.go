@@ -8,6 +8,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/atomic"
 )
 
 var (
@@ -28,6 +29,7 @@ type item struct {
 	txn       *transaction.Transaction
 	timeStamp time.Time
 	isLowPrio bool
+	data      interface{}
 }
 
 // items is a slice of item.
@@ -48,6 +50,17 @@ type Pool struct {
 	fees         map[util.Uint160]utilityBalanceAndFees
 
 	capacity int
+
+	// Subscriptions management, see RunSubscriptions.
+	subscriptionsOn atomic.Bool
+	stopCh          chan struct{}
+	subCh           chan chan<- Event
+	unsubCh         chan chan<- Event
+	// Events are queued here and then delivered by the dispatching
+	// routine, so that pool operations never wait for subscribers.
+	eventsLock sync.Mutex
+	events     []Event
+	eventsCh   chan struct{}
 }
 
 func (p items) Len() int           { return len(p) }
@@ -145,12 +158,16 @@ func (mp *Pool) addSendersFee(tx *transaction.Transaction) {
 	mp.fees[tx.Sender] = senderFee
 }
 
-// Add tries to add given transaction to the Pool.
-func (mp *Pool) Add(t *transaction.Transaction, fee Feer) error {
+// Add tries to add given transaction to the Pool. Optional data is stored
+// along with the transaction and can be retrieved with TryGetData.
+func (mp *Pool) Add(t *transaction.Transaction, fee Feer, data ...interface{}) error {
 	var pItem = &item{
 		txn:       t,
 		timeStamp: time.Now().UTC(),
 	}
+	if len(data) > 0 {
+		pItem.data = data[0]
+	}
 	pItem.isLowPrio = fee.IsLowPriority(pItem.txn.NetworkFee)
 	mp.lock.Lock()
 	if !mp.checkTxConflicts(t, fee) {
@@ -173,6 +190,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer) error {
 		return pItem.CompareTo(mp.verifiedTxes[n]) > 0
 	})
 
+	var unlucky *item
 	// We've reached our capacity already.
 	if len(mp.verifiedTxes) == mp.capacity {
 		// Less prioritized than the least prioritized we already have, won't fit.
@@ -181,7 +199,7 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer) error {
 			return ErrOOM
 		}
 		// Ditch the last one.
-		unlucky := mp.verifiedTxes[len(mp.verifiedTxes)-1]
+		unlucky = mp.verifiedTxes[len(mp.verifiedTxes)-1]
 		delete(mp.verifiedMap, unlucky.txn.Hash())
 		mp.verifiedTxes[len(mp.verifiedTxes)-1] = pItem
 	} else {
@@ -194,6 +212,10 @@ func (mp *Pool) Add(t *transaction.Transaction, fee Feer) error {
 	mp.addSendersFee(pItem.txn)
 
 	updateMempoolMetrics(len(mp.verifiedTxes))
+	if unlucky != nil {
+		mp.sendEvent(Event{Type: TransactionRemoved, Tx: unlucky.txn})
+	}
+	mp.sendEvent(Event{Type: TransactionAdded, Tx: t})
 	mp.lock.Unlock()
 	return nil
 }
@@ -218,6 +240,7 @@ func (mp *Pool) Remove(hash util.Uint256) {
 		senderFee := mp.fees[it.txn.Sender]
 		senderFee.feeSum -= it.txn.SystemFee + it.txn.NetworkFee
 		mp.fees[it.txn.Sender] = senderFee
+		mp.sendEvent(Event{Type: TransactionRemoved, Tx: it.txn})
 	}
 	updateMempoolMetrics(len(mp.verifiedTxes))
 	mp.lock.Unlock()
@@ -237,6 +260,7 @@ func (mp *Pool) RemoveStale(isOK func(*transaction.Transaction) bool, feer Feer)
 			newVerifiedTxes = append(newVerifiedTxes, itm)
 		} else {
 			delete(mp.verifiedMap, itm.txn.Hash())
+			mp.sendEvent(Event{Type: TransactionRemoved, Tx: itm.txn})
 		}
 	}
 	mp.verifiedTxes = newVerifiedTxes
@@ -250,6 +274,10 @@ func NewMemPool(capacity int) Pool {
 		verifiedTxes: make([]*item, 0, capacity),
 		capacity:     capacity,
 		fees:         make(map[util.Uint160]utilityBalanceAndFees),
+		stopCh:       make(chan struct{}),
+		eventsCh:     make(chan struct{}, 1),
+		subCh:        make(chan chan<- Event),
+		unsubCh:      make(chan chan<- Event),
 	}
 }
 
@@ -264,6 +292,18 @@ func (mp *Pool) TryGetValue(hash util.Uint256) (*transaction.Transaction, bool)
 	return nil, false
 }
 
+// TryGetData returns data associated with the specified transaction if it
+// exists in the memory pool.
+func (mp *Pool) TryGetData(hash util.Uint256) (interface{}, bool) {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	if pItem, ok := mp.verifiedMap[hash]; ok {
+		return pItem.data, ok
+	}
+
+	return nil, false
+}
+
 // GetVerifiedTransactions returns a slice of transactions with their fees.
 func (mp *Pool) GetVerifiedTransactions() []*transaction.Transaction {
 	mp.lock.RLock()
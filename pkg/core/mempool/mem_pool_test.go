@@ -256,3 +256,73 @@ func TestMemPoolFees(t *testing.T) {
 	}, &FeerStub{})
 	require.Equal(t, 0, len(mp.fees))
 }
+
+func TestMemPoolData(t *testing.T) {
+	fs := &FeerStub{}
+	mp := NewMemPool(10)
+	tx1 := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx2 := transaction.New([]byte{byte(opcode.PUSH2)}, 0)
+	require.NoError(t, mp.Add(tx1, fs, "data"))
+	require.NoError(t, mp.Add(tx2, fs))
+
+	data, ok := mp.TryGetData(tx1.Hash())
+	require.True(t, ok)
+	require.Equal(t, "data", data)
+
+	data, ok = mp.TryGetData(tx2.Hash())
+	require.True(t, ok)
+	require.Nil(t, data)
+
+	mp.Remove(tx1.Hash())
+	_, ok = mp.TryGetData(tx1.Hash())
+	require.False(t, ok)
+}
+
+func TestSubscriptions(t *testing.T) {
+	var fs = &FeerStub{lowPriority: true}
+	mp := NewMemPool(2)
+	mp.RunSubscriptions()
+	defer mp.StopSubscriptions()
+
+	ch := make(chan Event, 10)
+	mp.SubscribeForTransactions(ch)
+
+	txs := make([]*transaction.Transaction, 4)
+	for i := range txs {
+		txs[i] = transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		txs[i].Nonce = uint32(i)
+		txs[i].NetworkFee = util.Fixed8(i)
+	}
+	require.NoError(t, mp.Add(txs[0], fs))
+	require.NoError(t, mp.Add(txs[1], fs))
+	require.Equal(t, Event{Type: TransactionAdded, Tx: txs[0]}, <-ch)
+	require.Equal(t, Event{Type: TransactionAdded, Tx: txs[1]}, <-ch)
+
+	// The least prioritized one is pushed out.
+	require.NoError(t, mp.Add(txs[2], fs))
+	require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[0]}, <-ch)
+	require.Equal(t, Event{Type: TransactionAdded, Tx: txs[2]}, <-ch)
+
+	mp.RemoveStale(func(tx *transaction.Transaction) bool { return tx != txs[1] }, fs)
+	require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[1]}, <-ch)
+
+	mp.Remove(txs[2].Hash())
+	require.Equal(t, Event{Type: TransactionRemoved, Tx: txs[2]}, <-ch)
+
+	mp.UnsubscribeFromTransactions(ch)
+	require.NoError(t, mp.Add(txs[3], fs))
+	require.Equal(t, 0, len(ch))
+
+	// Pool operations don't wait for subscribers.
+	stuck := make(chan Event)
+	mp.SubscribeForTransactions(stuck)
+	require.NoError(t, mp.Add(txs[0], fs))
+	mp.Remove(txs[0].Hash())
+	require.NoError(t, mp.Add(txs[1], fs))
+	mp.RemoveStale(func(*transaction.Transaction) bool { return false }, fs)
+
+	mp.StopSubscriptions()
+	// No events and no blocking after stop.
+	mp.Remove(txs[3].Hash())
+	require.Equal(t, 0, len(ch))
+}
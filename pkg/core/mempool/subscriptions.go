@@ -0,0 +1,104 @@
+package mempool
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// EventType is a type of mempool event.
+type EventType byte
+
+const (
+	// TransactionAdded is emitted when a transaction is added to the pool.
+	TransactionAdded EventType = 0x01
+	// TransactionRemoved is emitted when a transaction is removed from the
+	// pool (because it's included into a block, became invalid or was
+	// pushed out of the pool by more prioritized transactions).
+	TransactionRemoved EventType = 0x02
+)
+
+// Event represents one of mempool events: transaction was added or removed.
+type Event struct {
+	Type EventType
+	Tx   *transaction.Transaction
+}
+
+// RunSubscriptions starts mempool events dispatching routine, no events are
+// generated before this call. It must be called at most once.
+func (mp *Pool) RunSubscriptions() {
+	mp.subscriptionsOn.Store(true)
+	go mp.notificationDispatcher()
+}
+
+// StopSubscriptions stops mempool events dispatching routine.
+func (mp *Pool) StopSubscriptions() {
+	if mp.subscriptionsOn.CAS(true, false) {
+		close(mp.stopCh)
+	}
+}
+
+// SubscribeForTransactions adds given channel to mempool events broadcasting,
+// so when there is a transaction added to or removed from the pool you'll
+// receive an Event via this channel. Make sure it's read from regularly as not
+// reading these events delays their delivery to other subscribers (pool
+// operations themselves are not affected, events are queued).
+func (mp *Pool) SubscribeForTransactions(ch chan<- Event) {
+	select {
+	case mp.subCh <- ch:
+	case <-mp.stopCh:
+	}
+}
+
+// UnsubscribeFromTransactions unsubscribes given channel from mempool events,
+// you can close it afterwards. Passing non-subscribed channel is a no-op.
+func (mp *Pool) UnsubscribeFromTransactions(ch chan<- Event) {
+	select {
+	case mp.unsubCh <- ch:
+	case <-mp.stopCh:
+	}
+}
+
+// sendEvent queues the event for the dispatching routine if it's running. It
+// never blocks, so it can be called with the pool (or chain) lock held.
+func (mp *Pool) sendEvent(e Event) {
+	if !mp.subscriptionsOn.Load() {
+		return
+	}
+	mp.eventsLock.Lock()
+	mp.events = append(mp.events, e)
+	mp.eventsLock.Unlock()
+	select {
+	case mp.eventsCh <- struct{}{}:
+	default:
+	}
+}
+
+// notificationDispatcher manages subscriptions and broadcasts events to
+// subscribers.
+func (mp *Pool) notificationDispatcher() {
+	// It's a set of subscribers, map is just convenient for managing it.
+	var feed = make(map[chan<- Event]bool)
+	for {
+		select {
+		case <-mp.stopCh:
+			return
+		case ch := <-mp.subCh:
+			feed[ch] = true
+		case ch := <-mp.unsubCh:
+			delete(feed, ch)
+		case <-mp.eventsCh:
+			mp.eventsLock.Lock()
+			events := mp.events
+			mp.events = nil
+			mp.eventsLock.Unlock()
+			for _, e := range events {
+				for ch := range feed {
+					select {
+					case ch <- e:
+					case <-mp.stopCh:
+						return
+					}
+				}
+			}
+		}
+	}
+}
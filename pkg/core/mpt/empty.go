@@ -0,0 +1,26 @@
+package mpt
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// EmptyNode represents an absent child node or an empty trie.
+type EmptyNode struct{}
+
+var _ Node = EmptyNode{}
+
+// Type implements Node interface.
+func (EmptyNode) Type() NodeType { return EmptyT }
+
+// Hash implements Node interface. Empty node has zero hash.
+func (EmptyNode) Hash() util.Uint256 { return util.Uint256{} }
+
+// Bytes implements Node interface.
+func (EmptyNode) Bytes() []byte { return []byte{byte(EmptyT)} }
+
+// DecodeBinary implements io.Serializable.
+func (EmptyNode) DecodeBinary(*io.BinReader) {}
+
+// EncodeBinary implements io.Serializable.
+func (EmptyNode) EncodeBinary(*io.BinWriter) {}
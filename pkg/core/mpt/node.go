@@ -0,0 +1,93 @@
+package mpt
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NodeType represents node type..
+type NodeType byte
+
+// Node types definitions.
+const (
+	BranchT    NodeType = 0x00
+	ExtensionT NodeType = 0x01
+	LeafT      NodeType = 0x02
+	HashT      NodeType = 0x03
+	EmptyT     NodeType = 0x04
+)
+
+// NodeObject represents Node together with it's type.
+// It is used for serialization/deserialization where type info
+// is also expected.
+type NodeObject struct {
+	Node
+}
+
+// Node represents common interface of all MPT nodes.
+type Node interface {
+	io.Serializable
+	Hash() util.Uint256
+	Type() NodeType
+	Bytes() []byte
+}
+
+// EncodeBinary implements io.Serializable.
+func (n NodeObject) EncodeBinary(w *io.BinWriter) {
+	encodeNodeWithType(n.Node, w)
+}
+
+// DecodeBinary implements io.Serializable.
+func (n *NodeObject) DecodeBinary(r *io.BinReader) {
+	typ := NodeType(r.ReadB())
+	switch typ {
+	case BranchT:
+		n.Node = new(BranchNode)
+	case ExtensionT:
+		n.Node = new(ExtensionNode)
+	case HashT:
+		n.Node = new(HashNode)
+	case LeafT:
+		n.Node = new(LeafNode)
+	case EmptyT:
+		n.Node = EmptyNode{}
+	default:
+		r.Err = fmt.Errorf("invalid node type: %x", typ)
+		return
+	}
+	n.Node.DecodeBinary(r)
+}
+
+// encodeBinaryAsChild encodes n as a reference to it used in parent nodes.
+func encodeBinaryAsChild(n Node, w *io.BinWriter) {
+	if isEmpty(n) {
+		w.WriteB(byte(EmptyT))
+		return
+	}
+	w.WriteB(byte(HashT))
+	w.WriteBytes(n.Hash().BytesBE())
+}
+
+// decodeBinaryAsChild decodes child reference written by encodeBinaryAsChild.
+func decodeBinaryAsChild(r *io.BinReader) Node {
+	var n NodeObject
+	n.DecodeBinary(r)
+	if r.Err != nil {
+		return nil
+	}
+	switch n.Node.(type) {
+	case *HashNode, EmptyNode:
+		return n.Node
+	default:
+		r.Err = fmt.Errorf("invalid child node type: %x", n.Node.Type())
+		return nil
+	}
+}
+
+// isEmpty checks whether n is an empty node.
+func isEmpty(n Node) bool {
+	_, ok := n.(EmptyNode)
+	return ok
+}
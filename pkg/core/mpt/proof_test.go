@@ -0,0 +1,47 @@
+package mpt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrie_GetProof(t *testing.T) {
+	tr := NewTrie(nil, newTestStore())
+	require.NoError(t, tr.Put([]byte{0x12, 0x31}, []byte("value1")))
+	require.NoError(t, tr.Put([]byte{0x12, 0x32}, []byte("value2")))
+	require.NoError(t, tr.Put([]byte{0x23, 0x24}, []byte("value3")))
+	require.NoError(t, tr.Flush())
+
+	t.Run("MissingKey", func(t *testing.T) {
+		_, err := tr.GetProof([]byte{0x12})
+		require.Error(t, err)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		proof, err := tr.GetProof([]byte{0x12, 0x31})
+		require.NoError(t, err)
+
+		v, ok := VerifyProof(tr.StateRoot(), []byte{0x12, 0x31}, proof)
+		require.True(t, ok)
+		require.Equal(t, []byte("value1"), v)
+	})
+
+	t.Run("InvalidRoot", func(t *testing.T) {
+		proof, err := tr.GetProof([]byte{0x12, 0x31})
+		require.NoError(t, err)
+
+		root := tr.StateRoot()
+		root[0] ^= 0xFF
+		_, ok := VerifyProof(root, []byte{0x12, 0x31}, proof)
+		require.False(t, ok)
+	})
+
+	t.Run("WrongKey", func(t *testing.T) {
+		proof, err := tr.GetProof([]byte{0x12, 0x31})
+		require.NoError(t, err)
+
+		_, ok := VerifyProof(tr.StateRoot(), []byte{0x23, 0x24}, proof)
+		require.False(t, ok)
+	})
+}
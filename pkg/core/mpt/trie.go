@@ -0,0 +1,430 @@
+package mpt
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Trie is an MPT trie storing all key-value pairs.
+type Trie struct {
+	Store storage.Store
+
+	root Node
+}
+
+// ErrNotFound is returned when requested trie item is missing.
+var ErrNotFound = errors.New("item not found")
+
+// NewTrie returns new MPT trie. It accepts a MemCachedStore to decouple storage errors from logic errors
+// so that all storage errors are processed during `store.Persist()` at the caller.
+// This also has the benefit, that every `Put` can be considered an atomic operation.
+func NewTrie(root Node, store storage.Store) *Trie {
+	if root == nil {
+		root = EmptyNode{}
+	}
+
+	return &Trie{
+		Store: store,
+		root:  root,
+	}
+}
+
+// Get returns value for the provided key in t.
+func (t *Trie) Get(key []byte) ([]byte, error) {
+	path := toNibbles(key)
+	r, bs, err := t.getWithPath(t.root, path)
+	if err != nil {
+		return nil, err
+	}
+	t.root = r
+	return bs, nil
+}
+
+// getWithPath returns value the provided path in a subtrie rooting in curr.
+// It also returns a current node with all hash nodes along the path
+// replaced to their "unhashed" counterparts.
+func (t *Trie) getWithPath(curr Node, path []byte) (Node, []byte, error) {
+	switch n := curr.(type) {
+	case *LeafNode:
+		if len(path) == 0 {
+			return curr, copySlice(n.value), nil
+		}
+	case *BranchNode:
+		i, path := splitPath(path)
+		r, bs, err := t.getWithPath(n.Children[i], path)
+		if err != nil {
+			return nil, nil, err
+		}
+		n.Children[i] = r
+		return n, bs, nil
+	case EmptyNode:
+	case *HashNode:
+		r, err := t.getFromStore(n.hash)
+		if err != nil {
+			return nil, nil, err
+		}
+		return t.getWithPath(r, path)
+	case *ExtensionNode:
+		if bytes.HasPrefix(path, n.key) {
+			r, bs, err := t.getWithPath(n.next, path[len(n.key):])
+			if err != nil {
+				return nil, nil, err
+			}
+			n.next = r
+			return curr, bs, err
+		}
+	default:
+		panic("invalid MPT node type")
+	}
+	return curr, nil, ErrNotFound
+}
+
+// Put puts key-value pair in t.
+func (t *Trie) Put(key, value []byte) error {
+	if len(key) > MaxKeyLength {
+		return errors.New("key is too big")
+	} else if len(value) > MaxValueLength {
+		return errors.New("value is too big")
+	}
+	if len(value) == 0 {
+		return t.Delete(key)
+	}
+	path := toNibbles(key)
+	n := NewLeafNode(value)
+	r, err := t.putIntoNode(t.root, path, n)
+	if err != nil {
+		return err
+	}
+	t.root = r
+	return nil
+}
+
+// putIntoLeaf puts val to trie if current node is a Leaf.
+// It returns Node if curr needs to be replaced and error if any.
+func (t *Trie) putIntoLeaf(curr *LeafNode, path []byte, val Node) (Node, error) {
+	v := val.(*LeafNode)
+	if len(path) == 0 {
+		return v, nil
+	}
+
+	b := NewBranchNode()
+	b.Children[path[0]] = newSubTrie(path[1:], v)
+	b.Children[lastChild] = curr
+	return b, nil
+}
+
+// putIntoBranch puts val to trie if current node is a Branch.
+// It returns Node if curr needs to be replaced and error if any.
+func (t *Trie) putIntoBranch(curr *BranchNode, path []byte, val Node) (Node, error) {
+	i, path := splitPath(path)
+	r, err := t.putIntoNode(curr.Children[i], path, val)
+	if err != nil {
+		return nil, err
+	}
+	curr.Children[i] = r
+	curr.invalidateCache()
+	return curr, nil
+}
+
+// putIntoExtension puts val to trie if current node is an Extension.
+// It returns Node if curr needs to be replaced and error if any.
+func (t *Trie) putIntoExtension(curr *ExtensionNode, path []byte, val Node) (Node, error) {
+	if bytes.HasPrefix(path, curr.key) {
+		r, err := t.putIntoNode(curr.next, path[len(curr.key):], val)
+		if err != nil {
+			return nil, err
+		}
+		curr.next = r
+		curr.invalidateCache()
+		return curr, nil
+	}
+
+	pref := lcp(curr.key, path)
+	lp := len(pref)
+	keyTail := curr.key[lp:]
+	pathTail := path[lp:]
+
+	s1 := newSubTrie(keyTail[1:], curr.next)
+	b := NewBranchNode()
+	b.Children[keyTail[0]] = s1
+
+	i, pathTail := splitPath(pathTail)
+	s2 := newSubTrie(pathTail, val)
+	b.Children[i] = s2
+
+	if lp > 0 {
+		return NewExtensionNode(copySlice(pref), b), nil
+	}
+	return b, nil
+}
+
+// putIntoHash puts val to trie if current node is a HashNode.
+// It returns Node if curr needs to be replaced and error if any.
+func (t *Trie) putIntoHash(curr *HashNode, path []byte, val Node) (Node, error) {
+	result, err := t.getFromStore(curr.hash)
+	if err != nil {
+		return nil, err
+	}
+	return t.putIntoNode(result, path, val)
+}
+
+// putIntoNode puts val with provided path inside curr and returns updated node.
+// Reference to node can be updated, e.g. in case when leaf node becomes a branch.
+func (t *Trie) putIntoNode(curr Node, path []byte, val Node) (Node, error) {
+	switch n := curr.(type) {
+	case *LeafNode:
+		return t.putIntoLeaf(n, path, val)
+	case *BranchNode:
+		return t.putIntoBranch(n, path, val)
+	case *ExtensionNode:
+		return t.putIntoExtension(n, path, val)
+	case *HashNode:
+		return t.putIntoHash(n, path, val)
+	case EmptyNode:
+		return newSubTrie(path, val), nil
+	default:
+		panic("invalid MPT node type")
+	}
+}
+
+// Delete removes key from trie.
+// It returns ErrNotFound on missing key.
+func (t *Trie) Delete(key []byte) error {
+	path := toNibbles(key)
+	r, err := t.deleteFromNode(t.root, path)
+	if err != nil {
+		return err
+	}
+	t.root = r
+	return nil
+}
+
+func (t *Trie) deleteFromBranch(b *BranchNode, path []byte) (Node, error) {
+	i, path := splitPath(path)
+	r, err := t.deleteFromNode(b.Children[i], path)
+	if err != nil {
+		return nil, err
+	}
+	b.Children[i] = r
+	b.invalidateCache()
+	var count, index int
+	for i := range b.Children {
+		if !isEmpty(b.Children[i]) {
+			index = i
+			count++
+		}
+	}
+	// count is >= 1 because branch node had at least 2 children before deletion.
+	if count > 1 {
+		return b, nil
+	}
+	c := b.Children[index]
+	if index == lastChild {
+		return c, nil
+	}
+	if h, ok := c.(*HashNode); ok {
+		c, err = t.getFromStore(h.Hash())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if e, ok := c.(*ExtensionNode); ok {
+		e.key = append([]byte{byte(index)}, e.key...)
+		e.invalidateCache()
+		return e, nil
+	}
+
+	return NewExtensionNode([]byte{byte(index)}, c), nil
+}
+
+func (t *Trie) deleteFromExtension(n *ExtensionNode, path []byte) (Node, error) {
+	if !bytes.HasPrefix(path, n.key) {
+		return nil, ErrNotFound
+	}
+	r, err := t.deleteFromNode(n.next, path[len(n.key):])
+	if err != nil {
+		return nil, err
+	}
+	switch nxt := r.(type) {
+	case *ExtensionNode:
+		key := make([]byte, 0, len(n.key)+len(nxt.key))
+		key = append(key, n.key...)
+		key = append(key, nxt.key...)
+		n.key = key
+		n.next = nxt.next
+	case EmptyNode:
+		return nxt, nil
+	default:
+		n.next = r
+	}
+	n.invalidateCache()
+	return n, nil
+}
+
+// deleteFromNode removes value with provided path from curr and returns an updated node.
+// In case of an error, the trie is left untouched.
+func (t *Trie) deleteFromNode(curr Node, path []byte) (Node, error) {
+	switch n := curr.(type) {
+	case *LeafNode:
+		if len(path) == 0 {
+			return EmptyNode{}, nil
+		}
+		return nil, ErrNotFound
+	case *BranchNode:
+		return t.deleteFromBranch(n, path)
+	case *ExtensionNode:
+		return t.deleteFromExtension(n, path)
+	case EmptyNode:
+		return nil, ErrNotFound
+	case *HashNode:
+		newNode, err := t.getFromStore(n.Hash())
+		if err != nil {
+			return nil, err
+		}
+		return t.deleteFromNode(newNode, path)
+	default:
+		panic("invalid MPT node type")
+	}
+}
+
+// Find calls f for every key-value pair in t with the given key prefix.
+func (t *Trie) Find(prefix []byte, f func(k, v []byte)) error {
+	path := toNibbles(prefix)
+	r, err := t.find(t.root, nil, path, f)
+	if err != nil {
+		return err
+	}
+	t.root = r
+	return nil
+}
+
+// find calls f for every value in a subtrie rooting in curr having the given
+// path which is the rest of the requested prefix. pref is a path of curr from
+// the root. It returns a current node with all visited hash nodes replaced to
+// their "unhashed" counterparts.
+func (t *Trie) find(curr Node, pref, path []byte, f func(k, v []byte)) (Node, error) {
+	switch n := curr.(type) {
+	case *LeafNode:
+		if len(path) == 0 {
+			f(fromNibbles(pref), copySlice(n.value))
+		}
+	case *BranchNode:
+		for i := range n.Children {
+			var p []byte
+			if len(path) != 0 {
+				if byte(i) != path[0] {
+					continue
+				}
+				p = path[1:]
+			} else if i == lastChild {
+				// Value of the branch itself has the same path as the branch.
+				r, err := t.find(n.Children[i], pref, nil, f)
+				if err != nil {
+					return nil, err
+				}
+				n.Children[i] = r
+				continue
+			}
+			r, err := t.find(n.Children[i], appendPath(pref, byte(i)), p, f)
+			if err != nil {
+				return nil, err
+			}
+			n.Children[i] = r
+		}
+	case *ExtensionNode:
+		var p []byte
+		switch {
+		case bytes.HasPrefix(path, n.key):
+			p = path[len(n.key):]
+		case !bytes.HasPrefix(n.key, path):
+			return curr, nil
+		}
+		r, err := t.find(n.next, appendPath(pref, n.key...), p, f)
+		if err != nil {
+			return nil, err
+		}
+		n.next = r
+	case *HashNode:
+		r, err := t.getFromStore(n.hash)
+		if err != nil {
+			return nil, err
+		}
+		return t.find(r, pref, path, f)
+	case EmptyNode:
+	default:
+		panic("invalid MPT node type")
+	}
+	return curr, nil
+}
+
+// StateRoot returns root hash of t.
+func (t *Trie) StateRoot() util.Uint256 {
+	if isEmpty(t.root) {
+		return util.Uint256{}
+	}
+	return t.root.Hash()
+}
+
+func makeStorageKey(mptKey []byte) []byte {
+	return append([]byte{byte(storage.DataMPT)}, mptKey...)
+}
+
+// Flush puts every node in the trie except Hash ones to the storage.
+// Because we care only about block-level changes, there is no need to put every
+// new node to storage. Normally, flush should be called with every StateRoot persist, i.e.
+// after every block.
+func (t *Trie) Flush() error {
+	r, err := t.flush(t.root)
+	if err != nil {
+		return err
+	}
+	t.root = r
+	return nil
+}
+
+// flush stores n and all of its children (except for hash and empty nodes)
+// to the underlying store and returns hash node referring to n.
+func (t *Trie) flush(n Node) (Node, error) {
+	switch node := n.(type) {
+	case *BranchNode:
+		for i := range node.Children {
+			r, err := t.flush(node.Children[i])
+			if err != nil {
+				return nil, err
+			}
+			node.Children[i] = r
+		}
+	case *ExtensionNode:
+		r, err := t.flush(node.next)
+		if err != nil {
+			return nil, err
+		}
+		node.next = r
+	case *HashNode, EmptyNode:
+		return n, nil
+	}
+	key := makeStorageKey(n.Hash().BytesBE())
+	if err := t.Store.Put(key, n.Bytes()); err != nil {
+		return nil, err
+	}
+	return NewHashNode(n.Hash()), nil
+}
+
+func (t *Trie) getFromStore(h util.Uint256) (Node, error) {
+	data, err := t.Store.Get(makeStorageKey(h.BytesBE()))
+	if err != nil {
+		return nil, err
+	}
+
+	var n NodeObject
+	r := io.NewBinReaderFromBuf(data)
+	n.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return n.Node, nil
+}
@@ -0,0 +1,225 @@
+package mpt
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/internal/random"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore() *storage.MemCachedStore {
+	return storage.NewMemCachedStore(storage.NewMemoryStore())
+}
+
+func testTrieDeleteSibling(t *testing.T, key1, key2 []byte) {
+	tr := NewTrie(nil, newTestStore())
+	require.NoError(t, tr.Put(key1, []byte{1}))
+	require.NoError(t, tr.Put(key2, []byte{1}))
+	require.NoError(t, tr.Flush())
+
+	// delete of key2 must not affect key1
+	require.NoError(t, tr.Delete(key2))
+	require.NoError(t, tr.Flush())
+	v, err := tr.Get(key1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, v)
+}
+
+func TestTrie_PutGet(t *testing.T) {
+	tr := NewTrie(nil, newTestStore())
+	pairs := [][2][]byte{
+		{{0x01, 0xAC}, {1}},
+		{{0x01, 0xAC, 0x01}, {2}},
+		{{0x01, 0xAC, 0x02}, {3}},
+		{{0x01, 0xAD}, {4}},
+		{{0x02}, {5}},
+		{{}, {6}},
+	}
+	for _, p := range pairs {
+		require.NoError(t, tr.Put(p[0], p[1]))
+	}
+	for _, p := range pairs {
+		v, err := tr.Get(p[0])
+		require.NoError(t, err)
+		require.Equal(t, p[1], v)
+	}
+
+	_, err := tr.Get([]byte{0x03})
+	require.Equal(t, ErrNotFound, err)
+
+	t.Run("replace", func(t *testing.T) {
+		require.NoError(t, tr.Put([]byte{0x01, 0xAC}, []byte{42}))
+		v, err := tr.Get([]byte{0x01, 0xAC})
+		require.NoError(t, err)
+		require.Equal(t, []byte{42}, v)
+	})
+}
+
+func TestTrie_PutInvalid(t *testing.T) {
+	tr := NewTrie(nil, newTestStore())
+	require.Error(t, tr.Put(make([]byte, MaxKeyLength+1), []byte{1}))
+	require.Error(t, tr.Put([]byte{1}, make([]byte, MaxValueLength+1)))
+}
+
+func TestTrie_DeleteSibling(t *testing.T) {
+	testTrieDeleteSibling(t, []byte{0x01, 0x02}, []byte{0x01, 0x03})
+	testTrieDeleteSibling(t, []byte{0x01}, []byte{0x01, 0x02})
+}
+
+func TestTrie_Flush(t *testing.T) {
+	pairs := map[string][]byte{
+		"":     []byte("value0"),
+		"key1": []byte("value1"),
+		"key2": []byte("value2"),
+	}
+
+	tr := NewTrie(nil, newTestStore())
+	for k, v := range pairs {
+		require.NoError(t, tr.Put([]byte(k), v))
+	}
+
+	require.NoError(t, tr.Flush())
+	require.IsType(t, (*HashNode)(nil), tr.root)
+
+	tr = NewTrie(NewHashNode(tr.StateRoot()), tr.Store)
+	for k, v := range pairs {
+		actual, err := tr.Get([]byte(k))
+		require.NoError(t, err)
+		require.Equal(t, v, actual)
+	}
+}
+
+func TestTrie_GetMissingHashNode(t *testing.T) {
+	tr := NewTrie(nil, newTestStore())
+	require.NoError(t, tr.Put([]byte{0x12, 0x31}, []byte("value1")))
+	require.NoError(t, tr.Flush())
+
+	// Storage errors must not be reported as a missing key.
+	tr = NewTrie(NewHashNode(tr.StateRoot()), newTestStore())
+	_, err := tr.Get([]byte{0x12, 0x31})
+	require.Equal(t, storage.ErrKeyNotFound, err)
+	_, err = tr.GetProof([]byte{0x12, 0x31})
+	require.Equal(t, storage.ErrKeyNotFound, err)
+}
+
+func TestTrie_Delete(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		tr := NewTrie(nil, newTestStore())
+		require.Error(t, tr.Delete([]byte{1}))
+	})
+
+	t.Run("Leaf", func(t *testing.T) {
+		tr := NewTrie(nil, newTestStore())
+		require.NoError(t, tr.Put([]byte{0xAB}, []byte{1}))
+		require.Error(t, tr.Delete([]byte{0xAC}))
+		require.NoError(t, tr.Delete([]byte{0xAB}))
+		require.True(t, isEmpty(tr.root))
+		require.Equal(t, EmptyNode{}.Hash(), tr.StateRoot())
+	})
+
+	t.Run("Extension", func(t *testing.T) {
+		tr := NewTrie(nil, newTestStore())
+		require.NoError(t, tr.Put([]byte{0xAB, 0xCD}, []byte{1}))
+		require.NoError(t, tr.Put([]byte{0xAB, 0xCE}, []byte{2}))
+		require.NoError(t, tr.Delete([]byte{0xAB, 0xCD}))
+		_, err := tr.Get([]byte{0xAB, 0xCD})
+		require.Equal(t, ErrNotFound, err)
+
+		exp := NewTrie(nil, newTestStore())
+		require.NoError(t, exp.Put([]byte{0xAB, 0xCE}, []byte{2}))
+		require.Equal(t, exp.StateRoot(), tr.StateRoot())
+	})
+
+	t.Run("WithFlush", func(t *testing.T) {
+		tr := NewTrie(nil, newTestStore())
+		require.NoError(t, tr.Put([]byte{0x12, 0x31}, []byte{1}))
+		require.NoError(t, tr.Put([]byte{0x12, 0x32}, []byte{2}))
+		require.NoError(t, tr.Put([]byte{0x12, 0x33, 0x45}, []byte{3}))
+		require.NoError(t, tr.Flush())
+		require.NoError(t, tr.Delete([]byte{0x12, 0x31}))
+		require.NoError(t, tr.Delete([]byte{0x12, 0x32}))
+
+		exp := NewTrie(nil, newTestStore())
+		require.NoError(t, exp.Put([]byte{0x12, 0x33, 0x45}, []byte{3}))
+		require.Equal(t, exp.StateRoot(), tr.StateRoot())
+	})
+}
+
+// TestTrie_OrderIndependent checks that the same set of key-value pairs
+// always produces the same root hash irregardless of insertion order and
+// intermediate deletions.
+func TestTrie_OrderIndependent(t *testing.T) {
+	const count = 100
+	keys := make([][]byte, count)
+	values := make([][]byte, count)
+	for i := range keys {
+		keys[i] = append([]byte{byte(i)}, random.Bytes(i%5)...)
+		values[i] = random.Bytes(10)
+	}
+
+	tr1 := NewTrie(nil, newTestStore())
+	for i := range keys {
+		require.NoError(t, tr1.Put(keys[i], values[i]))
+	}
+
+	tr2 := NewTrie(nil, newTestStore())
+	for i := count - 1; i >= 0; i-- {
+		require.NoError(t, tr2.Put(keys[i], values[i]))
+		require.NoError(t, tr2.Put(withSuffix(keys[i]), []byte{0xFF}))
+		if i%10 == 0 {
+			require.NoError(t, tr2.Flush())
+		}
+	}
+	for i := range keys {
+		require.NoError(t, tr2.Delete(withSuffix(keys[i])))
+	}
+	require.Equal(t, tr1.StateRoot(), tr2.StateRoot())
+}
+
+func withSuffix(key []byte) []byte {
+	res := make([]byte, len(key)+1)
+	copy(res, key)
+	res[len(key)] = 0xFF
+	return res
+}
+
+func TestTrie_Find(t *testing.T) {
+	pairs := [][2][]byte{
+		{{0x01, 0xAC}, {1}},
+		{{0x01, 0xAC, 0x01}, {2}},
+		{{0x01, 0xAC, 0x02}, {3}},
+		{{0x01, 0xAD}, {4}},
+		{{0x02}, {5}},
+		{{}, {6}},
+	}
+	tr := NewTrie(nil, newTestStore())
+	for _, p := range pairs {
+		require.NoError(t, tr.Put(p[0], p[1]))
+	}
+	require.NoError(t, tr.Flush())
+
+	find := func(t *testing.T, prefix []byte) map[string][]byte {
+		res := make(map[string][]byte)
+		require.NoError(t, tr.Find(prefix, func(k, v []byte) {
+			res[string(k)] = v
+		}))
+		return res
+	}
+	check := func(t *testing.T, prefix []byte, expected ...[2][]byte) {
+		res := find(t, prefix)
+		require.Equal(t, len(expected), len(res))
+		for _, p := range expected {
+			require.Equal(t, p[1], res[string(p[0])])
+		}
+	}
+
+	t.Run("All", func(t *testing.T) { check(t, nil, pairs...) })
+	t.Run("Branch", func(t *testing.T) { check(t, []byte{0x01}, pairs[:4]...) })
+	t.Run("Extension", func(t *testing.T) { check(t, []byte{0x01, 0xAC}, pairs[:3]...) })
+	t.Run("Leaf", func(t *testing.T) { check(t, []byte{0x02}, pairs[4]) })
+	t.Run("Missing", func(t *testing.T) {
+		check(t, []byte{0x03})
+		check(t, []byte{0x01, 0xAE})
+	})
+}
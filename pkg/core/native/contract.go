@@ -13,6 +13,8 @@ import (
 type Contracts struct {
 	NEO       *NEO
 	GAS       *GAS
+	Policy    *Policy
+	Oracle    *Oracle
 	Contracts []interop.Contract
 }
 
@@ -36,8 +38,8 @@ func (cs *Contracts) ByID(id uint32) interop.Contract {
 	return nil
 }
 
-// NewContracts returns new set of native contracts with new GAS and NEO
-// contracts.
+// NewContracts returns new set of native contracts with new GAS, NEO, Policy
+// and Oracle contracts.
 func NewContracts() *Contracts {
 	cs := new(Contracts)
 
@@ -50,6 +52,17 @@ func NewContracts() *Contracts {
 	cs.Contracts = append(cs.Contracts, gas)
 	cs.NEO = neo
 	cs.Contracts = append(cs.Contracts, neo)
+
+	policy := NewPolicy()
+	policy.NEO = neo
+	cs.Policy = policy
+	cs.Contracts = append(cs.Contracts, policy)
+
+	oracle := NewOracle()
+	oracle.NEO = neo
+	oracle.GAS = gas
+	cs.Oracle = oracle
+	cs.Contracts = append(cs.Contracts, oracle)
 	return cs
 }
 
@@ -79,8 +92,13 @@ func getNativeInterop(ic *interop.Context, c interop.Contract) func(v *vm.VM) er
 		if !ok {
 			return fmt.Errorf("method %s not found", name)
 		}
+		if !v.Context().GetCallFlags().Has(m.RequiredFlags) {
+			return errors.New("missing call flags")
+		}
 		result := m.Func(ic, args)
-		v.Estack().PushVal(result)
+		if result != nil {
+			v.Estack().PushVal(result)
+		}
 		return nil
 	}
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -80,13 +81,13 @@ func NewNEO() *NEO {
 
 	desc = newDescriptor("registerValidator", smartcontract.BoolType,
 		manifest.NewParameter("pubkey", smartcontract.PublicKeyType))
-	md = newMethodAndPrice(n.registerValidator, 1, smartcontract.NoneFlag)
+	md = newMethodAndPrice(n.registerValidator, 1, smartcontract.AllowModifyStates)
 	n.AddMethod(md, desc, false)
 
 	desc = newDescriptor("vote", smartcontract.BoolType,
 		manifest.NewParameter("account", smartcontract.Hash160Type),
 		manifest.NewParameter("pubkeys", smartcontract.ArrayType))
-	md = newMethodAndPrice(n.vote, 1, smartcontract.NoneFlag)
+	md = newMethodAndPrice(n.vote, 1, smartcontract.AllowModifyStates)
 	n.AddMethod(md, desc, false)
 
 	desc = newDescriptor("getRegisteredValidators", smartcontract.ArrayType)
@@ -460,6 +461,27 @@ func (n *NEO) GetNextBlockValidatorsInternal(bc blockchainer.Blockchainer, d dao
 	return pubs, nil
 }
 
+// checkCommittee checks whether the invocation of native contract with the
+// given hash is witnessed by the committee which is represented by the BFT
+// multisignature account of the next block validators.
+func checkCommittee(ic *interop.Context, n *NEO, h util.Uint160) bool {
+	pubs, err := n.GetNextBlockValidatorsInternal(ic.Chain, ic.DAO)
+	if err != nil {
+		return false
+	}
+	count := len(pubs)
+	script, err := smartcontract.CreateMultiSigRedeemScript(count-(count-1)/3, pubs)
+	if err != nil {
+		return false
+	}
+	ok, err := runtime.CheckHashedWitness(ic, nep5ScriptHash{
+		callingScriptHash: h,
+		entryScriptHash:   h,
+		currentScriptHash: h,
+	}, hash.Hash160(script))
+	return err == nil && ok
+}
+
 func pubsToArray(pubs keys.PublicKeys) stackitem.Item {
 	arr := make([]stackitem.Item, len(pubs))
 	for i := range pubs {
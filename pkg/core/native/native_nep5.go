@@ -74,7 +74,7 @@ func newNEP5Native(name string) *nep5TokenNative {
 		manifest.NewParameter("to", smartcontract.Hash160Type),
 		manifest.NewParameter("amount", smartcontract.IntegerType),
 	)
-	md = newMethodAndPrice(n.Transfer, 1, smartcontract.NoneFlag)
+	md = newMethodAndPrice(n.Transfer, 1, smartcontract.AllowModifyStates)
 	n.AddMethod(md, desc, false)
 
 	n.AddEvent("Transfer", desc.Parameters...)
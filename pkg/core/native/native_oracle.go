@@ -0,0 +1,429 @@
+package native
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Oracle represents Oracle native contract.
+type Oracle struct {
+	interop.ContractMD
+	NEO *NEO
+	GAS *GAS
+}
+
+const (
+	oracleSyscallName = "Neo.Native.Oracle"
+
+	// MinimumResponseGas is the minimum amount of GAS (in 10^-8 units) that
+	// must be paid for the oracle response.
+	MinimumResponseGas = 10000000
+
+	maxURLLength      = 256
+	maxFilterLength   = 128
+	maxCallbackLength = 32
+	maxUserDataLength = 512
+	// maxOracleNodes is the maximum number of oracle nodes that can be
+	// decoded from the storage.
+	maxOracleNodes = 1024
+)
+
+var (
+	// prefixRequest is a prefix used to store oracle requests.
+	prefixRequest = []byte{7}
+	// oracleNodesKey is a key used to store the list of oracle nodes.
+	oracleNodesKey = []byte{8}
+	// requestIDKey is a key used to store the next request ID.
+	requestIDKey = []byte{9}
+)
+
+var _ interop.Contract = (*Oracle)(nil)
+
+// NewOracle returns Oracle native contract.
+func NewOracle() *Oracle {
+	o := &Oracle{ContractMD: *interop.NewContractMD(oracleSyscallName)}
+
+	desc := newDescriptor("request", smartcontract.VoidType,
+		manifest.NewParameter("url", smartcontract.StringType),
+		manifest.NewParameter("filter", smartcontract.StringType),
+		manifest.NewParameter("callback", smartcontract.StringType),
+		manifest.NewParameter("userData", smartcontract.AnyType),
+		manifest.NewParameter("gasForResponse", smartcontract.IntegerType))
+	md := newMethodAndPrice(o.request, 5000000, smartcontract.AllowModifyStates)
+	o.AddMethod(md, desc, false)
+
+	desc = newDescriptor("finish", smartcontract.VoidType)
+	md = newMethodAndPrice(o.finish, 0, smartcontract.AllowModifyStates)
+	o.AddMethod(md, desc, false)
+
+	desc = newDescriptor("getOracleNodes", smartcontract.ArrayType)
+	md = newMethodAndPrice(o.getOracleNodes, 1000000, smartcontract.NoneFlag)
+	o.AddMethod(md, desc, true)
+
+	desc = newDescriptor("setOracleNodes", smartcontract.BoolType,
+		manifest.NewParameter("nodes", smartcontract.ArrayType))
+	md = newMethodAndPrice(o.setOracleNodes, 1000000, smartcontract.AllowModifyStates)
+	o.AddMethod(md, desc, false)
+
+	o.AddEvent("OracleRequest",
+		manifest.NewParameter("Id", smartcontract.IntegerType),
+		manifest.NewParameter("RequestContract", smartcontract.Hash160Type),
+		manifest.NewParameter("Url", smartcontract.StringType),
+		manifest.NewParameter("Filter", smartcontract.StringType))
+	o.AddEvent("OracleResponse",
+		manifest.NewParameter("Id", smartcontract.IntegerType),
+		manifest.NewParameter("OriginalTx", smartcontract.Hash256Type))
+
+	return o
+}
+
+// Metadata implements Contract interface.
+func (o *Oracle) Metadata() *interop.ContractMD {
+	return &o.ContractMD
+}
+
+// Initialize initializes Oracle native contract and implements Contract
+// interface. Standby validators are used as initial oracle nodes.
+func (o *Oracle) Initialize(ic *interop.Context) error {
+	si := &state.StorageItem{Value: make([]byte, 8)}
+	if err := ic.DAO.PutStorageItem(o.Hash, requestIDKey, si); err != nil {
+		return err
+	}
+	pubs, err := ic.Chain.GetStandByValidators()
+	if err != nil {
+		return err
+	}
+	return o.putOracleNodes(ic.DAO, pubs.Unique())
+}
+
+// OnPersist implements Contract interface.
+func (o *Oracle) OnPersist(_ *interop.Context) error {
+	return nil
+}
+
+// GetScriptHash returns Oracle contract hash.
+func (o *Oracle) GetScriptHash() util.Uint160 {
+	return o.Hash
+}
+
+// GetResponseScript returns script of the oracle response transaction.
+func (o *Oracle) GetResponseScript() []byte {
+	w := io.NewBufBinWriter()
+	emit.AppCallWithOperationAndArgs(w.BinWriter, o.Hash, "finish")
+	return w.Bytes()
+}
+
+// request is Oracle contract method and creates new oracle request.
+func (o *Oracle) request(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	url := toString(args[0], maxURLLength)
+	filter := toString(args[1], maxFilterLength)
+	cb := toString(args[2], maxCallbackLength)
+	if strings.HasPrefix(cb, "_") {
+		panic(errors.New("invalid callback method"))
+	}
+	userData, err := stackitem.SerializeItem(args[3])
+	if err != nil {
+		panic(err)
+	}
+	if len(userData) > maxUserDataLength {
+		panic(errors.New("user data is too big"))
+	}
+	gas := toBigInt(args[4])
+	if !gas.IsInt64() || gas.Int64() < MinimumResponseGas {
+		panic(errors.New("not enough gas for response"))
+	}
+	if !ic.VM.AddGas(util.Fixed8(gas.Int64())) {
+		panic(errors.New("gas limit exceeded"))
+	}
+
+	callingHash := ic.VM.GetCallingScriptHash()
+	if _, err := ic.DAO.GetContractState(callingHash); err != nil {
+		panic(errors.New("oracle request can only be made by a deployed contract"))
+	}
+
+	id, err := o.getNextRequestID(ic.DAO)
+	if err != nil {
+		panic(err)
+	}
+	req := &state.OracleRequest{
+		GasForResponse:   uint64(gas.Int64()),
+		URL:              url,
+		Filter:           filter,
+		CallbackContract: callingHash,
+		CallbackMethod:   cb,
+		UserData:         userData,
+	}
+	if ic.Tx != nil {
+		req.OriginalTxID = ic.Tx.Hash()
+	}
+	if err := o.putRequest(ic.DAO, id, req); err != nil {
+		panic(err)
+	}
+
+	ic.Notifications = append(ic.Notifications, state.NotificationEvent{
+		ScriptHash: o.Hash,
+		Item: stackitem.NewArray([]stackitem.Item{
+			stackitem.NewByteArray([]byte("OracleRequest")),
+			stackitem.NewBigInteger(new(big.Int).SetUint64(id)),
+			stackitem.NewByteArray(callingHash.BytesBE()),
+			stackitem.NewByteArray([]byte(url)),
+			stackitem.NewByteArray([]byte(filter)),
+		}),
+	})
+	return nil
+}
+
+// finish is Oracle contract method and invokes callback of the request the
+// response transaction is made for.
+func (o *Oracle) finish(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	if ic.Tx == nil {
+		panic(errors.New("no transaction in the context"))
+	}
+	resp := ic.Tx.GetOracleResponse()
+	if resp == nil {
+		panic(errors.New("oracle response attribute is missing"))
+	}
+	ok, err := o.isResponder(ic.DAO, resp.ID, ic.Tx.Sender)
+	if err != nil {
+		panic(err)
+	}
+	if !ok {
+		panic(errors.New("transaction is not sent by the designated oracle node"))
+	}
+	req, err := o.GetRequestInternal(ic.DAO, resp.ID)
+	if err != nil {
+		panic(err)
+	}
+	if err := ic.DAO.DeleteStorageItem(o.Hash, makeRequestKey(resp.ID)); err != nil {
+		panic(err)
+	}
+	o.GAS.mint(ic, ic.Tx.Sender, new(big.Int).SetUint64(req.GasForResponse))
+
+	ic.Notifications = append(ic.Notifications, state.NotificationEvent{
+		ScriptHash: o.Hash,
+		Item: stackitem.NewArray([]stackitem.Item{
+			stackitem.NewByteArray([]byte("OracleResponse")),
+			stackitem.NewBigInteger(new(big.Int).SetUint64(resp.ID)),
+			stackitem.NewByteArray(req.OriginalTxID.BytesBE()),
+		}),
+	})
+
+	userData, err := stackitem.DeserializeItem(req.UserData)
+	if err != nil {
+		panic(err)
+	}
+	cs, err := ic.DAO.GetContractState(req.CallbackContract)
+	if err != nil {
+		panic(errors.New("callback contract not found"))
+	}
+	ic.VM.LoadScriptWithFlags(cs.Script, ic.VM.Context().GetCallFlags())
+	ic.VM.Estack().PushVal(stackitem.NewArray([]stackitem.Item{
+		stackitem.NewByteArray([]byte(req.URL)),
+		userData,
+		stackitem.NewBigInteger(big.NewInt(int64(resp.Code))),
+		stackitem.NewByteArray(resp.Result),
+	}))
+	ic.VM.Estack().PushVal(req.CallbackMethod)
+	return nil
+}
+
+// getOracleNodes is Oracle contract method and returns the list of oracle
+// nodes public keys.
+func (o *Oracle) getOracleNodes(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	pubs, err := o.GetOracleNodesInternal(ic.DAO)
+	if err != nil {
+		panic(err)
+	}
+	return pubsToArray(pubs)
+}
+
+// GetOracleNodesInternal returns the list of oracle nodes public keys.
+func (o *Oracle) GetOracleNodesInternal(d dao.DAO) (keys.PublicKeys, error) {
+	si := d.GetStorageItem(o.Hash, oracleNodesKey)
+	if si == nil {
+		return keys.PublicKeys{}, nil
+	}
+	r := io.NewBinReaderFromBuf(si.Value)
+	var pubs keys.PublicKeys
+	r.ReadArray(&pubs, maxOracleNodes)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return pubs, nil
+}
+
+// setOracleNodes is Oracle contract method and sets the list of oracle nodes.
+// It must be witnessed by the committee.
+func (o *Oracle) setOracleNodes(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	arr, ok := args[0].Value().([]stackitem.Item)
+	if !ok || len(arr) > maxOracleNodes {
+		panic(errors.New("invalid oracle nodes list"))
+	}
+	pubs := make(keys.PublicKeys, len(arr))
+	for i := range arr {
+		pubs[i] = toPublicKey(arr[i])
+	}
+	if !checkCommittee(ic, o.NEO, o.Hash) {
+		return stackitem.NewBool(false)
+	}
+	if err := o.putOracleNodes(ic.DAO, pubs.Unique()); err != nil {
+		panic(err)
+	}
+	return stackitem.NewBool(true)
+}
+
+// GetRequestInternal returns oracle request with the specified id.
+func (o *Oracle) GetRequestInternal(d dao.DAO, id uint64) (*state.OracleRequest, error) {
+	si := d.GetStorageItem(o.Hash, makeRequestKey(id))
+	if si == nil {
+		return nil, errors.New("request not found")
+	}
+	return requestFromBytes(si.Value)
+}
+
+// GetRequestsInternal returns all pending oracle requests indexed by their
+// ids.
+func (o *Oracle) GetRequestsInternal(d dao.DAO) (map[uint64]*state.OracleRequest, error) {
+	items, err := d.GetStorageItemsWithPrefix(o.Hash, prefixRequest)
+	if err != nil {
+		return nil, err
+	}
+	reqs := make(map[uint64]*state.OracleRequest, len(items))
+	for k, si := range items {
+		if len(k) != 8 {
+			return nil, errors.New("invalid request key")
+		}
+		req, err := requestFromBytes(si.Value)
+		if err != nil {
+			return nil, err
+		}
+		reqs[binary.BigEndian.Uint64([]byte(k))] = req
+	}
+	return reqs, nil
+}
+
+// VerifyResponse checks whether tx is a valid oracle response transaction:
+// it has exactly one OracleResponse attribute referring to the existing
+// request, is sent by the oracle node designated for this request (see
+// GetOracleResponder) and only calls `finish`.
+func (o *Oracle) VerifyResponse(d dao.DAO, tx *transaction.Transaction) error {
+	var n int
+	for i := range tx.Attributes {
+		if tx.Attributes[i].Usage == transaction.OracleResponse {
+			n++
+		}
+	}
+	if n != 1 {
+		return errors.New("exactly one oracle response attribute is expected")
+	}
+	resp := tx.GetOracleResponse()
+	if resp == nil {
+		return errors.New("invalid oracle response attribute")
+	}
+	if _, err := o.GetRequestInternal(d, resp.ID); err != nil {
+		return err
+	}
+	ok, err := o.isResponder(d, resp.ID, tx.Sender)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("transaction is not sent by the designated oracle node")
+	}
+	if string(tx.Script) != string(o.GetResponseScript()) {
+		return errors.New("invalid oracle response script")
+	}
+	return nil
+}
+
+// GetOracleResponder returns the oracle node designated to respond to the
+// request with the given id or nil if there are no oracle nodes. Only this
+// node can send the response, so that oracle nodes don't compete with each
+// other for the same request.
+func GetOracleResponder(nodes keys.PublicKeys, id uint64) *keys.PublicKey {
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[id%uint64(len(nodes))]
+}
+
+// isResponder checks whether h is the script hash of the oracle node
+// designated to respond to the request with the given id.
+func (o *Oracle) isResponder(d dao.DAO, id uint64, h util.Uint160) (bool, error) {
+	pubs, err := o.GetOracleNodesInternal(d)
+	if err != nil {
+		return false, err
+	}
+	pub := GetOracleResponder(pubs, id)
+	return pub != nil && pub.GetScriptHash().Equals(h), nil
+}
+
+func (o *Oracle) getNextRequestID(d dao.DAO) (uint64, error) {
+	si := d.GetStorageItem(o.Hash, requestIDKey)
+	if si == nil || len(si.Value) != 8 {
+		return 0, errors.New("request id is not initialized")
+	}
+	id := binary.LittleEndian.Uint64(si.Value)
+	binary.LittleEndian.PutUint64(si.Value, id+1)
+	return id, d.PutStorageItem(o.Hash, requestIDKey, si)
+}
+
+func (o *Oracle) putRequest(d dao.DAO, id uint64, req *state.OracleRequest) error {
+	w := io.NewBufBinWriter()
+	req.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return w.Err
+	}
+	return d.PutStorageItem(o.Hash, makeRequestKey(id), &state.StorageItem{Value: w.Bytes()})
+}
+
+func (o *Oracle) putOracleNodes(d dao.DAO, pubs keys.PublicKeys) error {
+	w := io.NewBufBinWriter()
+	w.WriteArray(pubs)
+	if w.Err != nil {
+		return w.Err
+	}
+	return d.PutStorageItem(o.Hash, oracleNodesKey, &state.StorageItem{Value: w.Bytes()})
+}
+
+func makeRequestKey(id uint64) []byte {
+	k := make([]byte, 9)
+	k[0] = prefixRequest[0]
+	binary.BigEndian.PutUint64(k[1:], id)
+	return k
+}
+
+func requestFromBytes(b []byte) (*state.OracleRequest, error) {
+	req := new(state.OracleRequest)
+	r := io.NewBinReaderFromBuf(b)
+	req.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return req, nil
+}
+
+func toString(s stackitem.Item, maxLen int) string {
+	b, err := s.TryBytes()
+	if err != nil {
+		panic(err)
+	}
+	if len(b) > maxLen {
+		panic(errors.New("string is too long"))
+	}
+	return string(b)
+}
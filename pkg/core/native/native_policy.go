@@ -0,0 +1,367 @@
+package native
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Policy represents Policy native contract.
+type Policy struct {
+	interop.ContractMD
+	NEO *NEO
+}
+
+const (
+	policySyscallName = "Neo.Native.Policy"
+
+	// DefaultMaxTransactionsPerBlock is the default maximum number of
+	// transactions per block, 0 means there is no limit.
+	DefaultMaxTransactionsPerBlock uint32 = 0
+	// DefaultFeePerByte is the default network fee per transaction byte.
+	DefaultFeePerByte int64 = 1000
+	// DefaultMaxBlockSize is the default maximum block size in bytes.
+	DefaultMaxBlockSize uint32 = 1024 * 256
+	// maxBlockSizeLimit is the upper limit for the block size setting, it
+	// matches the maximum P2P message payload size.
+	maxBlockSizeLimit = 0x02000000
+	// MaxBlockedAccounts is the maximum number of blocked accounts, it's
+	// also the limit used when decoding the list from the storage.
+	MaxBlockedAccounts = 1024
+)
+
+var (
+	// feePerByteKey is a key used to store the network fee per byte.
+	feePerByteKey = []byte{10}
+	// maxBlockSizeKey is a key used to store the maximum block size.
+	maxBlockSizeKey = []byte{12}
+	// blockedAccountsKey is a key used to store the list of blocked accounts.
+	blockedAccountsKey = []byte{15}
+	// maxTransactionsPerBlockKey is a key used to store the maximum number
+	// of transactions per block.
+	maxTransactionsPerBlockKey = []byte{23}
+)
+
+// BlockedAccounts represents a sorted list of blocked accounts.
+type BlockedAccounts []util.Uint160
+
+// EncodeBinary implements io.Serializable.
+func (ba *BlockedAccounts) EncodeBinary(w *io.BinWriter) {
+	w.WriteArray(*ba)
+}
+
+// DecodeBinary implements io.Serializable.
+func (ba *BlockedAccounts) DecodeBinary(r *io.BinReader) {
+	r.ReadArray(ba, MaxBlockedAccounts)
+}
+
+// Bytes returns serialized BlockedAccounts.
+func (ba *BlockedAccounts) Bytes() []byte {
+	w := io.NewBufBinWriter()
+	ba.EncodeBinary(w.BinWriter)
+	return w.Bytes()
+}
+
+// BlockedAccountsFromBytes decodes BlockedAccounts from the given slice.
+func BlockedAccountsFromBytes(b []byte) (BlockedAccounts, error) {
+	ba := BlockedAccounts{}
+	r := io.NewBinReaderFromBuf(b)
+	ba.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return ba, nil
+}
+
+// Contains checks whether u is in ba.
+func (ba BlockedAccounts) Contains(u util.Uint160) bool {
+	i := ba.search(u)
+	return i < len(ba) && ba[i].Equals(u)
+}
+
+// search returns index of the first account in ba which is not less than u.
+func (ba BlockedAccounts) search(u util.Uint160) int {
+	return sort.Search(len(ba), func(i int) bool {
+		return !ba[i].Less(u)
+	})
+}
+
+var _ interop.Contract = (*Policy)(nil)
+
+// NewPolicy returns Policy native contract.
+func NewPolicy() *Policy {
+	p := &Policy{ContractMD: *interop.NewContractMD(policySyscallName)}
+
+	desc := newDescriptor("getMaxTransactionsPerBlock", smartcontract.IntegerType)
+	md := newMethodAndPrice(p.getMaxTransactionsPerBlock, 1, smartcontract.NoneFlag)
+	p.AddMethod(md, desc, true)
+
+	desc = newDescriptor("getMaxBlockSize", smartcontract.IntegerType)
+	md = newMethodAndPrice(p.getMaxBlockSize, 1, smartcontract.NoneFlag)
+	p.AddMethod(md, desc, true)
+
+	desc = newDescriptor("getFeePerByte", smartcontract.IntegerType)
+	md = newMethodAndPrice(p.getFeePerByte, 1, smartcontract.NoneFlag)
+	p.AddMethod(md, desc, true)
+
+	desc = newDescriptor("getBlockedAccounts", smartcontract.ArrayType)
+	md = newMethodAndPrice(p.getBlockedAccounts, 1, smartcontract.NoneFlag)
+	p.AddMethod(md, desc, true)
+
+	desc = newDescriptor("setMaxTransactionsPerBlock", smartcontract.BoolType,
+		manifest.NewParameter("value", smartcontract.IntegerType))
+	md = newMethodAndPrice(p.setMaxTransactionsPerBlock, 1, smartcontract.AllowModifyStates)
+	p.AddMethod(md, desc, false)
+
+	desc = newDescriptor("setMaxBlockSize", smartcontract.BoolType,
+		manifest.NewParameter("value", smartcontract.IntegerType))
+	md = newMethodAndPrice(p.setMaxBlockSize, 1, smartcontract.AllowModifyStates)
+	p.AddMethod(md, desc, false)
+
+	desc = newDescriptor("setFeePerByte", smartcontract.BoolType,
+		manifest.NewParameter("value", smartcontract.IntegerType))
+	md = newMethodAndPrice(p.setFeePerByte, 1, smartcontract.AllowModifyStates)
+	p.AddMethod(md, desc, false)
+
+	desc = newDescriptor("blockAccount", smartcontract.BoolType,
+		manifest.NewParameter("account", smartcontract.Hash160Type))
+	md = newMethodAndPrice(p.blockAccount, 1, smartcontract.AllowModifyStates)
+	p.AddMethod(md, desc, false)
+
+	desc = newDescriptor("unblockAccount", smartcontract.BoolType,
+		manifest.NewParameter("account", smartcontract.Hash160Type))
+	md = newMethodAndPrice(p.unblockAccount, 1, smartcontract.AllowModifyStates)
+	p.AddMethod(md, desc, false)
+
+	return p
+}
+
+// Metadata implements Contract interface.
+func (p *Policy) Metadata() *interop.ContractMD {
+	return &p.ContractMD
+}
+
+// Initialize initializes Policy native contract and implements Contract interface.
+func (p *Policy) Initialize(ic *interop.Context) error {
+	maxTxs := uint32(ic.Chain.GetConfig().MaxTransactionsPerBlock)
+	if err := p.putUint32(ic.DAO, maxTransactionsPerBlockKey, maxTxs); err != nil {
+		return err
+	}
+	if err := p.putInt64(ic.DAO, feePerByteKey, DefaultFeePerByte); err != nil {
+		return err
+	}
+	if err := p.putUint32(ic.DAO, maxBlockSizeKey, DefaultMaxBlockSize); err != nil {
+		return err
+	}
+	ba := BlockedAccounts{}
+	return ic.DAO.PutStorageItem(p.Hash, blockedAccountsKey, &state.StorageItem{Value: ba.Bytes()})
+}
+
+// OnPersist implements Contract interface.
+func (p *Policy) OnPersist(_ *interop.Context) error {
+	return nil
+}
+
+// getMaxTransactionsPerBlock is Policy contract method and returns the upper
+// limit of transactions per block (0 means there is no limit).
+func (p *Policy) getMaxTransactionsPerBlock(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(int64(p.GetMaxTransactionsPerBlockInternal(ic.DAO))))
+}
+
+// GetMaxTransactionsPerBlockInternal returns the upper limit of transactions per
+// block (0 means there is no limit).
+func (p *Policy) GetMaxTransactionsPerBlockInternal(d dao.DAO) uint32 {
+	return p.getUint32WithDefault(d, maxTransactionsPerBlockKey, DefaultMaxTransactionsPerBlock)
+}
+
+// getMaxBlockSize is Policy contract method and returns maximum block size.
+func (p *Policy) getMaxBlockSize(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(int64(p.GetMaxBlockSizeInternal(ic.DAO))))
+}
+
+// GetMaxBlockSizeInternal returns maximum block size.
+func (p *Policy) GetMaxBlockSizeInternal(d dao.DAO) uint32 {
+	return p.getUint32WithDefault(d, maxBlockSizeKey, DefaultMaxBlockSize)
+}
+
+// getFeePerByte is Policy contract method and returns required transaction's fee
+// per byte.
+func (p *Policy) getFeePerByte(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(p.GetFeePerByteInternal(ic.DAO)))
+}
+
+// GetFeePerByteInternal returns required transaction's fee per byte.
+func (p *Policy) GetFeePerByteInternal(d dao.DAO) int64 {
+	si := d.GetStorageItem(p.Hash, feePerByteKey)
+	if si == nil || len(si.Value) != 8 {
+		return DefaultFeePerByte
+	}
+	return int64(binary.LittleEndian.Uint64(si.Value))
+}
+
+// getBlockedAccounts is Policy contract method and returns list of blocked
+// accounts hashes.
+func (p *Policy) getBlockedAccounts(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	ba, err := p.GetBlockedAccountsInternal(ic.DAO)
+	if err != nil {
+		panic(err)
+	}
+	arr := make([]stackitem.Item, len(ba))
+	for i := range ba {
+		arr[i] = stackitem.NewByteArray(ba[i].BytesBE())
+	}
+	return stackitem.NewArray(arr)
+}
+
+// GetBlockedAccountsInternal returns list of blocked accounts hashes.
+func (p *Policy) GetBlockedAccountsInternal(d dao.DAO) (BlockedAccounts, error) {
+	si := d.GetStorageItem(p.Hash, blockedAccountsKey)
+	if si == nil {
+		return BlockedAccounts{}, nil
+	}
+	return BlockedAccountsFromBytes(si.Value)
+}
+
+// setMaxTransactionsPerBlock is Policy contract method and sets the upper limit
+// of transactions per block, 0 removes the limit.
+func (p *Policy) setMaxTransactionsPerBlock(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint32(args[0])
+	if !checkCommittee(ic, p.NEO, p.Hash) {
+		return stackitem.NewBool(false)
+	}
+	if err := p.putUint32(ic.DAO, maxTransactionsPerBlockKey, value); err != nil {
+		panic(err)
+	}
+	return stackitem.NewBool(true)
+}
+
+// setMaxBlockSize is Policy contract method and sets maximum block size.
+func (p *Policy) setMaxBlockSize(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint32(args[0])
+	if value > maxBlockSizeLimit || !checkCommittee(ic, p.NEO, p.Hash) {
+		return stackitem.NewBool(false)
+	}
+	if err := p.putUint32(ic.DAO, maxBlockSizeKey, value); err != nil {
+		panic(err)
+	}
+	return stackitem.NewBool(true)
+}
+
+// setFeePerByte is Policy contract method and sets transaction's fee per byte.
+func (p *Policy) setFeePerByte(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toBigInt(args[0])
+	if !value.IsInt64() || value.Sign() < 0 || !checkCommittee(ic, p.NEO, p.Hash) {
+		return stackitem.NewBool(false)
+	}
+	if err := p.putInt64(ic.DAO, feePerByteKey, value.Int64()); err != nil {
+		panic(err)
+	}
+	return stackitem.NewBool(true)
+}
+
+// blockAccount is Policy contract method and adds given account hash to the list
+// of blocked accounts. It fails if there are MaxBlockedAccounts accounts blocked
+// already.
+func (p *Policy) blockAccount(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint160(args[0])
+	if !checkCommittee(ic, p.NEO, p.Hash) {
+		return stackitem.NewBool(false)
+	}
+	ba, err := p.GetBlockedAccountsInternal(ic.DAO)
+	if err != nil {
+		panic(err)
+	}
+	i := ba.search(value)
+	if i < len(ba) && ba[i].Equals(value) || len(ba) >= MaxBlockedAccounts {
+		return stackitem.NewBool(false)
+	}
+	ba = append(ba, util.Uint160{})
+	copy(ba[i+1:], ba[i:])
+	ba[i] = value
+	if err := p.putBlockedAccounts(ic.DAO, ba); err != nil {
+		panic(err)
+	}
+	return stackitem.NewBool(true)
+}
+
+// unblockAccount is Policy contract method and removes given account hash from
+// the list of blocked accounts.
+func (p *Policy) unblockAccount(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint160(args[0])
+	if !checkCommittee(ic, p.NEO, p.Hash) {
+		return stackitem.NewBool(false)
+	}
+	ba, err := p.GetBlockedAccountsInternal(ic.DAO)
+	if err != nil {
+		panic(err)
+	}
+	i := ba.search(value)
+	if i == len(ba) || !ba[i].Equals(value) {
+		return stackitem.NewBool(false)
+	}
+	ba = append(ba[:i], ba[i+1:]...)
+	if err := p.putBlockedAccounts(ic.DAO, ba); err != nil {
+		panic(err)
+	}
+	return stackitem.NewBool(true)
+}
+
+// CheckPolicy checks whether transaction's sender and cosigners are not
+// blocked by the Policy contract.
+func (p *Policy) CheckPolicy(d dao.DAO, sender util.Uint160, cosigners []util.Uint160) (bool, error) {
+	ba, err := p.GetBlockedAccountsInternal(d)
+	if err != nil {
+		return false, err
+	}
+	if ba.Contains(sender) {
+		return false, nil
+	}
+	for i := range cosigners {
+		if ba.Contains(cosigners[i]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (p *Policy) getUint32WithDefault(d dao.DAO, key []byte, defaultValue uint32) uint32 {
+	si := d.GetStorageItem(p.Hash, key)
+	if si == nil || len(si.Value) != 4 {
+		return defaultValue
+	}
+	return binary.LittleEndian.Uint32(si.Value)
+}
+
+func (p *Policy) putUint32(d dao.DAO, key []byte, value uint32) error {
+	si := &state.StorageItem{Value: make([]byte, 4)}
+	binary.LittleEndian.PutUint32(si.Value, value)
+	return d.PutStorageItem(p.Hash, key, si)
+}
+
+func (p *Policy) putInt64(d dao.DAO, key []byte, value int64) error {
+	si := &state.StorageItem{Value: make([]byte, 8)}
+	binary.LittleEndian.PutUint64(si.Value, uint64(value))
+	return d.PutStorageItem(p.Hash, key, si)
+}
+
+func (p *Policy) putBlockedAccounts(d dao.DAO, ba BlockedAccounts) error {
+	return d.PutStorageItem(p.Hash, blockedAccountsKey, &state.StorageItem{Value: ba.Bytes()})
+}
+
+func toUint32(s stackitem.Item) uint32 {
+	bi := toBigInt(s)
+	if !bi.IsInt64() || bi.Int64() < 0 || bi.Int64() > int64(^uint32(0)) {
+		panic(errors.New("value doesn't fit into uint32"))
+	}
+	return uint32(bi.Int64())
+}
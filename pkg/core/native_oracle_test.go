@@ -0,0 +1,262 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// getOracleContractState returns test contract which passes `request` calls to
+// the Oracle contract and saves serialized arguments of any other call.
+func getOracleContractState(oracleHash util.Uint160) *state.Contract {
+	w := io.NewBufBinWriter()
+	emit.String(w.BinWriter, "saved")
+	emit.Syscall(w.BinWriter, "System.Storage.GetContext")
+	emit.Syscall(w.BinWriter, "System.Storage.Put")
+	emit.Opcode(w.BinWriter, opcode.RET)
+	cb := w.Bytes()
+
+	w = io.NewBufBinWriter()
+	emit.Bytes(w.BinWriter, oracleHash.BytesBE())
+	emit.Syscall(w.BinWriter, "System.Contract.Call")
+	emit.Opcode(w.BinWriter, opcode.RET)
+	req := w.Bytes()
+
+	w = io.NewBufBinWriter()
+	emit.Opcode(w.BinWriter, opcode.DUP)
+	emit.String(w.BinWriter, "request")
+	emit.Opcode(w.BinWriter, opcode.EQUAL)
+	emit.Instruction(w.BinWriter, opcode.JMPIFNOT, []byte{byte(2 + len(req))})
+	w.WriteBytes(req)
+	emit.Opcode(w.BinWriter, opcode.DROP)
+	emit.Syscall(w.BinWriter, "System.Runtime.Serialize")
+	w.WriteBytes(cb)
+	return &state.Contract{
+		Script:     w.Bytes(),
+		Properties: smartcontract.HasStorage,
+	}
+}
+
+// invokeByOwner creates transaction with the given script and fee sent by
+// the owner of all NEO and returns its execution result.
+func invokeByOwner(t *testing.T, chain *Blockchain, script []byte, sysFee util.Fixed8) *state.AppExecResult {
+	tx := transaction.New(script, sysFee)
+	tx.ValidUntilBlock = chain.blockHeight + 1
+	tx.Cosigners = []transaction.Cosigner{{
+		Account: neoOwner,
+		Scopes:  transaction.CalledByEntry,
+	}}
+	require.NoError(t, addSender(tx))
+	require.NoError(t, signTx(chain, tx))
+	require.NoError(t, chain.AddBlock(chain.newBlock(tx)))
+
+	res, err := chain.GetAppExecResult(tx.Hash())
+	require.NoError(t, err)
+	return res
+}
+
+func oracleRequestScript(t *testing.T, h util.Uint160, url, filter string, userData []byte, gas int64) []byte {
+	w := io.NewBufBinWriter()
+	emit.AppCallWithOperationAndArgs(w.BinWriter, h, "request", url, filter, "callback", userData, gas)
+	require.NoError(t, w.Err)
+	return w.Bytes()
+}
+
+// newOracleResponseTx returns signed response transaction sent by acc.
+func newOracleResponseTx(t *testing.T, chain *Blockchain, acc *wallet.Account, resp *transaction.OracleResponseData, gas uint64) *transaction.Transaction {
+	tx := transaction.New(chain.GetOracleResponseScript(), util.Fixed8(gas))
+	tx.Attributes = append(tx.Attributes, resp.ToAttribute())
+	tx.Sender = acc.Contract.ScriptHash()
+	tx.ValidUntilBlock = chain.blockHeight + 1
+	require.NoError(t, addNetworkFee(chain, tx, acc))
+	require.NoError(t, acc.SignTx(tx))
+	return tx
+}
+
+func TestOracle_Request(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.Close()
+
+	orc := chain.contracts.Oracle
+	cs := getOracleContractState(orc.Hash)
+	require.NoError(t, chain.dao.PutContractState(cs))
+
+	// The first request has id 0, find the node designated to respond to it.
+	nodes, err := chain.GetOracleNodes()
+	require.NoError(t, err)
+	require.True(t, len(nodes) > 1)
+	responder := native.GetOracleResponder(nodes, 0)
+	var priv, otherPriv *keys.PrivateKey
+	for i := 0; i < testchain.Size(); i++ {
+		if p := testchain.PrivateKey(i); p.PublicKey().Equal(responder) {
+			priv = p
+		} else if nodes.Contains(p.PublicKey()) {
+			otherPriv = p
+		}
+	}
+	require.NotNil(t, priv)
+	require.NotNil(t, otherPriv)
+	acc, err := wallet.NewAccountFromWIF(priv.WIF())
+	require.NoError(t, err)
+
+	// Fund oracle node account.
+	w := io.NewBufBinWriter()
+	emit.AppCallWithOperationAndArgs(w.BinWriter, chain.contracts.GAS.Hash, "transfer",
+		neoOwner, acc.Contract.ScriptHash(), int64(util.Fixed8FromInt64(100)))
+	emit.Opcode(w.BinWriter, opcode.ASSERT)
+	res := invokeByOwner(t, chain, w.Bytes(), 0)
+	require.Equal(t, "HALT", res.VMState)
+
+	gasForResponse := int64(native.MinimumResponseGas * 5)
+	userData := []byte("data")
+	script := oracleRequestScript(t, cs.ScriptHash(), "https://example.com/price", "$.price", userData, gasForResponse)
+	res = invokeByOwner(t, chain, script, util.Fixed8FromInt64(1))
+	require.Equal(t, "HALT", res.VMState)
+	require.Equal(t, 1, len(res.Events))
+	require.Equal(t, orc.Hash, res.Events[0].ScriptHash)
+
+	reqs, err := chain.GetOracleRequests()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(reqs))
+	req := reqs[0]
+	require.NotNil(t, req)
+	require.Equal(t, res.TxHash, req.OriginalTxID)
+	require.EqualValues(t, gasForResponse, req.GasForResponse)
+	require.Equal(t, "https://example.com/price", req.URL)
+	require.Equal(t, "$.price", req.Filter)
+	require.Equal(t, cs.ScriptHash(), req.CallbackContract)
+	require.Equal(t, "callback", req.CallbackMethod)
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		checkFault := func(t *testing.T, script []byte) {
+			res := invokeByOwner(t, chain, script, util.Fixed8FromInt64(1))
+			require.Equal(t, "FAULT", res.VMState)
+		}
+		t.Run("NotEnoughGas", func(t *testing.T) {
+			checkFault(t, oracleRequestScript(t, cs.ScriptHash(), "url", "", userData, native.MinimumResponseGas-1))
+		})
+		t.Run("BigURL", func(t *testing.T) {
+			checkFault(t, oracleRequestScript(t, cs.ScriptHash(), string(make([]byte, 257)), "", userData, gasForResponse))
+		})
+		t.Run("BigUserData", func(t *testing.T) {
+			checkFault(t, oracleRequestScript(t, cs.ScriptHash(), "url", "", make([]byte, 513), gasForResponse))
+		})
+		t.Run("NotFromContract", func(t *testing.T) {
+			checkFault(t, oracleRequestScript(t, orc.Hash, "url", "", userData, gasForResponse))
+		})
+	})
+
+	t.Run("VerifyResponse", func(t *testing.T) {
+		resp := &transaction.OracleResponseData{ID: 0, Code: transaction.Success, Result: []byte("[1]")}
+		tx := newOracleResponseTx(t, chain, acc, resp, req.GasForResponse)
+		require.NoError(t, chain.VerifyTx(tx, nil))
+
+		t.Run("UnknownRequest", func(t *testing.T) {
+			resp := &transaction.OracleResponseData{ID: 10, Code: transaction.Success}
+			tx := newOracleResponseTx(t, chain, acc, resp, req.GasForResponse)
+			require.Error(t, chain.VerifyTx(tx, nil))
+		})
+		t.Run("NotOracleNode", func(t *testing.T) {
+			acc, err := wallet.NewAccount()
+			require.NoError(t, err)
+			tx := newOracleResponseTx(t, chain, acc, resp, req.GasForResponse)
+			require.Error(t, orc.VerifyResponse(chain.dao, tx))
+		})
+		t.Run("NotResponder", func(t *testing.T) {
+			acc, err := wallet.NewAccountFromWIF(otherPriv.WIF())
+			require.NoError(t, err)
+			tx := newOracleResponseTx(t, chain, acc, resp, req.GasForResponse)
+			require.Error(t, orc.VerifyResponse(chain.dao, tx))
+		})
+		t.Run("InvalidScript", func(t *testing.T) {
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+			tx.Attributes = append(tx.Attributes, resp.ToAttribute())
+			tx.Sender = acc.Contract.ScriptHash()
+			require.Error(t, orc.VerifyResponse(chain.dao, tx))
+		})
+		t.Run("MultipleAttributes", func(t *testing.T) {
+			tx := transaction.New(chain.GetOracleResponseScript(), 0)
+			tx.Attributes = append(tx.Attributes, resp.ToAttribute(), resp.ToAttribute())
+			tx.Sender = acc.Contract.ScriptHash()
+			require.Error(t, orc.VerifyResponse(chain.dao, tx))
+		})
+	})
+
+	t.Run("Finish", func(t *testing.T) {
+		resp := &transaction.OracleResponseData{ID: 0, Code: transaction.Success, Result: []byte("[1]")}
+		tx := newOracleResponseTx(t, chain, acc, resp, req.GasForResponse)
+		require.NoError(t, chain.AddBlock(chain.newBlock(tx)))
+
+		res, err := chain.GetAppExecResult(tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.VMState)
+
+		reqs, err := chain.GetOracleRequests()
+		require.NoError(t, err)
+		require.Equal(t, 0, len(reqs))
+
+		// Response GAS is returned to the oracle node.
+		require.Equal(t, 2, len(res.Events))
+		require.Equal(t, chain.contracts.GAS.Hash, res.Events[0].ScriptHash)
+		require.Equal(t, stackitem.NewArray([]stackitem.Item{
+			stackitem.NewByteArray([]byte("Transfer")),
+			stackitem.Null{},
+			stackitem.NewByteArray(acc.Contract.ScriptHash().BytesBE()),
+			stackitem.NewBigInteger(big.NewInt(gasForResponse)),
+		}), res.Events[0].Item)
+		require.Equal(t, orc.Hash, res.Events[1].ScriptHash)
+
+		si := chain.GetStorageItem(cs.ScriptHash(), []byte("saved"))
+		require.NotNil(t, si)
+		item, err := stackitem.DeserializeItem(si.Value)
+		require.NoError(t, err)
+		require.Equal(t, stackitem.NewArray([]stackitem.Item{
+			stackitem.NewByteArray([]byte("https://example.com/price")),
+			stackitem.NewByteArray(userData),
+			stackitem.NewBigInteger(big.NewInt(0)),
+			stackitem.NewByteArray([]byte("[1]")),
+		}), item)
+
+		// Request was already processed.
+		tx = newOracleResponseTx(t, chain, acc, resp, req.GasForResponse)
+		require.Error(t, chain.VerifyTx(tx, nil))
+	})
+}
+
+func TestOracle_SetOracleNodes(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.Close()
+
+	priv := testchain.PrivateKeyByID(0)
+	w := io.NewBufBinWriter()
+	emit.Bytes(w.BinWriter, priv.PublicKey().Bytes())
+	emit.Int(w.BinWriter, 1)
+	emit.Opcode(w.BinWriter, opcode.PACK)
+	emit.Int(w.BinWriter, 1)
+	emit.Opcode(w.BinWriter, opcode.PACK)
+	emit.String(w.BinWriter, "setOracleNodes")
+	emit.Bytes(w.BinWriter, chain.contracts.Oracle.Hash.BytesBE())
+	emit.Syscall(w.BinWriter, "System.Contract.Call")
+	require.NoError(t, w.Err)
+
+	res := invokeByOwner(t, chain, w.Bytes(), 0)
+	checkPolicyResult(t, res, smartcontract.BoolType, true)
+
+	nodes, err := chain.GetOracleNodes()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(nodes))
+	require.True(t, nodes[0].Equal(priv.PublicKey()))
+}
@@ -0,0 +1,202 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+// invokeNativePolicyMethod invokes given Policy contract method with the
+// committee as a sender and returns execution result.
+func invokeNativePolicyMethod(t *testing.T, chain *Blockchain, method string, args ...interface{}) *state.AppExecResult {
+	w := io.NewBufBinWriter()
+	emit.AppCallWithOperationAndArgs(w.BinWriter, chain.contracts.Policy.Hash, method, args...)
+	require.NoError(t, w.Err)
+	tx := transaction.New(w.Bytes(), 0)
+	tx.ValidUntilBlock = chain.blockHeight + 1
+	tx.Cosigners = []transaction.Cosigner{{
+		Account: neoOwner,
+		Scopes:  transaction.CalledByEntry,
+	}}
+	require.NoError(t, addSender(tx))
+	require.NoError(t, signTx(chain, tx))
+	require.NoError(t, chain.AddBlock(chain.newBlock(tx)))
+
+	res, err := chain.GetAppExecResult(tx.Hash())
+	require.NoError(t, err)
+	return res
+}
+
+func checkPolicyResult(t *testing.T, res *state.AppExecResult, typ smartcontract.ParamType, value interface{}) {
+	require.Equal(t, "HALT", res.VMState)
+	require.Equal(t, 1, len(res.Stack))
+	require.Equal(t, typ, res.Stack[0].Type)
+	require.EqualValues(t, value, res.Stack[0].Value)
+}
+
+func TestPolicy_Defaults(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.Close()
+
+	p := chain.contracts.Policy
+	require.EqualValues(t, native.DefaultFeePerByte, p.GetFeePerByteInternal(chain.dao))
+	require.EqualValues(t, native.DefaultMaxBlockSize, p.GetMaxBlockSizeInternal(chain.dao))
+	maxTx := uint32(native.DefaultMaxTransactionsPerBlock)
+	if chain.config.MaxTransactionsPerBlock > 0 {
+		maxTx = uint32(chain.config.MaxTransactionsPerBlock)
+	}
+	require.Equal(t, maxTx, p.GetMaxTransactionsPerBlockInternal(chain.dao))
+	ba, err := p.GetBlockedAccountsInternal(chain.dao)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(ba))
+
+	res := invokeNativePolicyMethod(t, chain, "getFeePerByte")
+	checkPolicyResult(t, res, smartcontract.IntegerType, native.DefaultFeePerByte)
+}
+
+func TestPolicy_Setters(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.Close()
+
+	p := chain.contracts.Policy
+
+	t.Run("MaxTransactionsPerBlock", func(t *testing.T) {
+		res := invokeNativePolicyMethod(t, chain, "setMaxTransactionsPerBlock", int64(1024))
+		checkPolicyResult(t, res, smartcontract.BoolType, true)
+		require.EqualValues(t, 1024, p.GetMaxTransactionsPerBlockInternal(chain.dao))
+	})
+
+	t.Run("MaxBlockSize", func(t *testing.T) {
+		res := invokeNativePolicyMethod(t, chain, "setMaxBlockSize", int64(100500))
+		checkPolicyResult(t, res, smartcontract.BoolType, true)
+		require.EqualValues(t, 100500, p.GetMaxBlockSizeInternal(chain.dao))
+
+		res = invokeNativePolicyMethod(t, chain, "getMaxBlockSize")
+		checkPolicyResult(t, res, smartcontract.IntegerType, 100500)
+	})
+
+	t.Run("FeePerByte", func(t *testing.T) {
+		res := invokeNativePolicyMethod(t, chain, "setFeePerByte", int64(1100))
+		checkPolicyResult(t, res, smartcontract.BoolType, true)
+		require.EqualValues(t, 1100, p.GetFeePerByteInternal(chain.dao))
+		require.EqualValues(t, 1100, chain.FeePerByte())
+
+		res = invokeNativePolicyMethod(t, chain, "setFeePerByte", int64(-1))
+		checkPolicyResult(t, res, smartcontract.BoolType, false)
+		require.EqualValues(t, 1100, p.GetFeePerByteInternal(chain.dao))
+	})
+
+	t.Run("BlockedAccounts", func(t *testing.T) {
+		acc := util.Uint160{1, 2, 3}
+		res := invokeNativePolicyMethod(t, chain, "blockAccount", acc.BytesBE())
+		checkPolicyResult(t, res, smartcontract.BoolType, true)
+
+		res = invokeNativePolicyMethod(t, chain, "blockAccount", acc.BytesBE())
+		checkPolicyResult(t, res, smartcontract.BoolType, false)
+
+		ba, err := p.GetBlockedAccountsInternal(chain.dao)
+		require.NoError(t, err)
+		require.Equal(t, native.BlockedAccounts{acc}, ba)
+
+		tx := transaction.New([]byte{byte(0x11)}, 0) // PUSH1
+		tx.Sender = neoOwner
+		tx.Cosigners = []transaction.Cosigner{{Account: acc}}
+		require.Equal(t, []*transaction.Transaction{}, chain.ApplyPolicyToTxSet([]*transaction.Transaction{tx}))
+		require.Equal(t, ErrPolicy, chain.checkPolicy(tx))
+
+		res = invokeNativePolicyMethod(t, chain, "unblockAccount", acc.BytesBE())
+		checkPolicyResult(t, res, smartcontract.BoolType, true)
+
+		ba, err = p.GetBlockedAccountsInternal(chain.dao)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(ba))
+		require.NoError(t, chain.checkPolicy(tx))
+	})
+
+	t.Run("BlockedAccountsLimit", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		for i := 0; i < native.MaxBlockedAccounts; i++ {
+			acc := util.Uint160{byte(i), byte(i >> 8), 0xFF}
+			emit.AppCallWithOperationAndArgs(w.BinWriter, p.Hash, "blockAccount", acc.BytesBE())
+			emit.Opcode(w.BinWriter, opcode.ASSERT)
+		}
+		require.NoError(t, w.Err)
+		tx := transaction.New(w.Bytes(), 0)
+		tx.ValidUntilBlock = chain.blockHeight + 1
+		tx.Cosigners = []transaction.Cosigner{{
+			Account: neoOwner,
+			Scopes:  transaction.CalledByEntry,
+		}}
+		require.NoError(t, addSender(tx))
+		require.NoError(t, signTx(chain, tx))
+		require.NoError(t, chain.AddBlock(chain.newBlock(tx)))
+		res, err := chain.GetAppExecResult(tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, "HALT", res.VMState)
+
+		acc := util.Uint160{0xFF, 0xFF, 0xFF}
+		res = invokeNativePolicyMethod(t, chain, "blockAccount", acc.BytesBE())
+		checkPolicyResult(t, res, smartcontract.BoolType, false)
+
+		ba, err := p.GetBlockedAccountsInternal(chain.dao)
+		require.NoError(t, err)
+		require.Equal(t, native.MaxBlockedAccounts, len(ba))
+		require.False(t, ba.Contains(acc))
+	})
+}
+
+func TestApplyPolicyToTxSet(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.Close()
+
+	blocked := util.Uint160{1, 2, 3}
+	res := invokeNativePolicyMethod(t, chain, "blockAccount", blocked.BytesBE())
+	checkPolicyResult(t, res, smartcontract.BoolType, true)
+
+	txes := make([]*transaction.Transaction, 3)
+	for i := range txes {
+		txes[i] = transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		txes[i].Sender = neoOwner
+	}
+	txes[0].Sender = blocked
+	orig := append([]*transaction.Transaction{}, txes...)
+
+	t.Run("Unlimited", func(t *testing.T) {
+		require.EqualValues(t, 0, chain.contracts.Policy.GetMaxTransactionsPerBlockInternal(chain.dao))
+		require.Equal(t, orig[1:], chain.ApplyPolicyToTxSet(txes))
+		require.Equal(t, orig, txes)
+	})
+	t.Run("Limited", func(t *testing.T) {
+		res := invokeNativePolicyMethod(t, chain, "setMaxTransactionsPerBlock", int64(2))
+		checkPolicyResult(t, res, smartcontract.BoolType, true)
+		require.Equal(t, orig[1:2], chain.ApplyPolicyToTxSet(txes))
+		require.Equal(t, orig, txes)
+	})
+}
+
+func TestPolicy_NoWitness(t *testing.T) {
+	chain := newTestChain(t)
+	defer chain.Close()
+
+	w := io.NewBufBinWriter()
+	emit.AppCallWithOperationAndArgs(w.BinWriter, chain.contracts.Policy.Hash, "setFeePerByte", int64(1))
+	require.NoError(t, w.Err)
+	tx := transaction.New(w.Bytes(), 0)
+	tx.ValidUntilBlock = chain.blockHeight + 1
+	require.NoError(t, addSender(tx))
+	require.NoError(t, signTx(chain, tx))
+	require.NoError(t, chain.AddBlock(chain.newBlock(tx)))
+
+	res, err := chain.GetAppExecResult(tx.Hash())
+	require.NoError(t, err)
+	checkPolicyResult(t, res, smartcontract.BoolType, false)
+	require.EqualValues(t, native.DefaultFeePerByte, chain.contracts.Policy.GetFeePerByteInternal(chain.dao))
+}
@@ -24,6 +24,13 @@ type Account struct {
 	Balances   map[util.Uint256][]UnspentBalance
 }
 
+// AccountTransaction is a reference to the transaction an account was a
+// sender or a cosigner of.
+type AccountTransaction struct {
+	BlockIndex uint32
+	TxHash     util.Uint256
+}
+
 // NewAccount returns a new Account object.
 func NewAccount(scriptHash util.Uint160) *Account {
 	return &Account{
@@ -84,6 +91,18 @@ func (u *UnspentBalance) EncodeBinary(w *io.BinWriter) {
 	u.Value.EncodeBinary(w)
 }
 
+// DecodeBinary implements io.Serializable interface.
+func (t *AccountTransaction) DecodeBinary(r *io.BinReader) {
+	t.BlockIndex = r.ReadU32LE()
+	t.TxHash.DecodeBinary(r)
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (t *AccountTransaction) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(t.BlockIndex)
+	t.TxHash.EncodeBinary(w)
+}
+
 // GetBalanceValues sums all unspent outputs and returns a map of asset IDs to
 // overall balances.
 func (s *Account) GetBalanceValues() map[util.Uint256]util.Fixed8 {
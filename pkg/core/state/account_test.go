@@ -49,3 +49,11 @@ func TestAccountStateBalanceValues(t *testing.T) {
 	assert.Equal(t, util.Fixed8(ref), bVals[asset1])
 	assert.Equal(t, util.Fixed8(ref*10), bVals[asset2])
 }
+
+func TestDecodeEncodeAccountTransaction(t *testing.T) {
+	at := &AccountTransaction{
+		BlockIndex: 42,
+		TxHash:     random.Uint256(),
+	}
+	testserdes.EncodeDecodeBinary(t, at, new(AccountTransaction))
+}
@@ -0,0 +1,24 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// MPTRoot represents storage state root of the block.
+type MPTRoot struct {
+	Index uint32       `json:"index"`
+	Root  util.Uint256 `json:"stateroot"`
+}
+
+// EncodeBinary implements io.Serializable.
+func (s *MPTRoot) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(s.Index)
+	w.WriteBytes(s.Root[:])
+}
+
+// DecodeBinary implements io.Serializable.
+func (s *MPTRoot) DecodeBinary(r *io.BinReader) {
+	s.Index = r.ReadU32LE()
+	r.ReadBytes(s.Root[:])
+}
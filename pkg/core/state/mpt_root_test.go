@@ -0,0 +1,17 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/internal/testserdes"
+)
+
+func TestEncodeDecodeMPTRoot(t *testing.T) {
+	r := &MPTRoot{
+		Index: 42,
+		Root:  random.Uint256(),
+	}
+
+	testserdes.EncodeDecodeBinary(t, r, new(MPTRoot))
+}
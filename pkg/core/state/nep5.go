@@ -110,6 +110,28 @@ func (lg *NEP5TransferLog) ForEach(f func(*NEP5Transfer) error) error {
 	return nil
 }
 
+// ForEachReverse iterates over transfer log starting from the latest transfer
+// until f returns false or an error. It returns false if the iteration was
+// stopped by f.
+func (lg *NEP5TransferLog) ForEachReverse(f func(*NEP5Transfer) (bool, error)) (bool, error) {
+	if lg == nil {
+		return true, nil
+	}
+	tr := new(NEP5Transfer)
+	for i := len(lg.Raw) - NEP5TransferSize; i >= 0; i -= NEP5TransferSize {
+		r := io.NewBinReaderFromBuf(lg.Raw[i : i+NEP5TransferSize])
+		tr.DecodeBinary(r)
+		if r.Err != nil {
+			return false, r.Err
+		}
+		cont, err := f(tr)
+		if err != nil || !cont {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // Size returns an amount of transfer written in log.
 func (lg *NEP5TransferLog) Size() int {
 	return len(lg.Raw) / NEP5TransferSize
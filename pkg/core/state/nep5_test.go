@@ -36,6 +36,15 @@ func TestNEP5TransferLog_Append(t *testing.T) {
 	})
 	require.NoError(t, err)
 
+	i = len(expected) - 1
+	cont, err := lg.ForEachReverse(func(tr *NEP5Transfer) (bool, error) {
+		require.Equal(t, expected[i], tr)
+		i--
+		return i > 0, nil
+	})
+	require.NoError(t, err)
+	require.False(t, cont)
+	require.Equal(t, 0, i)
 }
 
 func TestNEP5Tracker_EncodeBinary(t *testing.T) {
@@ -15,6 +15,14 @@ type NotificationEvent struct {
 	Item       stackitem.Item
 }
 
+// ContractNotification is a notification event along with the block and the
+// transaction it was emitted in.
+type ContractNotification struct {
+	NotificationEvent
+	BlockIndex uint32
+	TxHash     util.Uint256
+}
+
 // AppExecResult represent the result of the script execution, gathering together
 // all resulting notifications, state, stack and other metadata.
 type AppExecResult struct {
@@ -38,6 +46,20 @@ func (ne *NotificationEvent) DecodeBinary(r *io.BinReader) {
 	ne.Item = stackitem.DecodeBinaryStackItem(r)
 }
 
+// EncodeBinary implements the Serializable interface.
+func (cn *ContractNotification) EncodeBinary(w *io.BinWriter) {
+	cn.NotificationEvent.EncodeBinary(w)
+	w.WriteU32LE(cn.BlockIndex)
+	w.WriteBytes(cn.TxHash[:])
+}
+
+// DecodeBinary implements the Serializable interface.
+func (cn *ContractNotification) DecodeBinary(r *io.BinReader) {
+	cn.NotificationEvent.DecodeBinary(r)
+	cn.BlockIndex = r.ReadU32LE()
+	r.ReadBytes(cn.TxHash[:])
+}
+
 // EncodeBinary implements the Serializable interface.
 func (aer *AppExecResult) EncodeBinary(w *io.BinWriter) {
 	w.WriteBytes(aer.TxHash[:])
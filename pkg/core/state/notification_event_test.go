@@ -18,6 +18,19 @@ func TestEncodeDecodeNotificationEvent(t *testing.T) {
 	testserdes.EncodeDecodeBinary(t, event, new(NotificationEvent))
 }
 
+func TestEncodeDecodeContractNotification(t *testing.T) {
+	cn := &ContractNotification{
+		NotificationEvent: NotificationEvent{
+			ScriptHash: random.Uint160(),
+			Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make("event"), stackitem.Make(42)}),
+		},
+		BlockIndex: 123,
+		TxHash:     random.Uint256(),
+	}
+
+	testserdes.EncodeDecodeBinary(t, cn, new(ContractNotification))
+}
+
 func TestEncodeDecodeAppExecResult(t *testing.T) {
 	appExecResult := &AppExecResult{
 		TxHash:      random.Uint256(),
@@ -0,0 +1,46 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// OracleRequest represents oracle request stored by the Oracle native contract.
+type OracleRequest struct {
+	// OriginalTxID is the hash of the transaction that made this request.
+	OriginalTxID util.Uint256
+	// GasForResponse is the amount of GAS (in 10^-8 units) paid for the response.
+	GasForResponse uint64
+	// URL is the resource to be fetched.
+	URL string
+	// Filter is a JSONPath expression applied to the fetched data.
+	Filter string
+	// CallbackContract is the contract to be called with the result.
+	CallbackContract util.Uint160
+	// CallbackMethod is the method of CallbackContract to be called.
+	CallbackMethod string
+	// UserData is serialized stack item passed to the callback as is.
+	UserData []byte
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (r *OracleRequest) EncodeBinary(w *io.BinWriter) {
+	w.WriteBytes(r.OriginalTxID[:])
+	w.WriteU64LE(r.GasForResponse)
+	w.WriteString(r.URL)
+	w.WriteString(r.Filter)
+	w.WriteBytes(r.CallbackContract[:])
+	w.WriteString(r.CallbackMethod)
+	w.WriteVarBytes(r.UserData)
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (r *OracleRequest) DecodeBinary(br *io.BinReader) {
+	br.ReadBytes(r.OriginalTxID[:])
+	r.GasForResponse = br.ReadU64LE()
+	r.URL = br.ReadString()
+	r.Filter = br.ReadString()
+	br.ReadBytes(r.CallbackContract[:])
+	r.CallbackMethod = br.ReadString()
+	r.UserData = br.ReadVarBytes()
+}
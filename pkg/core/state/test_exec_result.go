@@ -0,0 +1,37 @@
+package state
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// TestExecResult is the result of the test script execution. Apart from the
+// regular AppExecResult data it contains details not stored for transactions
+// persisted.
+type TestExecResult struct {
+	AppExecResult
+	// FaultException is the error message if the execution has failed.
+	FaultException string
+	// Logs contains all messages logged with System.Runtime.Log.
+	Logs []LogEvent
+	// StorageChanges contains all contract storage changes made, they are
+	// sorted by contract and key.
+	StorageChanges []StorageChange
+	// Iterators contains iterator (and enumerator) items from the resulting
+	// stack, keys are their positions in the Stack.
+	Iterators map[int]stackitem.Item
+}
+
+// LogEvent is a message logged by some contract during execution.
+type LogEvent struct {
+	ScriptHash util.Uint160
+	Message    string
+}
+
+// StorageChange is a change of contract storage item made during execution.
+// Value is nil for deleted items.
+type StorageChange struct {
+	ScriptHash util.Uint160
+	Key        []byte
+	Value      []byte
+}
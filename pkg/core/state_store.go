@@ -0,0 +1,115 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// errReadOnlyStore is returned on any attempt to change stateStore.
+var errReadOnlyStore = errors.New("historical state store is read-only")
+
+// stateStore is a read-only storage.Store providing the state as it was at
+// some height. Items covered by the state root (see isStateKey) are taken from
+// the MPT with the root of that height while all the other ones are taken
+// from the current backend store.
+type stateStore struct {
+	trie    *mpt.Trie
+	backend storage.Store
+}
+
+// newStateStore returns stateStore for the MPT with the given root hash
+// (which can be zero for an empty trie) stored in backend.
+func newStateStore(root util.Uint256, backend storage.Store) *stateStore {
+	var r mpt.Node
+	if !root.Equals(util.Uint256{}) {
+		r = mpt.NewHashNode(root)
+	}
+	return &stateStore{
+		trie:    mpt.NewTrie(r, backend),
+		backend: backend,
+	}
+}
+
+// Batch implements storage.Store interface.
+func (s *stateStore) Batch() storage.Batch {
+	return s.backend.Batch()
+}
+
+// Delete implements storage.Store interface, it always returns an error.
+func (s *stateStore) Delete(k []byte) error {
+	return errReadOnlyStore
+}
+
+// Get implements storage.Store interface.
+func (s *stateStore) Get(k []byte) ([]byte, error) {
+	if !isStateKey(k) {
+		return s.backend.Get(k)
+	}
+	v, err := s.trie.Get(k)
+	if err == mpt.ErrNotFound {
+		return nil, storage.ErrKeyNotFound
+	}
+	return v, err
+}
+
+// Put implements storage.Store interface, it always returns an error.
+func (s *stateStore) Put(k, v []byte) error {
+	return errReadOnlyStore
+}
+
+// PutBatch implements storage.Store interface, it always returns an error.
+func (s *stateStore) PutBatch(storage.Batch) error {
+	return errReadOnlyStore
+}
+
+// Seek implements storage.Store interface. It returns an error if the state
+// can't be traversed (e.g. some of the MPT nodes are missing from the backend
+// store).
+func (s *stateStore) Seek(k []byte, f func(k, v []byte)) error {
+	if !isStateKey(k) {
+		return s.backend.Seek(k, f)
+	}
+	if err := s.trie.Find(k, f); err != nil {
+		return fmt.Errorf("historical state seek: %v", err)
+	}
+	return nil
+}
+
+// SeekFrom implements storage.Store interface. MPT doesn't allow to start
+// from an arbitrary key, so all the items with the given prefix are fetched
+// and sorted first, nothing is passed to f if it fails.
+func (s *stateStore) SeekFrom(prefix, start []byte, f func(k, v []byte) bool) error {
+	if !isStateKey(prefix) {
+		return s.backend.SeekFrom(prefix, start, f)
+	}
+	var kvs []storage.KeyValue
+	from := string(prefix) + string(start)
+	err := s.Seek(prefix, func(k, v []byte) {
+		if string(k) >= from {
+			kvs = append(kvs, storage.KeyValue{Key: k, Value: v})
+		}
+	})
+	if err != nil {
+		return err
+	}
+	sort.Slice(kvs, func(i, j int) bool {
+		return bytes.Compare(kvs[i].Key, kvs[j].Key) < 0
+	})
+	for i := range kvs {
+		if !f(kvs[i].Key, kvs[i].Value) {
+			break
+		}
+	}
+	return nil
+}
+
+// Close implements storage.Store interface, the backend store is not closed.
+func (s *stateStore) Close() error {
+	return nil
+}
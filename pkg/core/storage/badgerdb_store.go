@@ -104,8 +104,8 @@ func (b *BadgerDBStore) PutBatch(batch Batch) error {
 }
 
 // Seek implements the Store interface.
-func (b *BadgerDBStore) Seek(key []byte, f func(k, v []byte)) {
-	err := b.db.View(func(txn *badger.Txn) error {
+func (b *BadgerDBStore) Seek(key []byte, f func(k, v []byte)) error {
+	return b.db.View(func(txn *badger.Txn) error {
 		it := txn.NewIterator(badger.IteratorOptions{
 			PrefetchValues: true,
 			PrefetchSize:   100,
@@ -126,9 +126,29 @@ func (b *BadgerDBStore) Seek(key []byte, f func(k, v []byte)) {
 		}
 		return nil
 	})
-	if err != nil {
-		panic(err)
-	}
+}
+
+// SeekFrom implements the Store interface.
+func (b *BadgerDBStore) SeekFrom(prefix, start []byte, f func(k, v []byte) bool) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.IteratorOptions{
+			PrefetchValues: true,
+			PrefetchSize:   100,
+			Prefix:         prefix,
+		})
+		defer it.Close()
+		for it.Seek(seekKey(prefix, start)); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+			v, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if !f(item.Key(), v) {
+				break
+			}
+		}
+		return nil
+	})
 }
 
 // Close releases all db resources.
@@ -98,8 +98,8 @@ func (s *BoltDBStore) PutBatch(batch Batch) error {
 }
 
 // Seek implements the Store interface.
-func (s *BoltDBStore) Seek(key []byte, f func(k, v []byte)) {
-	err := s.db.View(func(tx *bbolt.Tx) error {
+func (s *BoltDBStore) Seek(key []byte, f func(k, v []byte)) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
 		c := tx.Bucket(Bucket).Cursor()
 		prefix := util.BytesPrefix(key)
 		for k, v := c.Seek(prefix.Start); k != nil && bytes.Compare(k, prefix.Limit) <= 0; k, v = c.Next() {
@@ -107,9 +107,19 @@ func (s *BoltDBStore) Seek(key []byte, f func(k, v []byte)) {
 		}
 		return nil
 	})
-	if err != nil {
-		panic(err)
-	}
+}
+
+// SeekFrom implements the Store interface.
+func (s *BoltDBStore) SeekFrom(prefix, start []byte, f func(k, v []byte) bool) error {
+	return s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(Bucket).Cursor()
+		for k, v := c.Seek(seekKey(prefix, start)); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+			if !f(k, v) {
+				break
+			}
+		}
+		return nil
+	})
 }
 
 // Batch implements the Batch interface and returns a boltdb
@@ -60,12 +60,27 @@ func (s *LevelDBStore) PutBatch(batch Batch) error {
 }
 
 // Seek implements the Store interface.
-func (s *LevelDBStore) Seek(key []byte, f func(k, v []byte)) {
+func (s *LevelDBStore) Seek(key []byte, f func(k, v []byte)) error {
 	iter := s.db.NewIterator(util.BytesPrefix(key), nil)
 	for iter.Next() {
 		f(iter.Key(), iter.Value())
 	}
 	iter.Release()
+	return iter.Error()
+}
+
+// SeekFrom implements the Store interface.
+func (s *LevelDBStore) SeekFrom(prefix, start []byte, f func(k, v []byte) bool) error {
+	rng := util.BytesPrefix(prefix)
+	rng.Start = seekKey(prefix, start)
+	iter := s.db.NewIterator(rng, nil)
+	for iter.Next() {
+		if !f(iter.Key(), iter.Value()) {
+			break
+		}
+	}
+	iter.Release()
+	return iter.Error()
 }
 
 // Batch implements the Batch interface and returns a leveldb
@@ -1,5 +1,10 @@
 package storage
 
+import (
+	"bytes"
+	"sort"
+)
+
 // MemCachedStore is a wrapper around persistent store that caches all changes
 // being made for them to be later flushed in one batch.
 type MemCachedStore struct {
@@ -72,11 +77,11 @@ func (s *MemCachedStore) GetBatch() *MemBatch {
 }
 
 // Seek implements the Store interface.
-func (s *MemCachedStore) Seek(key []byte, f func(k, v []byte)) {
+func (s *MemCachedStore) Seek(key []byte, f func(k, v []byte)) error {
 	s.mut.RLock()
 	defer s.mut.RUnlock()
 	s.MemoryStore.seek(key, f)
-	s.ps.Seek(key, func(k, v []byte) {
+	return s.ps.Seek(key, func(k, v []byte) {
 		elem := string(k)
 		// If it's in mem, we already called f() for it in MemoryStore.Seek().
 		_, present := s.mem[elem]
@@ -90,6 +95,44 @@ func (s *MemCachedStore) Seek(key []byte, f func(k, v []byte)) {
 	})
 }
 
+// SeekFrom implements the Store interface. Cached changes are merged with
+// the persistent store contents on the fly, so that it can stop early.
+func (s *MemCachedStore) SeekFrom(prefix, start []byte, f func(k, v []byte) bool) error {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	mem := s.MemoryStore.collect(prefix, start)
+	sort.Slice(mem, func(i, j int) bool {
+		return bytes.Compare(mem[i].Key, mem[j].Key) < 0
+	})
+	stop := false
+	err := s.ps.SeekFrom(prefix, start, func(k, v []byte) bool {
+		// Cached items preceding (or overwriting) the persisted one.
+		for len(mem) > 0 {
+			cmp := bytes.Compare(mem[0].Key, k)
+			if cmp > 0 {
+				break
+			}
+			stop = !f(mem[0].Key, mem[0].Value)
+			mem = mem[1:]
+			if stop || cmp == 0 {
+				return !stop
+			}
+		}
+		if s.del[string(k)] {
+			return true
+		}
+		stop = !f(k, v)
+		return !stop
+	})
+	if err != nil {
+		return err
+	}
+	for i := 0; !stop && i < len(mem); i++ {
+		stop = !f(mem[i].Key, mem[i].Value)
+	}
+	return nil
+}
+
 // Persist flushes all the MemoryStore contents into the (supposedly) persistent
 // store ps.
 func (s *MemCachedStore) Persist() (int, error) {
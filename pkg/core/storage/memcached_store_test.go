@@ -158,9 +158,9 @@ func TestCachedSeek(t *testing.T) {
 		require.NoError(t, ts.Put(v.key, v.val))
 	}
 	foundKVs := make(map[string][]byte)
-	ts.Seek(goodPrefix, func(k, v []byte) {
+	require.NoError(t, ts.Seek(goodPrefix, func(k, v []byte) {
 		foundKVs[string(k)] = v
-	})
+	}))
 	assert.Equal(t, len(foundKVs), len(lowerKVs)+len(updatedKVs))
 	for _, kv := range lowerKVs {
 		assert.Equal(t, kv.val, foundKVs[string(kv.key)])
@@ -174,6 +174,31 @@ func TestCachedSeek(t *testing.T) {
 	}
 }
 
+func TestCachedSeekFrom(t *testing.T) {
+	ps := NewMemoryStore()
+	ts := NewMemCachedStore(ps)
+	for _, k := range []string{"fa", "fc", "fe", "fg"} {
+		require.NoError(t, ps.Put([]byte(k), []byte("lower")))
+	}
+	require.NoError(t, ts.Put([]byte("fb"), []byte("upper")))
+	require.NoError(t, ts.Put([]byte("fc"), []byte("upper")))
+	require.NoError(t, ts.Put([]byte("fh"), []byte("upper")))
+	require.NoError(t, ts.Delete([]byte("fe")))
+
+	seek := func(start string, limit int) []string {
+		var res []string
+		require.NoError(t, ts.SeekFrom([]byte("f"), []byte(start), func(k, v []byte) bool {
+			res = append(res, string(k)+":"+string(v))
+			return len(res) < limit
+		}))
+		return res
+	}
+	require.Equal(t, []string{"fa:lower", "fb:upper", "fc:upper", "fg:lower", "fh:upper"}, seek("", 10))
+	require.Equal(t, []string{"fc:upper", "fg:lower"}, seek("c", 2))
+	require.Equal(t, []string{"fb:upper"}, seek("b", 1))
+	require.Equal(t, []string{"fg:lower", "fh:upper"}, seek("d", 10))
+}
+
 func newMemCachedStoreForTesting(t *testing.T) Store {
 	return NewMemCachedStore(NewMemoryStore())
 }
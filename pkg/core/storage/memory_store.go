@@ -96,10 +96,11 @@ func (s *MemoryStore) PutBatch(batch Batch) error {
 }
 
 // Seek implements the Store interface.
-func (s *MemoryStore) Seek(key []byte, f func(k, v []byte)) {
+func (s *MemoryStore) Seek(key []byte, f func(k, v []byte)) error {
 	s.mut.RLock()
 	s.seek(key, f)
 	s.mut.RUnlock()
+	return nil
 }
 
 // seek is an internal unlocked implementation of Seek.
@@ -111,6 +112,28 @@ func (s *MemoryStore) seek(key []byte, f func(k, v []byte)) {
 	}
 }
 
+// SeekFrom implements the Store interface.
+func (s *MemoryStore) SeekFrom(prefix, start []byte, f func(k, v []byte) bool) error {
+	s.mut.RLock()
+	kvs := s.collect(prefix, start)
+	s.mut.RUnlock()
+	seekSorted(kvs, f)
+	return nil
+}
+
+// collect is an internal unlocked function returning all key-value pairs
+// SeekFrom should iterate over (in no particular order).
+func (s *MemoryStore) collect(prefix, start []byte) []KeyValue {
+	var kvs []KeyValue
+	from := string(seekKey(prefix, start))
+	for k, v := range s.mem {
+		if strings.HasPrefix(k, string(prefix)) && k >= from {
+			kvs = append(kvs, KeyValue{Key: []byte(k), Value: v})
+		}
+	}
+	return kvs
+}
+
 // Batch implements the Batch interface and returns a compatible Batch.
 func (s *MemoryStore) Batch() Batch {
 	return newMemoryBatch()
@@ -74,13 +74,38 @@ func (s *RedisStore) PutBatch(b Batch) error {
 }
 
 // Seek implements the Store interface.
-func (s *RedisStore) Seek(k []byte, f func(k, v []byte)) {
+func (s *RedisStore) Seek(k []byte, f func(k, v []byte)) error {
 	iter := s.client.Scan(0, fmt.Sprintf("%s*", k), 0).Iterator()
 	for iter.Next() {
 		key := iter.Val()
-		val, _ := s.client.Get(key).Result()
+		val, err := s.client.Get(key).Result()
+		if err == redis.Nil {
+			// Deleted after the scan.
+			continue
+		}
+		if err != nil {
+			return err
+		}
 		f([]byte(key), []byte(val))
 	}
+	return iter.Err()
+}
+
+// SeekFrom implements the Store interface. Redis keys are not ordered, so
+// all the matching pairs are fetched and sorted first.
+func (s *RedisStore) SeekFrom(prefix, start []byte, f func(k, v []byte) bool) error {
+	var kvs []KeyValue
+	from := string(seekKey(prefix, start))
+	err := s.Seek(prefix, func(k, v []byte) {
+		if string(k) >= from {
+			kvs = append(kvs, KeyValue{Key: k, Value: v})
+		}
+	})
+	if err != nil {
+		return err
+	}
+	seekSorted(kvs, f)
+	return nil
 }
 
 // Close implements the Store interface.
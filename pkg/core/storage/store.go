@@ -1,14 +1,18 @@
 package storage
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
+	"sort"
 )
 
 // KeyPrefix constants.
 const (
 	DataBlock        KeyPrefix = 0x01
 	DataTransaction  KeyPrefix = 0x02
+	DataMPT          KeyPrefix = 0x03
+	DataStateRoot    KeyPrefix = 0x04
 	STAccount        KeyPrefix = 0x40
 	STNotification   KeyPrefix = 0x4d
 	STContract       KeyPrefix = 0x50
@@ -16,6 +20,8 @@ const (
 	STNEP5Transfers  KeyPrefix = 0x72
 	STNEP5Balances   KeyPrefix = 0x73
 	IXHeaderHashList KeyPrefix = 0x80
+	IXNotifications  KeyPrefix = 0x81
+	IXAccountTxs     KeyPrefix = 0x82
 	SYSCurrentBlock  KeyPrefix = 0xc0
 	SYSCurrentHeader KeyPrefix = 0xc1
 	SYSVersion       KeyPrefix = 0xf0
@@ -34,7 +40,15 @@ type (
 		Get([]byte) ([]byte, error)
 		Put(k, v []byte) error
 		PutBatch(Batch) error
-		Seek(k []byte, f func(k, v []byte))
+		// Seek calls f for every key-value pair with the given prefix
+		// (in no particular order), an error is returned if the store
+		// can't be traversed.
+		Seek(k []byte, f func(k, v []byte)) error
+		// SeekFrom calls f for every key-value pair with the given
+		// prefix and the rest of the key not less than start in the
+		// ascending key order until f returns false, an error is
+		// returned if the store can't be traversed.
+		SeekFrom(prefix, start []byte, f func(k, v []byte) bool) error
 		Close() error
 	}
 
@@ -91,3 +105,23 @@ func NewStore(cfg DBConfiguration) (Store, error) {
 	}
 	return store, err
 }
+
+// seekKey returns the key SeekFrom starts from.
+func seekKey(prefix, start []byte) []byte {
+	key := make([]byte, 0, len(prefix)+len(start))
+	key = append(key, prefix...)
+	return append(key, start...)
+}
+
+// seekSorted sorts the given key-value pairs by key and calls f for every one
+// of them until it returns false.
+func seekSorted(kvs []KeyValue, f func(k, v []byte) bool) {
+	sort.Slice(kvs, func(i, j int) bool {
+		return bytes.Compare(kvs[i].Key, kvs[j].Key) < 0
+	})
+	for i := range kvs {
+		if !f(kvs[i].Key, kvs[i].Value) {
+			return
+		}
+	}
+}
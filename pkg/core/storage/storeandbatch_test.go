@@ -97,7 +97,7 @@ func testStoreSeek(t *testing.T, s Store) {
 	}
 
 	numFound := 0
-	s.Seek(goodprefix, func(k, v []byte) {
+	require.NoError(t, s.Seek(goodprefix, func(k, v []byte) {
 		for i := 0; i < len(goodkvs); i++ {
 			if string(k) == string(goodkvs[i].key) {
 				assert.Equal(t, string(goodkvs[i].val), string(v))
@@ -110,7 +110,7 @@ func testStoreSeek(t *testing.T, s Store) {
 			}
 		}
 		numFound++
-	})
+	}))
 	assert.Equal(t, len(goodkvs), numFound)
 	for i := 0; i < len(goodkvs); i++ {
 		assert.Equal(t, true, goodkvs[i].seen)
@@ -121,6 +121,28 @@ func testStoreSeek(t *testing.T, s Store) {
 	require.NoError(t, s.Close())
 }
 
+func testStoreSeekFrom(t *testing.T, s Store) {
+	for _, k := range []string{"xaa", "xab", "xb", "xba", "xc", "ya", "wa"} {
+		require.NoError(t, s.Put([]byte(k), []byte("v"+k)))
+	}
+	seek := func(prefix, start string, limit int) []string {
+		var res []string
+		require.NoError(t, s.SeekFrom([]byte(prefix), []byte(start), func(k, v []byte) bool {
+			require.Equal(t, "v"+string(k), string(v))
+			res = append(res, string(k))
+			return len(res) < limit
+		}))
+		return res
+	}
+	require.Equal(t, []string{"xaa", "xab", "xb", "xba", "xc"}, seek("x", "", 10))
+	require.Equal(t, []string{"xb", "xba", "xc"}, seek("x", "b", 10))
+	require.Equal(t, []string{"xab", "xb"}, seek("x", "ab", 2))
+	require.Equal(t, []string{"xb", "xba"}, seek("xb", "", 10))
+	require.Equal(t, []string(nil), seek("x", "d", 10))
+	require.Equal(t, []string{"xaa", "xab", "xb"}, seek("", "x", 3))
+	require.NoError(t, s.Close())
+}
+
 func testStoreDeleteNonExistent(t *testing.T, s Store) {
 	key := []byte("sparse")
 
@@ -227,7 +249,7 @@ func TestAllDBs(t *testing.T) {
 		{"BadgerDB", newBadgerDBForTesting},
 	}
 	var tests = []dbTestFunction{testStoreClose, testStorePutAndGet,
-		testStoreGetNonExistent, testStorePutBatch, testStoreSeek,
+		testStoreGetNonExistent, testStorePutBatch, testStoreSeek, testStoreSeekFrom,
 		testStoreDeleteNonExistent, testStorePutAndDelete,
 		testStorePutBatchWithDelete}
 	for _, db := range DBs {
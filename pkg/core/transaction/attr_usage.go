@@ -10,6 +10,7 @@ const (
 	ContractHash   AttrUsage = 0x00
 	ECDH02         AttrUsage = 0x02
 	ECDH03         AttrUsage = 0x03
+	OracleResponse AttrUsage = 0x11
 	Vote           AttrUsage = 0x30
 	CertURL        AttrUsage = 0x80
 	DescriptionURL AttrUsage = 0x81
@@ -46,6 +46,12 @@ func (attr *Attribute) DecodeBinary(br *io.BinReader) {
 		Remark5, Remark6, Remark7, Remark8, Remark9, Remark10, Remark11,
 		Remark12, Remark13, Remark14, Remark15:
 		datasize = br.ReadVarUint()
+	case OracleResponse:
+		datasize = br.ReadVarUint()
+		if datasize > maxOracleResponseAttrSize {
+			br.Err = fmt.Errorf("oracle response attribute is too big: %d", datasize)
+			return
+		}
 	default:
 		br.Err = fmt.Errorf("failed decoding TX attribute usage: 0x%2x", int(attr.Usage))
 		return
@@ -62,7 +68,7 @@ func (attr *Attribute) EncodeBinary(bw *io.BinWriter) {
 		bw.WriteBytes(attr.Data[1:])
 	case Description, Remark, Remark1, Remark2, Remark3, Remark4,
 		Remark5, Remark6, Remark7, Remark8, Remark9, Remark10, Remark11,
-		Remark12, Remark13, Remark14, Remark15:
+		Remark12, Remark13, Remark14, Remark15, OracleResponse:
 		bw.WriteVarBytes(attr.Data)
 	case DescriptionURL:
 		bw.WriteB(byte(len(attr.Data)))
@@ -101,6 +107,8 @@ func (attr *Attribute) UnmarshalJSON(data []byte) error {
 		attr.Usage = ECDH02
 	case "ECDH03":
 		attr.Usage = ECDH03
+	case "OracleResponse":
+		attr.Usage = OracleResponse
 	case "Vote":
 		attr.Usage = Vote
 	case "CertURL":
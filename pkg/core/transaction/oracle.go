@@ -0,0 +1,100 @@
+package transaction
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// OracleResponseCode represents result code of oracle response.
+type OracleResponseCode byte
+
+// OracleResponseData represents oracle response for the request with the given
+// ID. It's stored in the Data of the OracleResponse transaction attribute.
+type OracleResponseData struct {
+	ID     uint64             `json:"id"`
+	Code   OracleResponseCode `json:"code"`
+	Result []byte             `json:"result"`
+}
+
+// MaxOracleResultSize is the maximum allowed oracle answer size.
+const MaxOracleResultSize = 0xFFFF
+
+// maxOracleResponseAttrSize is the maximum size of serialized OracleResponseData.
+const maxOracleResponseAttrSize = 8 + 1 + 3 + MaxOracleResultSize
+
+// Enumeration of possible oracle response codes.
+const (
+	Success              OracleResponseCode = 0x00
+	ProtocolNotSupported OracleResponseCode = 0x10
+	ConsensusUnreachable OracleResponseCode = 0x12
+	NotFound             OracleResponseCode = 0x14
+	Timeout              OracleResponseCode = 0x16
+	Forbidden            OracleResponseCode = 0x18
+	ResponseTooLarge     OracleResponseCode = 0x1a
+	InsufficientFunds    OracleResponseCode = 0x1c
+	Error                OracleResponseCode = 0xff
+)
+
+// IsValid checks if c is valid response code.
+func (c OracleResponseCode) IsValid() bool {
+	return c == Success || c == ProtocolNotSupported || c == ConsensusUnreachable || c == NotFound ||
+		c == Timeout || c == Forbidden || c == ResponseTooLarge || c == InsufficientFunds || c == Error
+}
+
+// DecodeBinary implements io.Serializable interface.
+func (r *OracleResponseData) DecodeBinary(br *io.BinReader) {
+	r.ID = br.ReadU64LE()
+	r.Code = OracleResponseCode(br.ReadB())
+	if !r.Code.IsValid() {
+		br.Err = errors.New("invalid response code")
+		return
+	}
+	r.Result = br.ReadVarBytes()
+	if br.Err != nil {
+		return
+	}
+	if len(r.Result) > MaxOracleResultSize {
+		br.Err = errors.New("result is too big")
+		return
+	}
+	if r.Code != Success && len(r.Result) > 0 {
+		br.Err = errors.New("result is not empty")
+	}
+}
+
+// EncodeBinary implements io.Serializable interface.
+func (r *OracleResponseData) EncodeBinary(w *io.BinWriter) {
+	w.WriteU64LE(r.ID)
+	w.WriteB(byte(r.Code))
+	w.WriteVarBytes(r.Result)
+}
+
+// ToAttribute returns OracleResponse transaction attribute containing r.
+func (r *OracleResponseData) ToAttribute() Attribute {
+	w := io.NewBufBinWriter()
+	r.EncodeBinary(w.BinWriter)
+	return Attribute{
+		Usage: OracleResponse,
+		Data:  w.Bytes(),
+	}
+}
+
+// GetOracleResponse returns OracleResponseData stored in the transaction
+// attributes. It returns nil if there is no such attribute or it can't be
+// decoded.
+func (t *Transaction) GetOracleResponse() *OracleResponseData {
+	for i := range t.Attributes {
+		if t.Attributes[i].Usage != OracleResponse {
+			continue
+		}
+		resp := new(OracleResponseData)
+		r := io.NewBinReaderFromBuf(t.Attributes[i].Data)
+		resp.DecodeBinary(r)
+		if r.Err != nil {
+			return nil
+		}
+		return resp
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/internal/testserdes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOracleResponseEncodeDecode(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		expected := &OracleResponseData{ID: 123, Code: Success, Result: []byte{1, 2, 3}}
+		testserdes.EncodeDecodeBinary(t, expected, new(OracleResponseData))
+	})
+	t.Run("Error", func(t *testing.T) {
+		expected := &OracleResponseData{ID: 123, Code: NotFound, Result: []byte{}}
+		testserdes.EncodeDecodeBinary(t, expected, new(OracleResponseData))
+	})
+	t.Run("InvalidCode", func(t *testing.T) {
+		data, err := testserdes.EncodeBinary(&OracleResponseData{ID: 1, Code: 0x42})
+		require.NoError(t, err)
+		require.Error(t, testserdes.DecodeBinary(data, new(OracleResponseData)))
+	})
+	t.Run("ResultWithError", func(t *testing.T) {
+		data, err := testserdes.EncodeBinary(&OracleResponseData{ID: 1, Code: Error, Result: []byte{1}})
+		require.NoError(t, err)
+		require.Error(t, testserdes.DecodeBinary(data, new(OracleResponseData)))
+	})
+	t.Run("TooBigResult", func(t *testing.T) {
+		data, err := testserdes.EncodeBinary(&OracleResponseData{ID: 1, Code: Success, Result: make([]byte, MaxOracleResultSize+1)})
+		require.NoError(t, err)
+		require.Error(t, testserdes.DecodeBinary(data, new(OracleResponseData)))
+	})
+}
+
+func TestGetOracleResponse(t *testing.T) {
+	tx := New([]byte{1}, 0)
+	require.Nil(t, tx.GetOracleResponse())
+
+	resp := &OracleResponseData{ID: 42, Code: Success, Result: []byte("result")}
+	tx.Attributes = append(tx.Attributes, Attribute{Usage: Remark, Data: []byte{1}}, resp.ToAttribute())
+	require.Equal(t, resp, tx.GetOracleResponse())
+
+	testserdes.EncodeDecodeBinary(t, &tx.Attributes[1], new(Attribute))
+	testserdes.MarshalUnmarshalJSON(t, &tx.Attributes[1], new(Attribute))
+}
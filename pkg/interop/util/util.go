@@ -17,3 +17,27 @@ func FromAddress(address string) []byte {
 func Equals(a, b interface{}) bool {
 	return false
 }
+
+// Pow returns a^b. It's implemented as a POW VM opcode, b must be
+// non-negative and not greater than 256.
+func Pow(a, b int) int {
+	return 0
+}
+
+// Sqrt returns integer square root of a rounded down. It's implemented as
+// a SQRT VM opcode, a must be non-negative.
+func Sqrt(a int) int {
+	return 0
+}
+
+// ModMul returns a*b % mod. It's implemented as a MODMUL VM opcode, result
+// has the same sign as a*b (like the Go's % operator does).
+func ModMul(a, b, mod int) int {
+	return 0
+}
+
+// ModPow returns a^b % mod. It's implemented as a MODPOW VM opcode, if b is -1
+// it returns modular inverse of a.
+func ModPow(a, b, mod int) int {
+	return 0
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
@@ -76,12 +77,30 @@ func (chain testChain) GetHeader(hash util.Uint256) (*block.Header, error) {
 func (chain testChain) GetAccountState(util.Uint160) *state.Account {
 	panic("TODO")
 }
+func (chain testChain) GetAccountTransactions(util.Uint160, int, int) ([]state.AccountTransaction, bool, error) {
+	panic("TODO")
+}
 func (chain testChain) GetNEP5TransferLog(util.Uint160) *state.NEP5TransferLog {
 	panic("TODO")
 }
+func (chain testChain) GetContractNotifications(util.Uint160, string, uint32, uint32, int, int) ([]state.ContractNotification, bool, error) {
+	panic("TODO")
+}
+func (chain testChain) ForEachNEP5Transfer(util.Uint160, func(*state.NEP5Transfer) (bool, error)) error {
+	panic("TODO")
+}
 func (chain testChain) GetNEP5Balances(util.Uint160) *state.NEP5Balances {
 	panic("TODO")
 }
+func (chain testChain) GetOracleNodes() (keys.PublicKeys, error) {
+	panic("TODO")
+}
+func (chain testChain) GetOracleRequests() (map[uint64]*state.OracleRequest, error) {
+	panic("TODO")
+}
+func (chain testChain) GetOracleResponseScript() []byte {
+	panic("TODO")
+}
 func (chain testChain) GetValidators() ([]*keys.PublicKey, error) {
 	panic("TODO")
 }
@@ -94,12 +113,36 @@ func (chain testChain) GetEnrollments() ([]state.Validator, error) {
 func (chain testChain) GetScriptHashesForVerifying(*transaction.Transaction) ([]util.Uint160, error) {
 	panic("TODO")
 }
+func (chain testChain) GetStateProof(util.Uint256, []byte) ([][]byte, error) {
+	panic("TODO")
+}
+func (chain testChain) GetStateRoot(height uint32) (*state.MPTRoot, error) {
+	panic("TODO")
+}
 func (chain testChain) GetStorageItem(scripthash util.Uint160, key []byte) *state.StorageItem {
 	panic("TODO")
 }
+func (chain testChain) GetStorageItemAt(height uint32, scripthash util.Uint160, key []byte) (*state.StorageItem, error) {
+	panic("TODO")
+}
 func (chain testChain) GetTestVM() *vm.VM {
 	panic("TODO")
 }
+func (chain testChain) GetTestVMAt(height uint32) (*vm.VM, error) {
+	panic("TODO")
+}
+func (chain testChain) CalculateTxNetworkFee(*transaction.Transaction) (util.Fixed8, error) {
+	panic("TODO")
+}
+func (chain testChain) TestInvoke([]byte, *transaction.Transaction, util.Fixed8) *state.TestExecResult {
+	panic("TODO")
+}
+func (chain testChain) TestInvokeAt(uint32, []byte, *transaction.Transaction, util.Fixed8) (*state.TestExecResult, error) {
+	panic("TODO")
+}
+func (chain testChain) TraceTransaction(util.Uint256, vm.Tracer) (*state.AppExecResult, error) {
+	panic("TODO")
+}
 func (chain testChain) GetStorageItems(hash util.Uint160) (map[string]*state.StorageItem, error) {
 	panic("TODO")
 }
@@ -135,16 +178,30 @@ func (chain testChain) GetUtilityTokenBalance(uint160 util.Uint160) util.Fixed8
 	panic("TODO")
 }
 
+func (chain testChain) UtilityTokenHash() util.Uint160 {
+	panic("TODO")
+}
+
 func (chain testChain) PoolTx(*transaction.Transaction) error {
 	panic("TODO")
 }
 
+func (chain testChain) RemovesUntraceableBlocks() bool {
+	return false
+}
+
+func (chain testChain) SeekStorageItems(util.Uint160, []byte, []byte, func([]byte, *state.StorageItem) bool) error {
+	panic("TODO")
+}
 func (chain testChain) SubscribeForBlocks(ch chan<- *block.Block) {
 	panic("TODO")
 }
 func (chain testChain) SubscribeForExecutions(ch chan<- *state.AppExecResult) {
 	panic("TODO")
 }
+func (chain testChain) SubscribeForHeaders(ch chan<- *block.Header) {
+	panic("TODO")
+}
 func (chain testChain) SubscribeForNotifications(ch chan<- *state.NotificationEvent) {
 	panic("TODO")
 }
@@ -156,12 +213,19 @@ func (chain testChain) VerifyTx(*transaction.Transaction, *block.Block) error {
 	panic("TODO")
 }
 
+func (chain testChain) VerifyWitness(util.Uint160, crypto.Verifiable, *transaction.Witness) error {
+	panic("TODO")
+}
+
 func (chain testChain) UnsubscribeFromBlocks(ch chan<- *block.Block) {
 	panic("TODO")
 }
 func (chain testChain) UnsubscribeFromExecutions(ch chan<- *state.AppExecResult) {
 	panic("TODO")
 }
+func (chain testChain) UnsubscribeFromHeaders(ch chan<- *block.Header) {
+	panic("TODO")
+}
 func (chain testChain) UnsubscribeFromNotifications(ch chan<- *state.NotificationEvent) {
 	panic("TODO")
 }
@@ -81,6 +81,9 @@ const (
 
 	// others
 	CMDAlert CommandType = 0x40
+
+	// P2P notary
+	CMDP2PNotaryRequest = CommandType(payload.P2PNotaryRequestType)
 )
 
 // NewMessage returns a new message with the given payload.
@@ -152,6 +155,8 @@ func (m *Message) decodePayload() error {
 		p = &payload.MerkleBlock{}
 	case CMDPing, CMDPong:
 		p = &payload.Ping{}
+	case CMDP2PNotaryRequest:
+		p = &payload.P2PNotaryRequest{}
 	default:
 		return fmt.Errorf("can't decode command %s", m.Command.String())
 	}
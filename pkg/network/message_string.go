@@ -31,6 +31,7 @@ func _() {
 	_ = x[CMDFilterClear-50]
 	_ = x[CMDMerkleBlock-56]
 	_ = x[CMDAlert-64]
+	_ = x[CMDP2PNotaryRequest-80]
 }
 
 const (
@@ -43,6 +44,7 @@ const (
 	_CommandType_name_6 = "CMDRejectCMDFilterLoadCMDFilterAddCMDFilterClear"
 	_CommandType_name_7 = "CMDMerkleBlock"
 	_CommandType_name_8 = "CMDAlert"
+	_CommandType_name_9 = "CMDP2PNotaryRequest"
 )
 
 var (
@@ -81,6 +83,8 @@ func (i CommandType) String() string {
 		return _CommandType_name_7
 	case i == 64:
 		return _CommandType_name_8
+	case i == 80:
+		return _CommandType_name_9
 	default:
 		return "CommandType(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
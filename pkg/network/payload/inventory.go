@@ -20,6 +20,8 @@ func (i InventoryType) String() string {
 		return "block"
 	case ConsensusType:
 		return "consensus"
+	case P2PNotaryRequestType:
+		return "p2pNotaryRequest"
 	default:
 		return "unknown inventory type"
 	}
@@ -27,7 +29,7 @@ func (i InventoryType) String() string {
 
 // Valid returns true if the inventory (type) is known.
 func (i InventoryType) Valid() bool {
-	return i == BlockType || i == TXType || i == ConsensusType
+	return i == BlockType || i == TXType || i == ConsensusType || i == P2PNotaryRequestType
 }
 
 // List of valid InventoryTypes.
@@ -35,6 +37,8 @@ const (
 	TXType        InventoryType = 0x2b
 	BlockType     InventoryType = 0x2c
 	ConsensusType InventoryType = 0x2d
+
+	P2PNotaryRequestType InventoryType = 0x50
 )
 
 // Inventory payload.
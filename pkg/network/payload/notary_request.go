@@ -0,0 +1,65 @@
+package payload
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// P2PNotaryRequest contains main transaction which is not yet fully signed
+// and a fallback transaction which is to be sent in case main transaction
+// can't be completed before its ValidUntilBlock. Every request is signed by
+// the sender of the fallback transaction.
+type P2PNotaryRequest struct {
+	// MainTransaction is a transaction with a part of signatures.
+	MainTransaction *transaction.Transaction
+	// FallbackTransaction is a fully signed transaction sent instead of
+	// MainTransaction if it isn't completed in time.
+	FallbackTransaction *transaction.Transaction
+	// Witness is a witness of the fallback transaction sender.
+	Witness transaction.Witness
+}
+
+// errNilTransaction is returned when one of request transactions is missing.
+var errNilTransaction = errors.New("nil transaction")
+
+// GetSignedPart implements crypto.Verifiable interface. Request is signed
+// by hashes of both transactions.
+func (r *P2PNotaryRequest) GetSignedPart() []byte {
+	buf := io.NewBufBinWriter()
+	r.encodeHashableFields(buf.BinWriter)
+	if buf.Err != nil {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+func (r *P2PNotaryRequest) encodeHashableFields(bw *io.BinWriter) {
+	if r.MainTransaction == nil || r.FallbackTransaction == nil {
+		bw.Err = errNilTransaction
+		return
+	}
+	bw.WriteBytes(r.MainTransaction.Hash().BytesBE())
+	bw.WriteBytes(r.FallbackTransaction.Hash().BytesBE())
+}
+
+// DecodeBinary implements Serializable interface.
+func (r *P2PNotaryRequest) DecodeBinary(br *io.BinReader) {
+	r.MainTransaction = new(transaction.Transaction)
+	r.MainTransaction.DecodeBinary(br)
+	r.FallbackTransaction = new(transaction.Transaction)
+	r.FallbackTransaction.DecodeBinary(br)
+	r.Witness.DecodeBinary(br)
+}
+
+// EncodeBinary implements Serializable interface.
+func (r *P2PNotaryRequest) EncodeBinary(bw *io.BinWriter) {
+	if r.MainTransaction == nil || r.FallbackTransaction == nil {
+		bw.Err = errNilTransaction
+		return
+	}
+	r.MainTransaction.EncodeBinary(bw)
+	r.FallbackTransaction.EncodeBinary(bw)
+	r.Witness.EncodeBinary(bw)
+}
@@ -0,0 +1,54 @@
+package payload
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func newNotaryTestTx(script []byte) *transaction.Transaction {
+	tx := transaction.New(script, 0)
+	tx.Sender = util.Uint160{1, 2, 3}
+	tx.Scripts = []transaction.Witness{{
+		InvocationScript:   []byte{byte(opcode.PUSH1)},
+		VerificationScript: []byte{byte(opcode.PUSH2)},
+	}}
+	tx.Hash()
+	return tx
+}
+
+func TestP2PNotaryRequest_EncodeDecodeBinary(t *testing.T) {
+	r := &P2PNotaryRequest{
+		MainTransaction:     newNotaryTestTx([]byte{byte(opcode.PUSH1)}),
+		FallbackTransaction: newNotaryTestTx([]byte{byte(opcode.PUSH2)}),
+		Witness: transaction.Witness{
+			InvocationScript:   []byte{1, 2, 3},
+			VerificationScript: []byte{4, 5, 6},
+		},
+	}
+	testserdes.EncodeDecodeBinary(t, r, new(P2PNotaryRequest))
+
+	t.Run("NilTransaction", func(t *testing.T) {
+		_, err := testserdes.EncodeBinary(&P2PNotaryRequest{MainTransaction: r.MainTransaction})
+		require.Error(t, err)
+	})
+}
+
+func TestP2PNotaryRequest_GetSignedPart(t *testing.T) {
+	r := &P2PNotaryRequest{
+		MainTransaction:     newNotaryTestTx([]byte{byte(opcode.PUSH1)}),
+		FallbackTransaction: newNotaryTestTx([]byte{byte(opcode.PUSH2)}),
+	}
+	w := io.NewBufBinWriter()
+	w.WriteBytes(r.MainTransaction.Hash().BytesBE())
+	w.WriteBytes(r.FallbackTransaction.Hash().BytesBE())
+	require.Equal(t, w.Bytes(), r.GetSignedPart())
+
+	r.FallbackTransaction = nil
+	require.Nil(t, r.GetSignedPart())
+}
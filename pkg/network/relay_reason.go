@@ -12,4 +12,10 @@ const (
 	RelayInvalid
 	RelayPolicyFail
 	RelayUnknown
+	// RelayConflict is returned when transaction conflicts with the
+	// memory pool (its sender can't pay for all of its transactions).
+	RelayConflict
+	// RelayDuplicate is returned when transaction is already in the memory
+	// pool (see mempool.ErrDup).
+	RelayDuplicate
 )
@@ -14,9 +14,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/network/capability"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/notary"
+	"github.com/nspcc-dev/neo-go/pkg/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
@@ -30,6 +33,7 @@ const (
 	maxBlockBatch           = 200
 	maxAddrsToSend          = 200
 	minPoolCount            = 30
+	notaryRequestPoolSize   = 1000
 )
 
 var (
@@ -58,6 +62,12 @@ type (
 		chain     blockchainer.Blockchainer
 		bQueue    *blockQueue
 		consensus consensus.Service
+		oracle    *oracle.Oracle
+		notary    *notary.Notary
+
+		// notaryRequestPool contains P2P notary requests indexed by the
+		// hashes of their fallback transactions.
+		notaryRequestPool *mempool.Pool
 
 		lock  sync.RWMutex
 		peers map[Peer]bool
@@ -91,17 +101,19 @@ func NewServer(config ServerConfig, chain blockchainer.Blockchainer, log *zap.Lo
 		return nil, errors.New("logger is a required parameter")
 	}
 
+	notaryPool := mempool.NewMemPool(notaryRequestPoolSize)
 	s := &Server{
-		ServerConfig:     config,
-		chain:            chain,
-		id:               randomID(),
-		quit:             make(chan struct{}),
-		register:         make(chan Peer),
-		unregister:       make(chan peerDrop),
-		peers:            make(map[Peer]bool),
-		consensusStarted: atomic.NewBool(false),
-		log:              log,
-		transactions:     make(chan *transaction.Transaction, 64),
+		ServerConfig:      config,
+		notaryRequestPool: &notaryPool,
+		chain:             chain,
+		id:                randomID(),
+		quit:              make(chan struct{}),
+		register:          make(chan Peer),
+		unregister:        make(chan peerDrop),
+		peers:             make(map[Peer]bool),
+		consensusStarted:  atomic.NewBool(false),
+		log:               log,
+		transactions:      make(chan *transaction.Transaction, 64),
 	}
 	s.bQueue = newBlockQueue(maxBlockBatch, chain, log, func(b *block.Block) {
 		if !s.consensusStarted.Load() {
@@ -124,6 +136,44 @@ func NewServer(config ServerConfig, chain blockchainer.Blockchainer, log *zap.Lo
 
 	s.consensus = srv
 
+	if config.Oracle.Enabled {
+		orc, err := oracle.NewOracle(oracle.Config{
+			Log:     log,
+			Chain:   chain,
+			Fetcher: oracle.NewHTTPFetcher(config.Oracle.RequestTimeout, config.Oracle.AllowPrivateHost),
+			Wallet:  config.Oracle.UnlockWallet,
+			OnTransaction: func(tx *transaction.Transaction) {
+				if r := s.RelayTxn(tx); r != RelaySucceed {
+					log.Warn("can't relay oracle response",
+						zap.Stringer("hash", tx.Hash()),
+						zap.Uint8("reason", uint8(r)))
+				}
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.oracle = orc
+	}
+
+	if config.Notary.Enabled {
+		n, err := notary.NewNotary(notary.Config{
+			Log:   log,
+			Chain: chain,
+			OnTransaction: func(tx *transaction.Transaction) {
+				if r := s.RelayTxn(tx); r != RelaySucceed {
+					log.Warn("can't relay notary transaction",
+						zap.Stringer("hash", tx.Hash()),
+						zap.Uint8("reason", uint8(r)))
+				}
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		s.notary = n
+	}
+
 	if s.MinPeers < 0 {
 		s.log.Info("bad MinPeers configured, using the default value",
 			zap.Int("configured", s.MinPeers),
@@ -169,6 +219,12 @@ func (s *Server) Start(errChan chan error) {
 
 	s.discovery.BackFill(s.Seeds...)
 
+	if s.oracle != nil {
+		go s.oracle.Run()
+	}
+	if s.notary != nil {
+		go s.notary.Run()
+	}
 	go s.broadcastTxLoop()
 	go s.relayBlocksLoop()
 	go s.bQueue.run()
@@ -186,6 +242,12 @@ func (s *Server) Shutdown() {
 		p.Disconnect(errServerShutdown)
 	}
 	s.bQueue.discard()
+	if s.oracle != nil {
+		s.oracle.Shutdown()
+	}
+	if s.notary != nil {
+		s.notary.Shutdown()
+	}
 	close(s.quit)
 }
 
@@ -497,6 +559,7 @@ func (s *Server) handleInvCmd(p Peer, inv *payload.Inventory) error {
 			cp := s.consensus.GetPayload(h)
 			return cp != nil
 		},
+		payload.P2PNotaryRequestType: s.notaryRequestPool.ContainsKey,
 	}
 	if exists := typExists[inv.Type]; exists != nil {
 		for _, hash := range inv.Hashes {
@@ -539,6 +602,10 @@ func (s *Server) handleGetDataCmd(p Peer, inv *payload.Inventory) error {
 			if cp := s.consensus.GetPayload(hash); cp != nil {
 				msg = NewMessage(CMDConsensus, cp)
 			}
+		case payload.P2PNotaryRequestType:
+			if r, ok := s.notaryRequestPool.TryGetData(hash); ok {
+				msg = NewMessage(CMDP2PNotaryRequest, r.(*payload.P2PNotaryRequest))
+			}
 		}
 		if msg != nil {
 			pkt, err := msg.Bytes()
@@ -639,13 +706,22 @@ func (s *Server) handleConsensusCmd(cp *consensus.Payload) error {
 func (s *Server) handleTxCmd(tx *transaction.Transaction) error {
 	// It's OK for it to fail for various reasons like tx already existing
 	// in the pool.
-	if s.verifyAndPoolTX(tx) == RelaySucceed {
+	if r, _ := s.verifyAndPoolTX(tx); r == RelaySucceed {
 		s.consensus.OnTransaction(tx)
 		s.broadcastTX(tx)
 	}
 	return nil
 }
 
+// handleP2PNotaryRequestCmd processes received P2P notary request.
+// It never returns an error.
+func (s *Server) handleP2PNotaryRequestCmd(r *payload.P2PNotaryRequest) error {
+	// It's OK for it to fail for various reasons like request already
+	// existing in the pool.
+	_ = s.RelayP2PNotaryRequest(r)
+	return nil
+}
+
 // handleAddrCmd will process received addresses.
 func (s *Server) handleAddrCmd(p Peer, addrs *payload.AddressList) error {
 	for _, a := range addrs.Addrs {
@@ -749,6 +825,9 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 		case CMDTX:
 			tx := msg.Payload.(*transaction.Transaction)
 			return s.handleTxCmd(tx)
+		case CMDP2PNotaryRequest:
+			r := msg.Payload.(*payload.P2PNotaryRequest)
+			return s.handleP2PNotaryRequestCmd(r)
 		case CMDPing:
 			ping := msg.Payload.(*payload.Ping)
 			return s.handlePing(peer, ping)
@@ -841,37 +920,130 @@ func (s *Server) relayBlocksLoop() {
 			s.iteratePeersWithSendMsg(msg, Peer.EnqueuePacket, func(p Peer) bool {
 				return p.Handshaked() && p.LastBlockIndex() < b.Index
 			})
+			s.removeStaleNotaryRequests()
 		}
 	}
 }
 
-// verifyAndPoolTX verifies the TX and adds it to the local mempool.
-func (s *Server) verifyAndPoolTX(t *transaction.Transaction) RelayReason {
+// verifyAndPoolTX verifies the TX and adds it to the local mempool. It also
+// returns the error that has caused the failure if there is any.
+func (s *Server) verifyAndPoolTX(t *transaction.Transaction) (RelayReason, error) {
 	if err := s.chain.PoolTx(t); err != nil {
 		switch err {
 		case core.ErrAlreadyExists:
-			return RelayAlreadyExists
+			return RelayAlreadyExists, err
 		case core.ErrOOM:
-			return RelayOutOfMemory
+			return RelayOutOfMemory, err
 		case core.ErrPolicy:
-			return RelayPolicyFail
+			return RelayPolicyFail, err
+		case mempool.ErrConflict:
+			return RelayConflict, err
+		case mempool.ErrDup:
+			return RelayDuplicate, err
 		default:
-			return RelayInvalid
+			return RelayInvalid, err
 		}
 	}
-	return RelaySucceed
+	return RelaySucceed, nil
 }
 
 // RelayTxn a new transaction to the local node and the connected peers.
 // Reference: the method OnRelay in C#: https://github.com/neo-project/neo/blob/master/neo/Network/P2P/LocalNode.cs#L159
 func (s *Server) RelayTxn(t *transaction.Transaction) RelayReason {
-	ret := s.verifyAndPoolTX(t)
+	ret, _ := s.RelayTxnWithError(t)
+	return ret
+}
+
+// RelayTxnWithError is the same as RelayTxn, but it also returns the error
+// that has caused the failure (nil for RelaySucceed).
+func (s *Server) RelayTxnWithError(t *transaction.Transaction) (RelayReason, error) {
+	ret, err := s.verifyAndPoolTX(t)
 	if ret == RelaySucceed {
 		s.broadcastTX(t)
 	}
+	return ret, err
+}
+
+// verifyNotaryRequest checks that P2P notary request is correctly signed by
+// the fallback transaction sender and that fallback transaction is valid.
+func (s *Server) verifyNotaryRequest(r *payload.P2PNotaryRequest) error {
+	main, fb := r.MainTransaction, r.FallbackTransaction
+	if main.ValidUntilBlock <= s.chain.BlockHeight() {
+		return errors.New("main transaction has expired")
+	}
+	if fb.ValidUntilBlock <= main.ValidUntilBlock {
+		return errors.New("fallback transaction expires before the main one")
+	}
+	if err := s.chain.VerifyWitness(fb.Sender, r, &r.Witness); err != nil {
+		return fmt.Errorf("bad request witness: %v", err)
+	}
+	if err := s.chain.VerifyTx(fb, nil); err != nil {
+		return fmt.Errorf("bad fallback transaction: %v", err)
+	}
+	return nil
+}
+
+// verifyAndPoolNotaryRequest verifies P2P notary request and adds it to the
+// notary request pool.
+func (s *Server) verifyAndPoolNotaryRequest(r *payload.P2PNotaryRequest) RelayReason {
+	if s.chain.HasTransaction(r.MainTransaction.Hash()) || s.chain.HasTransaction(r.FallbackTransaction.Hash()) {
+		return RelayAlreadyExists
+	}
+	if err := s.verifyNotaryRequest(r); err != nil {
+		s.log.Debug("invalid P2P notary request",
+			zap.Stringer("fallback", r.FallbackTransaction.Hash()),
+			zap.Error(err))
+		return RelayInvalid
+	}
+	if err := s.notaryRequestPool.Add(r.FallbackTransaction, s.chain, r); err != nil {
+		switch err {
+		case mempool.ErrDup:
+			return RelayAlreadyExists
+		case mempool.ErrOOM:
+			return RelayOutOfMemory
+		default:
+			return RelayInvalid
+		}
+	}
+	return RelaySucceed
+}
+
+// RelayP2PNotaryRequest adds P2P notary request to the local pool, passes it
+// to the notary service (if enabled) and announces it to the connected peers.
+func (s *Server) RelayP2PNotaryRequest(r *payload.P2PNotaryRequest) RelayReason {
+	ret := s.verifyAndPoolNotaryRequest(r)
+	if ret == RelaySucceed {
+		if s.notary != nil {
+			s.notary.OnNewRequest(r)
+		}
+		msg := NewMessage(CMDInv, payload.NewInventory(payload.P2PNotaryRequestType,
+			[]util.Uint256{r.FallbackTransaction.Hash()}))
+		s.iteratePeersWithSendMsg(msg, Peer.EnqueuePacket, Peer.IsFullNode)
+	}
 	return ret
 }
 
+// removeStaleNotaryRequests removes P2P notary requests with already accepted
+// or expired transactions from the notary request pool.
+func (s *Server) removeStaleNotaryRequests() {
+	height := s.chain.BlockHeight()
+	stale := make(map[util.Uint256]bool)
+	for _, fb := range s.notaryRequestPool.GetVerifiedTransactions() {
+		h := fb.Hash()
+		data, ok := s.notaryRequestPool.TryGetData(h)
+		if !ok {
+			continue
+		}
+		main := data.(*payload.P2PNotaryRequest).MainTransaction
+		if fb.ValidUntilBlock <= height || s.chain.HasTransaction(h) || s.chain.HasTransaction(main.Hash()) {
+			stale[h] = true
+		}
+	}
+	s.notaryRequestPool.RemoveStale(func(t *transaction.Transaction) bool {
+		return !stale[t.Hash()]
+	}, s.chain)
+}
+
 // broadcastTX broadcasts an inventory message about new transaction.
 func (s *Server) broadcastTX(t *transaction.Transaction) {
 	select {
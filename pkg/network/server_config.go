@@ -66,6 +66,12 @@ type (
 
 		// TimePerBlock is an interval which should pass between two successive blocks.
 		TimePerBlock time.Duration
+
+		// Oracle is an oracle service configuration.
+		Oracle config.OracleConfiguration
+
+		// Notary is a notary service configuration.
+		Notary config.NotaryConfiguration
 	}
 )
 
@@ -80,6 +86,9 @@ func NewServerConfig(cfg config.Config) ServerConfig {
 		wc = &appConfig.UnlockWallet
 	}
 
+	oc := appConfig.Oracle
+	oc.RequestTimeout *= time.Second
+
 	return ServerConfig{
 		UserAgent:         cfg.GenerateUserAgent(),
 		Address:           appConfig.Address,
@@ -96,5 +105,7 @@ func NewServerConfig(cfg config.Config) ServerConfig {
 		MinPeers:          appConfig.MinPeers,
 		Wallet:            wc,
 		TimePerBlock:      time.Duration(protoConfig.SecondsPerBlock) * time.Second,
+		Oracle:            oc,
+		Notary:            appConfig.Notary,
 	}
 }
@@ -0,0 +1,352 @@
+/*
+Package notary implements a service collecting signatures for partially
+signed transactions. Every participant sends a P2P notary request with the
+main transaction signed by its own key and a fallback transaction. Once all
+witnesses of the main transaction can be completed, the notary relays it,
+otherwise fallback transactions are relayed after the main transaction
+expires.
+*/
+package notary
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"go.uber.org/zap"
+)
+
+// signatureLen is the length of the signature in the invocation script.
+const signatureLen = 64
+
+// Notary represents the notary service.
+type Notary struct {
+	Config
+
+	log *zap.Logger
+
+	mtx sync.Mutex
+	// requests maps main transaction hashes to the collected data.
+	requests map[util.Uint256]*request
+
+	blocks chan *block.Block
+	quit   chan struct{}
+}
+
+// Config is a notary service configuration.
+type Config struct {
+	// Log is a logger instance.
+	Log *zap.Logger
+	// Chain is a core.Blockchainer instance.
+	Chain blockchainer.Blockchainer
+	// OnTransaction is a callback which is called for every completed main
+	// transaction and for every fallback transaction that is to be sent.
+	OnTransaction func(*transaction.Transaction)
+}
+
+// request contains main transaction and its witnesses collected from all
+// notary requests with the same main transaction.
+type request struct {
+	main *transaction.Transaction
+	// witnesses contains information about every main transaction witness.
+	witnesses []witnessInfo
+	fallbacks []*transaction.Transaction
+	isSent    bool
+}
+
+// witnessInfo describes a single witness of the main transaction.
+type witnessInfo struct {
+	// nSigs is the number of signatures needed for multisignature witnesses
+	// and 0 for all others.
+	nSigs int
+	pubs  keys.PublicKeys
+	// sigs contains collected signatures indexed by the position of the
+	// corresponding key in pubs.
+	sigs map[int][]byte
+	// invocation is the invocation script of non-multisignature witnesses.
+	invocation []byte
+}
+
+// NewNotary returns new notary service instance.
+func NewNotary(cfg Config) (*Notary, error) {
+	if cfg.Log == nil {
+		return nil, errors.New("empty logger")
+	}
+	if cfg.Chain == nil {
+		return nil, errors.New("empty chain")
+	}
+	if cfg.OnTransaction == nil {
+		cfg.OnTransaction = func(*transaction.Transaction) {}
+	}
+	return &Notary{
+		Config:   cfg,
+		log:      cfg.Log,
+		requests: make(map[util.Uint256]*request),
+		blocks:   make(chan *block.Block, 1),
+		quit:     make(chan struct{}),
+	}, nil
+}
+
+// Run listens for new blocks and processes expired requests. It blocks until
+// Shutdown is called.
+func (n *Notary) Run() {
+	n.Chain.SubscribeForBlocks(n.blocks)
+	defer n.Chain.UnsubscribeFromBlocks(n.blocks)
+
+	for {
+		select {
+		case <-n.blocks:
+			n.PostPersist()
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// Shutdown stops the service.
+func (n *Notary) Shutdown() {
+	close(n.quit)
+}
+
+// OnNewRequest adds signatures from the given notary request and relays main
+// transaction if it is complete. Request is expected to be verified already.
+func (n *Notary) OnNewRequest(r *payload.P2PNotaryRequest) {
+	var toSend *transaction.Transaction
+
+	n.mtx.Lock()
+	h := r.MainTransaction.Hash()
+	req, ok := n.requests[h]
+	if !ok {
+		var err error
+		req, err = newRequest(r.MainTransaction)
+		if err != nil {
+			n.mtx.Unlock()
+			n.log.Debug("invalid notary request",
+				zap.Stringer("hash", h),
+				zap.Error(err))
+			return
+		}
+		n.requests[h] = req
+	}
+	req.addFallback(r.FallbackTransaction)
+	if !req.isSent {
+		if err := req.addWitnesses(n.Chain, r.MainTransaction); err != nil {
+			n.log.Debug("invalid notary request witnesses",
+				zap.Stringer("hash", h),
+				zap.Error(err))
+		} else if req.isComplete() {
+			req.isSent = true
+			toSend = req.finalize()
+		}
+	}
+	n.mtx.Unlock()
+
+	if toSend != nil {
+		n.log.Debug("notary main transaction completed", zap.Stringer("hash", h))
+		n.OnTransaction(toSend)
+	}
+}
+
+// PostPersist removes requests with main transactions accepted to the chain
+// and relays fallback transactions for the expired ones.
+func (n *Notary) PostPersist() {
+	var toSend []*transaction.Transaction
+
+	height := n.Chain.BlockHeight()
+	n.mtx.Lock()
+	for h, req := range n.requests {
+		// Main transaction is persisted, it's not just in the mempool.
+		if _, err := n.Chain.GetAppExecResult(h); err == nil {
+			delete(n.requests, h)
+			continue
+		}
+		if req.main.ValidUntilBlock <= height {
+			for _, fb := range req.fallbacks {
+				if fb.ValidUntilBlock > height {
+					toSend = append(toSend, fb)
+				}
+			}
+			delete(n.requests, h)
+		}
+	}
+	n.mtx.Unlock()
+
+	for _, tx := range toSend {
+		n.log.Debug("sending notary fallback", zap.Stringer("hash", tx.Hash()))
+		n.OnTransaction(tx)
+	}
+}
+
+// newRequest creates new request for the main transaction tx.
+func newRequest(tx *transaction.Transaction) (*request, error) {
+	if len(tx.Scripts) == 0 {
+		return nil, errors.New("no witnesses")
+	}
+	main := *tx
+	main.Scripts = make([]transaction.Witness, len(tx.Scripts))
+	req := &request{
+		main:      &main,
+		witnesses: make([]witnessInfo, len(tx.Scripts)),
+	}
+	for i := range tx.Scripts {
+		main.Scripts[i].VerificationScript = tx.Scripts[i].VerificationScript
+		if nSigs, pubs, ok := vm.ParseMultiSigContract(tx.Scripts[i].VerificationScript); ok {
+			w := &req.witnesses[i]
+			w.nSigs = nSigs
+			w.sigs = make(map[int][]byte)
+			for _, p := range pubs {
+				pub, err := keys.NewPublicKeyFromBytes(p)
+				if err != nil {
+					return nil, err
+				}
+				w.pubs = append(w.pubs, pub)
+			}
+		} else if vm.IsSignatureContract(tx.Scripts[i].VerificationScript) {
+			pub, err := keys.NewPublicKeyFromBytes(tx.Scripts[i].VerificationScript[2:35])
+			if err != nil {
+				return nil, err
+			}
+			req.witnesses[i].pubs = keys.PublicKeys{pub}
+		}
+	}
+	return req, nil
+}
+
+// addFallback adds fallback transaction tx if it is not present yet.
+func (r *request) addFallback(tx *transaction.Transaction) {
+	h := tx.Hash()
+	for _, fb := range r.fallbacks {
+		if fb.Hash().Equals(h) {
+			return
+		}
+	}
+	r.fallbacks = append(r.fallbacks, tx)
+}
+
+// addWitnesses adds valid signatures and invocation scripts from tx witnesses.
+func (r *request) addWitnesses(bc blockchainer.Blockchainer, tx *transaction.Transaction) error {
+	if len(tx.Scripts) != len(r.witnesses) {
+		return errors.New("witness count mismatch")
+	}
+	for i := range tx.Scripts {
+		if !bytes.Equal(tx.Scripts[i].VerificationScript, r.main.Scripts[i].VerificationScript) {
+			return errors.New("verification script mismatch")
+		}
+	}
+
+	h := hash.Sha256(r.main.GetSignedPart()).BytesBE()
+	for i := range tx.Scripts {
+		w := &r.witnesses[i]
+		inv := tx.Scripts[i].InvocationScript
+		switch {
+		case len(inv) == 0:
+		case w.nSigs != 0:
+			for _, sig := range getSignatures(inv) {
+				for j := range w.pubs {
+					if _, ok := w.sigs[j]; !ok && w.pubs[j].Verify(sig, h) {
+						w.sigs[j] = sig
+						break
+					}
+				}
+			}
+		case len(w.pubs) == 1:
+			sigs := getSignatures(inv)
+			if len(sigs) == 1 && w.pubs[0].Verify(sigs[0], h) {
+				w.invocation = inv
+			}
+		case w.invocation == nil:
+			if r.verifyWitness(bc, i, inv) {
+				w.invocation = inv
+			}
+		}
+	}
+	return nil
+}
+
+// verifyWitness checks whether inv is a valid invocation script for the i-th
+// witness of the main transaction. It's used for contract and non-standard
+// witnesses which can't be checked without running the verification script.
+func (r *request) verifyWitness(bc blockchainer.Blockchainer, i int, inv []byte) bool {
+	w := &transaction.Witness{
+		InvocationScript:   inv,
+		VerificationScript: r.main.Scripts[i].VerificationScript,
+	}
+	var hashes []util.Uint160
+	if len(w.VerificationScript) != 0 {
+		hashes = []util.Uint160{w.ScriptHash()}
+	} else {
+		// Deployed contract, it can be any of the signers.
+		var err error
+		hashes, err = bc.GetScriptHashesForVerifying(r.main)
+		if err != nil {
+			return false
+		}
+	}
+	for _, h := range hashes {
+		if bc.VerifyWitness(h, r.main, w) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isComplete checks whether all witnesses of the main transaction can be
+// completed.
+func (r *request) isComplete() bool {
+	for i := range r.witnesses {
+		w := &r.witnesses[i]
+		if w.nSigs != 0 {
+			if len(w.sigs) < w.nSigs {
+				return false
+			}
+		} else if w.invocation == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// finalize fills main transaction invocation scripts and returns it.
+func (r *request) finalize() *transaction.Transaction {
+	for i := range r.witnesses {
+		w := &r.witnesses[i]
+		if w.nSigs == 0 {
+			r.main.Scripts[i].InvocationScript = w.invocation
+			continue
+		}
+		buf := io.NewBufBinWriter()
+		for j, count := 0, 0; j < len(w.pubs) && count < w.nSigs; j++ {
+			if sig, ok := w.sigs[j]; ok {
+				emit.Bytes(buf.BinWriter, sig)
+				count++
+			}
+		}
+		r.main.Scripts[i].InvocationScript = buf.Bytes()
+	}
+	return r.main
+}
+
+// getSignatures returns signatures pushed by the invocation script. Nil is
+// returned if script contains anything else.
+func getSignatures(script []byte) [][]byte {
+	var sigs [][]byte
+	for len(script) > 0 {
+		if len(script) < signatureLen+2 || script[0] != byte(opcode.PUSHDATA1) || script[1] != signatureLen {
+			return nil
+		}
+		sigs = append(sigs, script[2:signatureLen+2])
+		script = script[signatureLen+2:]
+	}
+	return sigs
+}
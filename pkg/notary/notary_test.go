@@ -0,0 +1,246 @@
+package notary
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type testChain struct {
+	blockchainer.Blockchainer
+	height    uint32
+	persisted map[util.Uint256]bool
+	// validInvocation is the only invocation script accepted for
+	// non-standard witnesses.
+	validInvocation []byte
+}
+
+func (c *testChain) BlockHeight() uint32 {
+	return c.height
+}
+
+func (c *testChain) GetAppExecResult(h util.Uint256) (*state.AppExecResult, error) {
+	if c.persisted[h] {
+		return &state.AppExecResult{TxHash: h}, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (c *testChain) VerifyWitness(h util.Uint160, _ crypto.Verifiable, w *transaction.Witness) error {
+	if !h.Equals(w.ScriptHash()) || !bytes.Equal(w.InvocationScript, c.validInvocation) {
+		return errors.New("invalid witness")
+	}
+	return nil
+}
+
+func newTestNotary(t *testing.T, chain *testChain) (*Notary, *[]*transaction.Transaction) {
+	var txs []*transaction.Transaction
+	n, err := NewNotary(Config{
+		Log:           zaptest.NewLogger(t),
+		Chain:         chain,
+		OnTransaction: func(tx *transaction.Transaction) { txs = append(txs, tx) },
+	})
+	require.NoError(t, err)
+	return n, &txs
+}
+
+func getPrivateKeys(t *testing.T, n int) []*keys.PrivateKey {
+	privs := make([]*keys.PrivateKey, n)
+	for i := range privs {
+		var err error
+		privs[i], err = keys.NewPrivateKey()
+		require.NoError(t, err)
+	}
+	return privs
+}
+
+func getInvocation(privs []*keys.PrivateKey, tx *transaction.Transaction) []byte {
+	w := io.NewBufBinWriter()
+	for _, p := range privs {
+		emit.Bytes(w.BinWriter, p.Sign(tx.GetSignedPart()))
+	}
+	return w.Bytes()
+}
+
+// newNotaryRequest returns notary request with main transaction signed by priv.
+func newNotaryRequest(main *transaction.Transaction, priv *keys.PrivateKey, vub uint32) *payload.P2PNotaryRequest {
+	tx := *main
+	tx.Scripts = make([]transaction.Witness, len(main.Scripts))
+	copy(tx.Scripts, main.Scripts)
+	for i := range tx.Scripts {
+		tx.Scripts[i].InvocationScript = nil
+	}
+	tx.Scripts[0].InvocationScript = getInvocation([]*keys.PrivateKey{priv}, main)
+
+	fb := transaction.New([]byte{byte(opcode.RET)}, 0)
+	fb.Sender = priv.GetScriptHash()
+	fb.ValidUntilBlock = vub
+	fb.Scripts = []transaction.Witness{{VerificationScript: priv.PublicKey().GetVerificationScript()}}
+	fb.Scripts[0].InvocationScript = getInvocation([]*keys.PrivateKey{priv}, fb)
+	return &payload.P2PNotaryRequest{
+		MainTransaction:     &tx,
+		FallbackTransaction: fb,
+	}
+}
+
+func TestNotary_MultiSig(t *testing.T) {
+	privs := getPrivateKeys(t, 3)
+	pubs := keys.PublicKeys{privs[0].PublicKey(), privs[1].PublicKey(), privs[2].PublicKey()}
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+
+	main := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	main.Sender = hash.Hash160(script)
+	main.ValidUntilBlock = 10
+	main.Scripts = []transaction.Witness{{VerificationScript: script}}
+
+	chain := &testChain{height: 5, persisted: make(map[util.Uint256]bool)}
+	n, txs := newTestNotary(t, chain)
+
+	r1 := newNotaryRequest(main, privs[2], 20)
+	n.OnNewRequest(r1)
+	require.Equal(t, 0, len(*txs))
+
+	// The same signature doesn't count twice.
+	n.OnNewRequest(r1)
+	require.Equal(t, 0, len(*txs))
+
+	t.Run("InvalidSignature", func(t *testing.T) {
+		r := newNotaryRequest(main, privs[1], 20)
+		r.MainTransaction.Scripts[0].InvocationScript[10]++
+		n.OnNewRequest(r)
+		require.Equal(t, 0, len(*txs))
+	})
+
+	r2 := newNotaryRequest(main, privs[0], 20)
+	n.OnNewRequest(r2)
+	require.Equal(t, 1, len(*txs))
+
+	tx := (*txs)[0]
+	require.Equal(t, main.Hash(), tx.Hash())
+	require.Equal(t, script, tx.Scripts[0].VerificationScript)
+	sigs := getSignatures(tx.Scripts[0].InvocationScript)
+	require.Equal(t, 2, len(sigs))
+
+	// Signatures are ordered by public keys.
+	h := hash.Sha256(main.GetSignedPart()).BytesBE()
+	sorted := keys.PublicKeys{pubs[0], pubs[1], pubs[2]}
+	var signers keys.PublicKeys
+	for _, sig := range sigs {
+		for _, pub := range sorted {
+			if pub.Verify(sig, h) {
+				signers = append(signers, pub)
+			}
+		}
+	}
+	require.Equal(t, 2, len(signers))
+	require.True(t, signers[0].Cmp(signers[1]) < 0)
+
+	t.Run("AlreadySent", func(t *testing.T) {
+		n.OnNewRequest(newNotaryRequest(main, privs[1], 20))
+		require.Equal(t, 1, len(*txs))
+	})
+
+	t.Run("Persisted", func(t *testing.T) {
+		*txs = nil
+		chain.persisted[main.Hash()] = true
+		chain.height = main.ValidUntilBlock
+		n.PostPersist()
+		require.Equal(t, 0, len(*txs))
+		require.Equal(t, 0, len(n.requests))
+	})
+}
+
+func TestNotary_Fallback(t *testing.T) {
+	privs := getPrivateKeys(t, 2)
+	pubs := keys.PublicKeys{privs[0].PublicKey(), privs[1].PublicKey()}
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+
+	main := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	main.Sender = privs[0].GetScriptHash()
+	main.ValidUntilBlock = 10
+	main.Cosigners = []transaction.Cosigner{{Account: hash.Hash160(script)}}
+	main.Scripts = []transaction.Witness{
+		{VerificationScript: script},
+		{VerificationScript: privs[0].PublicKey().GetVerificationScript()},
+	}
+
+	chain := &testChain{height: 5, persisted: make(map[util.Uint256]bool)}
+	n, txs := newTestNotary(t, chain)
+
+	r := newNotaryRequest(main, privs[0], 11)
+	n.OnNewRequest(r)
+	require.Equal(t, 0, len(*txs))
+
+	t.Run("NotExpired", func(t *testing.T) {
+		chain.height = main.ValidUntilBlock - 1
+		n.PostPersist()
+		require.Equal(t, 0, len(*txs))
+	})
+
+	chain.height = main.ValidUntilBlock
+	n.PostPersist()
+	require.Equal(t, 1, len(*txs))
+	require.Equal(t, r.FallbackTransaction, (*txs)[0])
+	require.Equal(t, 0, len(n.requests))
+}
+
+func TestNotary_ContractWitness(t *testing.T) {
+	priv := getPrivateKeys(t, 1)[0]
+	verif := []byte{byte(opcode.PUSH2), byte(opcode.NUMEQUAL)}
+
+	main := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	main.Sender = priv.GetScriptHash()
+	main.ValidUntilBlock = 10
+	main.Cosigners = []transaction.Cosigner{{Account: hash.Hash160(verif)}}
+	main.Scripts = []transaction.Witness{
+		{VerificationScript: priv.PublicKey().GetVerificationScript()},
+		{VerificationScript: verif},
+	}
+
+	chain := &testChain{
+		height:          5,
+		persisted:       make(map[util.Uint256]bool),
+		validInvocation: []byte{byte(opcode.PUSH2)},
+	}
+	n, txs := newTestNotary(t, chain)
+
+	r := newNotaryRequest(main, priv, 20)
+	r.MainTransaction.Scripts[1].InvocationScript = []byte{byte(opcode.PUSH3)}
+	n.OnNewRequest(r)
+	require.Equal(t, 0, len(*txs))
+
+	r = newNotaryRequest(main, priv, 20)
+	r.MainTransaction.Scripts[1].InvocationScript = chain.validInvocation
+	n.OnNewRequest(r)
+	require.Equal(t, 1, len(*txs))
+	require.Equal(t, main.Hash(), (*txs)[0].Hash())
+	require.Equal(t, chain.validInvocation, (*txs)[0].Scripts[1].InvocationScript)
+}
+
+func TestGetSignatures(t *testing.T) {
+	sig := make([]byte, signatureLen)
+	w := io.NewBufBinWriter()
+	emit.Bytes(w.BinWriter, sig)
+	emit.Bytes(w.BinWriter, sig)
+	require.Equal(t, [][]byte{sig, sig}, getSignatures(w.Bytes()))
+
+	emit.Opcode(w.BinWriter, opcode.PUSH1)
+	require.Nil(t, getSignatures(w.Bytes()))
+}
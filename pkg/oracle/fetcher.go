@@ -0,0 +1,164 @@
+package oracle
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+// Fetcher is an interface for getting data requested by oracle requests.
+type Fetcher interface {
+	// Fetch returns data located at url along with the response code.
+	// Data is expected to be empty for all codes except transaction.Success.
+	Fetch(url string) ([]byte, transaction.OracleResponseCode)
+}
+
+// defaultRequestTimeout is the timeout used by HTTP fetcher if none is specified.
+const defaultRequestTimeout = 5 * time.Second
+
+// errRestrictedAddress is returned when the request is made to the private
+// network address.
+var errRestrictedAddress = errors.New("address is restricted")
+
+// privateNets is a list of network ranges not available for oracle requests.
+var privateNets = mustParseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+)
+
+type httpFetcher struct {
+	client http.Client
+}
+
+// NewHTTPFetcher returns Fetcher making HTTP(S) GET requests. If
+// allowPrivateHost is false, requests to loopback and private network
+// addresses are rejected with transaction.Forbidden code.
+func NewHTTPFetcher(timeout time.Duration, allowPrivateHost bool) Fetcher {
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateHost {
+		dialer.Control = restrictPrivateHosts
+	}
+	return &httpFetcher{
+		client: http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy:       http.ProxyFromEnvironment,
+				DialContext: dialer.DialContext,
+			},
+		},
+	}
+}
+
+// Fetch implements Fetcher interface.
+func (f *httpFetcher) Fetch(rawURL string) ([]byte, transaction.OracleResponseCode) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, transaction.Error
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, transaction.ProtocolNotSupported
+	}
+
+	resp, err := f.client.Get(rawURL)
+	if err != nil {
+		return nil, errorToCode(err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, transaction.NotFound
+	case http.StatusForbidden:
+		return nil, transaction.Forbidden
+	case http.StatusRequestTimeout:
+		return nil, transaction.Timeout
+	default:
+		return nil, transaction.Error
+	}
+	if resp.ContentLength > transaction.MaxOracleResultSize {
+		return nil, transaction.ResponseTooLarge
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, transaction.MaxOracleResultSize+1))
+	if err != nil {
+		return nil, errorToCode(err)
+	}
+	if len(data) > transaction.MaxOracleResultSize {
+		return nil, transaction.ResponseTooLarge
+	}
+	return data, transaction.Success
+}
+
+func errorToCode(err error) transaction.OracleResponseCode {
+	if uerr, ok := err.(*url.Error); ok {
+		err = uerr.Err
+	}
+	if oerr, ok := err.(*net.OpError); ok && oerr.Err == errRestrictedAddress {
+		return transaction.Forbidden
+	}
+	if nerr, ok := err.(net.Error); ok && nerr.Timeout() {
+		return transaction.Timeout
+	}
+	return transaction.Error
+}
+
+// restrictPrivateHosts is a net.Dialer control function which denies
+// connections to private network addresses. It's checked after DNS resolution
+// so that the host can't be hidden behind a domain name.
+func restrictPrivateHosts(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return errRestrictedAddress
+	}
+	if isPrivateIP(ip) {
+		return errRestrictedAddress
+	}
+	return nil
+}
+
+func isPrivateIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return true
+	}
+	for _, n := range privateNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i := range cidrs {
+		_, n, err := net.ParseCIDR(cidrs[i])
+		if err != nil {
+			panic(err)
+		}
+		nets[i] = n
+	}
+	return nets
+}
@@ -0,0 +1,74 @@
+package oracle
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			_, _ = w.Write([]byte("data"))
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "/big":
+			_, _ = w.Write(make([]byte, transaction.MaxOracleResultSize+1))
+		case "/slow":
+			time.Sleep(300 * time.Millisecond)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("PrivateHostAllowed", func(t *testing.T) {
+		f := NewHTTPFetcher(100*time.Millisecond, true)
+		testCases := []struct {
+			path string
+			data []byte
+			code transaction.OracleResponseCode
+		}{
+			{"/ok", []byte("data"), transaction.Success},
+			{"/unknown", nil, transaction.NotFound},
+			{"/forbidden", nil, transaction.Forbidden},
+			{"/big", nil, transaction.ResponseTooLarge},
+			{"/slow", nil, transaction.Timeout},
+			{"/error", nil, transaction.Error},
+		}
+		for _, tc := range testCases {
+			data, code := f.Fetch(srv.URL + tc.path)
+			require.Equal(t, tc.code, code, tc.path)
+			require.Equal(t, tc.data, data, tc.path)
+		}
+	})
+	t.Run("PrivateHostForbidden", func(t *testing.T) {
+		f := NewHTTPFetcher(time.Second, false)
+		_, code := f.Fetch(srv.URL + "/ok")
+		require.Equal(t, transaction.Forbidden, code)
+	})
+	t.Run("UnsupportedProtocol", func(t *testing.T) {
+		f := NewHTTPFetcher(time.Second, true)
+		_, code := f.Fetch("ftp://example.com/file")
+		require.Equal(t, transaction.ProtocolNotSupported, code)
+	})
+}
+
+func TestIsPrivateIP(t *testing.T) {
+	private := []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1"}
+	for _, s := range private {
+		require.True(t, isPrivateIP(net.ParseIP(s)), s)
+	}
+	public := []string{"8.8.8.8", "172.32.0.1", "2001:4860:4860::8888"}
+	for _, s := range public {
+		require.False(t, isPrivateIP(net.ParseIP(s)), s)
+	}
+}
@@ -0,0 +1,358 @@
+/*
+Package jsonpath implements a subset of JSONPath used to filter oracle
+responses. Supported elements are:
+
+	$            root object
+	.name        child by name
+	['name',...] children by names
+	.* and [*]   all children
+	..           recursive descent
+	[n,...]      array elements by indices (negative ones count from the end)
+	[start:end]  array slice
+*/
+package jsonpath
+
+import (
+	"sort"
+	"strconv"
+)
+
+// maxObjects is the maximum number of objects that can be selected at any
+// step of path processing.
+const maxObjects = 1024
+
+type pathParser struct {
+	s string
+	i int
+}
+
+// Get returns substructures of value selected by path. value is expected to be
+// decoded by encoding/json into interface{}. The result is always an array,
+// false is returned if path is invalid.
+func Get(path string, value interface{}) ([]interface{}, bool) {
+	if path == "" {
+		return []interface{}{value}, true
+	}
+
+	p := pathParser{s: path}
+	if tok, ok := p.next(); !ok || tok != "$" {
+		return nil, false
+	}
+
+	var ok bool
+	objs := []interface{}{value}
+	for p.i < len(p.s) {
+		tok, _ := p.next()
+		switch tok {
+		case ".":
+			objs, ok = p.processDot(objs)
+		case "..":
+			objs, ok = p.processDescent(objs)
+		case "[":
+			objs, ok = p.processBracket(objs)
+		default:
+			return nil, false
+		}
+		if !ok || len(objs) > maxObjects {
+			return nil, false
+		}
+	}
+	return objs, true
+}
+
+// next returns the next token of the path.
+func (p *pathParser) next() (string, bool) {
+	if p.i >= len(p.s) {
+		return "", false
+	}
+
+	start := p.i
+	switch c := p.s[p.i]; {
+	case c == '$', c == '[', c == ']', c == ',', c == ':', c == '*':
+		p.i++
+	case c == '.':
+		p.i++
+		if p.i < len(p.s) && p.s[p.i] == '.' {
+			p.i++
+		}
+	case c == '\'':
+		p.i++
+		for p.i < len(p.s) && p.s[p.i] != '\'' {
+			p.i++
+		}
+		if p.i == len(p.s) {
+			return "", false
+		}
+		p.i++
+	case c == '-' || isDigit(c):
+		p.i++
+		for p.i < len(p.s) && isDigit(p.s[p.i]) {
+			p.i++
+		}
+	case isIdentChar(c):
+		for p.i < len(p.s) && isIdentChar(p.s[p.i]) {
+			p.i++
+		}
+	default:
+		return "", false
+	}
+	return p.s[start:p.i], true
+}
+
+func (p *pathParser) processDot(objs []interface{}) ([]interface{}, bool) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, false
+	}
+	if tok == "*" {
+		return children(objs), true
+	}
+	if !isIdentChar(tok[0]) {
+		return nil, false
+	}
+	return fields(objs, tok), true
+}
+
+func (p *pathParser) processDescent(objs []interface{}) ([]interface{}, bool) {
+	all := descendants(objs)
+	if len(all) > maxObjects {
+		return nil, false
+	}
+	if p.i < len(p.s) && p.s[p.i] == '[' {
+		p.i++
+		return p.processBracket(all)
+	}
+
+	tok, ok := p.next()
+	if !ok {
+		return nil, false
+	}
+	if tok == "*" {
+		return children(all), true
+	}
+	if !isIdentChar(tok[0]) {
+		return nil, false
+	}
+	return fields(all, tok), true
+}
+
+func (p *pathParser) processBracket(objs []interface{}) ([]interface{}, bool) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, false
+	}
+
+	switch {
+	case tok == "*":
+		if tok, ok := p.next(); !ok || tok != "]" {
+			return nil, false
+		}
+		return children(objs), true
+	case tok[0] == '\'':
+		return p.processNames(objs, tok)
+	case tok == ":":
+		return p.processSlice(objs, 0, false)
+	}
+
+	index, err := strconv.Atoi(tok)
+	if err != nil {
+		return nil, false
+	}
+	tok, ok = p.next()
+	if !ok {
+		return nil, false
+	}
+	switch tok {
+	case ":":
+		return p.processSlice(objs, index, true)
+	case "]", ",":
+		indices := []int{index}
+		for tok == "," {
+			if tok, ok = p.next(); !ok {
+				return nil, false
+			}
+			if index, err = strconv.Atoi(tok); err != nil {
+				return nil, false
+			}
+			indices = append(indices, index)
+			if tok, ok = p.next(); !ok {
+				return nil, false
+			}
+		}
+		if tok != "]" {
+			return nil, false
+		}
+		return elements(objs, indices), true
+	default:
+		return nil, false
+	}
+}
+
+// processNames processes `['name1','name2',...]` expression, first is the
+// first (already parsed) quoted name.
+func (p *pathParser) processNames(objs []interface{}, first string) ([]interface{}, bool) {
+	names := []string{first[1 : len(first)-1]}
+	for {
+		tok, ok := p.next()
+		if !ok {
+			return nil, false
+		}
+		if tok == "]" {
+			break
+		}
+		if tok != "," {
+			return nil, false
+		}
+		tok, ok = p.next()
+		if !ok || tok[0] != '\'' {
+			return nil, false
+		}
+		names = append(names, tok[1:len(tok)-1])
+	}
+
+	var res []interface{}
+	for i := range objs {
+		m, ok := objs[i].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, name := range names {
+			if v, ok := m[name]; ok {
+				res = append(res, v)
+			}
+		}
+	}
+	return res, true
+}
+
+// processSlice processes `[start:end]` expression after the colon.
+func (p *pathParser) processSlice(objs []interface{}, start int, hasStart bool) ([]interface{}, bool) {
+	tok, ok := p.next()
+	if !ok {
+		return nil, false
+	}
+	var end int
+	hasEnd := tok != "]"
+	if hasEnd {
+		var err error
+		if end, err = strconv.Atoi(tok); err != nil {
+			return nil, false
+		}
+		if tok, ok = p.next(); !ok || tok != "]" {
+			return nil, false
+		}
+	}
+
+	var res []interface{}
+	for i := range objs {
+		arr, ok := objs[i].([]interface{})
+		if !ok {
+			continue
+		}
+		s, e := 0, len(arr)
+		if hasStart {
+			s = normalizeIndex(start, len(arr))
+		}
+		if hasEnd {
+			e = normalizeIndex(end, len(arr))
+		}
+		if s < e {
+			res = append(res, arr[s:e]...)
+		}
+	}
+	return res, true
+}
+
+// normalizeIndex converts possibly negative index to the one in [0, n] range.
+func normalizeIndex(i, n int) int {
+	if i < 0 {
+		i += n
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > n {
+		return n
+	}
+	return i
+}
+
+// fields returns values of the name field of all objects in objs.
+func fields(objs []interface{}, name string) []interface{} {
+	var res []interface{}
+	for i := range objs {
+		if m, ok := objs[i].(map[string]interface{}); ok {
+			if v, ok := m[name]; ok {
+				res = append(res, v)
+			}
+		}
+	}
+	return res
+}
+
+// elements returns array elements with the specified indices.
+func elements(objs []interface{}, indices []int) []interface{} {
+	var res []interface{}
+	for i := range objs {
+		arr, ok := objs[i].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, j := range indices {
+			if j < 0 {
+				j += len(arr)
+			}
+			if 0 <= j && j < len(arr) {
+				res = append(res, arr[j])
+			}
+		}
+	}
+	return res
+}
+
+// children returns all direct children of objects in objs. Object fields are
+// ordered by their names.
+func children(objs []interface{}) []interface{} {
+	var res []interface{}
+	for i := range objs {
+		switch v := objs[i].(type) {
+		case []interface{}:
+			res = append(res, v...)
+		case map[string]interface{}:
+			for _, k := range sortedKeys(v) {
+				res = append(res, v[k])
+			}
+		}
+	}
+	return res
+}
+
+// descendants returns objs and all of their children recursively.
+func descendants(objs []interface{}) []interface{} {
+	var res []interface{}
+	for i := range objs {
+		res = append(res, objs[i])
+		res = append(res, descendants(children(objs[i:i+1]))...)
+		if len(res) > maxObjects {
+			break
+		}
+	}
+	return res
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || isDigit(c) || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
@@ -0,0 +1,102 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testJSON = `{
+	"store": {
+		"book": [
+			{"title": "Sayings of the Century", "price": 8.95},
+			{"title": "Sword of Honour", "price": 12.99},
+			{"title": "Moby Dick", "price": 8.99},
+			{"title": "The Lord of the Rings", "price": 22.99}
+		],
+		"bicycle": {"color": "red", "price": 19.95}
+	},
+	"expensive": 10
+}`
+
+func unmarshal(t *testing.T, s string) interface{} {
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestGet(t *testing.T) {
+	value := unmarshal(t, testJSON)
+	testCases := []struct {
+		path   string
+		result string
+	}{
+		{"", "[" + testJSON + "]"},
+		{"$", "[" + testJSON + "]"},
+		{"$.expensive", `[10]`},
+		{"$['expensive']", `[10]`},
+		{"$.store.bicycle.color", `["red"]`},
+		{"$['store']['bicycle']['color','price']", `["red", 19.95]`},
+		{"$.store.book[0].title", `["Sayings of the Century"]`},
+		{"$.store.book[-1].title", `["The Lord of the Rings"]`},
+		{"$.store.book[0,2].price", `[8.95, 8.99]`},
+		{"$.store.book[1:3].price", `[12.99, 8.99]`},
+		{"$.store.book[:2].price", `[8.95, 12.99]`},
+		{"$.store.book[-2:].price", `[8.99, 22.99]`},
+		{"$.store.book[*].price", `[8.95, 12.99, 8.99, 22.99]`},
+		{"$.store.bicycle.*", `["red", 19.95]`},
+		{"$..price", `[19.95, 8.95, 12.99, 8.99, 22.99]`},
+		{"$..book[1].title", `["Sword of Honour"]`},
+		{"$.store.unknown", `[]`},
+		{"$.store.book[10]", `[]`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			actual, ok := Get(tc.path, value)
+			require.True(t, ok)
+			expected := unmarshal(t, tc.result).([]interface{})
+			if len(expected) == 0 {
+				require.Empty(t, actual)
+			} else {
+				require.Equal(t, expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetInvalid(t *testing.T) {
+	value := unmarshal(t, testJSON)
+	paths := []string{
+		"store",
+		"$.",
+		"$store",
+		"$.store[",
+		"$.store['book'",
+		"$.store['book]",
+		"$.store.book[0",
+		"$.store.book[a]",
+		"$.store.book[1:2",
+		"$.store.book[*",
+		"$.store.book[0,]",
+		"$.store.book#",
+	}
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			_, ok := Get(path, value)
+			require.False(t, ok)
+		})
+	}
+}
+
+func TestGetTooManyObjects(t *testing.T) {
+	arr := make([]interface{}, maxObjects+1)
+	for i := range arr {
+		arr[i] = float64(i)
+	}
+	_, ok := Get("$[*]", arr)
+	require.False(t, ok)
+
+	_, ok = Get("$..*", arr)
+	require.False(t, ok)
+}
@@ -0,0 +1,269 @@
+package oracle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/oracle/jsonpath"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"go.uber.org/zap"
+)
+
+// responseValidIncrement is the number of blocks response transaction is
+// valid for. Request is processed again if the response wasn't accepted
+// during this period.
+const responseValidIncrement = 100
+
+// maxConcurrentFetches is the maximum number of requests processed
+// simultaneously.
+const maxConcurrentFetches = 16
+
+// Oracle represents oracle service which processes requests stored in the
+// Oracle native contract and sends response transactions.
+type Oracle struct {
+	Config
+
+	log    *zap.Logger
+	wallet *wallet.Wallet
+
+	mtx sync.Mutex
+	// pending maps ids of the processed requests to ValidUntilBlock of the
+	// corresponding response transactions.
+	pending map[uint64]uint32
+
+	blocks chan *block.Block
+	quit   chan struct{}
+}
+
+// Config is an oracle service configuration.
+type Config struct {
+	// Log is a logger instance.
+	Log *zap.Logger
+	// Chain is a core.Blockchainer instance.
+	Chain blockchainer.Blockchainer
+	// Fetcher is used to get requested data, it must be safe for concurrent
+	// use and limit the time spent on every request. HTTP fetcher is used
+	// if nil.
+	Fetcher Fetcher
+	// Wallet is a wallet with oracle node account.
+	Wallet wallet.Config
+	// OnTransaction is a callback which is called for every response
+	// transaction created, it can be called concurrently.
+	OnTransaction func(*transaction.Transaction)
+}
+
+// NewOracle returns new oracle service instance.
+func NewOracle(cfg Config) (*Oracle, error) {
+	if cfg.Log == nil {
+		return nil, errors.New("empty logger")
+	}
+	if cfg.Chain == nil {
+		return nil, errors.New("empty chain")
+	}
+	if cfg.Fetcher == nil {
+		cfg.Fetcher = NewHTTPFetcher(defaultRequestTimeout, false)
+	}
+	if cfg.OnTransaction == nil {
+		cfg.OnTransaction = func(*transaction.Transaction) {}
+	}
+
+	w, err := wallet.NewWalletFromFile(cfg.Wallet.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer w.Close()
+
+	return &Oracle{
+		Config:  cfg,
+		log:     cfg.Log,
+		wallet:  w,
+		pending: make(map[uint64]uint32),
+		blocks:  make(chan *block.Block, 1),
+		quit:    make(chan struct{}),
+	}, nil
+}
+
+// Run processes pending requests and then listens for new blocks. It blocks
+// until Shutdown is called.
+func (o *Oracle) Run() {
+	o.Chain.SubscribeForBlocks(o.blocks)
+	defer o.Chain.UnsubscribeFromBlocks(o.blocks)
+
+	o.ProcessRequests()
+	for {
+		select {
+		case <-o.blocks:
+			o.ProcessRequests()
+		case <-o.quit:
+			return
+		}
+	}
+}
+
+// Shutdown stops the service.
+func (o *Oracle) Shutdown() {
+	close(o.quit)
+}
+
+// ProcessRequests processes all pending oracle requests which weren't
+// processed yet or responses to which have expired. Only requests this node
+// is designated to respond to (see native.GetOracleResponder) are processed.
+// Data is fetched concurrently (with up to maxConcurrentFetches requests at
+// a time) and it returns when all requests are processed.
+func (o *Oracle) ProcessRequests() {
+	nodes, err := o.Chain.GetOracleNodes()
+	if err != nil {
+		o.log.Error("can't get oracle nodes", zap.Error(err))
+		return
+	}
+	reqs, err := o.Chain.GetOracleRequests()
+	if err != nil {
+		o.log.Error("can't get oracle requests", zap.Error(err))
+		return
+	}
+
+	height := o.Chain.BlockHeight()
+
+	type task struct {
+		acc *wallet.Account
+		id  uint64
+		req *state.OracleRequest
+	}
+	var tasks []task
+	o.mtx.Lock()
+	for id := range o.pending {
+		if _, ok := reqs[id]; !ok {
+			delete(o.pending, id)
+		}
+	}
+	for id, req := range reqs {
+		if vub, ok := o.pending[id]; ok && vub > height {
+			continue
+		}
+		acc := o.getAccount(native.GetOracleResponder(nodes, id))
+		if acc == nil {
+			continue
+		}
+		// Request is marked as pending before fetching the data, so that
+		// it's not processed twice if the next block comes earlier.
+		o.pending[id] = height + responseValidIncrement
+		tasks = append(tasks, task{acc: acc, id: id, req: req})
+	}
+	o.mtx.Unlock()
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentFetches)
+	for _, tk := range tasks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(tk task) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			tx, err := o.CreateResponseTx(tk.acc, tk.id, tk.req)
+			o.mtx.Lock()
+			if err != nil {
+				delete(o.pending, tk.id)
+			} else {
+				o.pending[tk.id] = tx.ValidUntilBlock
+			}
+			o.mtx.Unlock()
+			if err != nil {
+				o.log.Error("can't create oracle response", zap.Uint64("id", tk.id), zap.Error(err))
+				return
+			}
+			o.log.Debug("oracle response created",
+				zap.Uint64("id", tk.id),
+				zap.Stringer("hash", tx.Hash()))
+			o.OnTransaction(tx)
+		}(tk)
+	}
+	wg.Wait()
+}
+
+// CreateResponseTx fetches data for the request with the given id and returns
+// response transaction signed by acc.
+func (o *Oracle) CreateResponseTx(acc *wallet.Account, id uint64, req *state.OracleRequest) (*transaction.Transaction, error) {
+	resp := &transaction.OracleResponseData{ID: id}
+	resp.Result, resp.Code = o.Fetcher.Fetch(req.URL)
+	if resp.Code == transaction.Success {
+		resp.Result, resp.Code = filter(resp.Result, req.Filter)
+	}
+	if resp.Code != transaction.Success {
+		resp.Result = nil
+	}
+
+	tx := transaction.New(o.Chain.GetOracleResponseScript(), util.Fixed8(req.GasForResponse))
+	tx.Attributes = append(tx.Attributes, resp.ToAttribute())
+	tx.Sender = acc.Contract.ScriptHash()
+	tx.ValidUntilBlock = o.Chain.BlockHeight() + responseValidIncrement
+
+	netFee, sizeDelta := core.CalculateNetworkFee(acc.Contract.Script)
+	size := io.GetVarSize(tx) + sizeDelta
+	tx.NetworkFee = netFee + util.Fixed8(int64(size)*int64(o.Chain.FeePerByte()))
+
+	if err := acc.SignTx(tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// getAccount returns unlocked wallet account for the given oracle node key or
+// nil if there is no such account in the wallet. It must be called with mtx
+// held.
+func (o *Oracle) getAccount(pub *keys.PublicKey) *wallet.Account {
+	if pub == nil {
+		return nil
+	}
+	acc := o.wallet.GetAccount(pub.GetScriptHash())
+	if acc == nil {
+		return nil
+	}
+	if acc.PrivateKey() == nil {
+		if err := acc.Decrypt(o.Wallet.Password); err != nil {
+			o.log.Error("can't unlock oracle account", zap.String("address", acc.Address), zap.Error(err))
+			return nil
+		}
+	}
+	return acc
+}
+
+// filter applies JSONPath filter to data.
+func filter(data []byte, path string) ([]byte, transaction.OracleResponseCode) {
+	if path == "" {
+		return data, transaction.Success
+	}
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, transaction.Error
+	}
+	objs, ok := jsonpath.Get(path, v)
+	if !ok {
+		return nil, transaction.Error
+	}
+	if objs == nil {
+		objs = []interface{}{}
+	}
+	res, err := json.Marshal(objs)
+	if err != nil {
+		return nil, transaction.Error
+	}
+	if len(res) > transaction.MaxOracleResultSize {
+		return nil, transaction.ResponseTooLarge
+	}
+	return res, transaction.Success
+}
@@ -0,0 +1,197 @@
+package oracle
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// testNodeKey is the public key of the account from testdata/wallet.json.
+const testNodeKey = "02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2"
+
+var testResponseScript = []byte{1, 2, 3}
+
+type testChain struct {
+	blockchainer.Blockchainer
+	height uint32
+	nodes  keys.PublicKeys
+	reqs   map[uint64]*state.OracleRequest
+}
+
+func (c *testChain) BlockHeight() uint32 {
+	return c.height
+}
+
+func (c *testChain) FeePerByte() util.Fixed8 {
+	return 1000
+}
+
+func (c *testChain) GetOracleNodes() (keys.PublicKeys, error) {
+	return c.nodes, nil
+}
+
+func (c *testChain) GetOracleRequests() (map[uint64]*state.OracleRequest, error) {
+	return c.reqs, nil
+}
+
+func (c *testChain) GetOracleResponseScript() []byte {
+	return testResponseScript
+}
+
+type testFetcher map[string][]byte
+
+func (f testFetcher) Fetch(url string) ([]byte, transaction.OracleResponseCode) {
+	data, ok := f[url]
+	if !ok {
+		return nil, transaction.NotFound
+	}
+	return data, transaction.Success
+}
+
+func newTestOracle(t *testing.T, chain *testChain) (*Oracle, *[]*transaction.Transaction) {
+	var (
+		txs []*transaction.Transaction
+		mtx sync.Mutex
+	)
+	orc, err := NewOracle(Config{
+		Log:   zaptest.NewLogger(t),
+		Chain: chain,
+		Fetcher: testFetcher{
+			"https://example.com/price": []byte(`{"price": 12.345, "currency": "USD"}`),
+		},
+		Wallet: wallet.Config{
+			Path:     "./testdata/wallet.json",
+			Password: "one",
+		},
+		OnTransaction: func(tx *transaction.Transaction) {
+			mtx.Lock()
+			txs = append(txs, tx)
+			mtx.Unlock()
+		},
+	})
+	require.NoError(t, err)
+	return orc, &txs
+}
+
+func TestOracle_ProcessRequests(t *testing.T) {
+	pub, err := keys.NewPublicKeyFromString(testNodeKey)
+	require.NoError(t, err)
+
+	chain := &testChain{
+		height: 10,
+		nodes:  keys.PublicKeys{pub},
+		reqs: map[uint64]*state.OracleRequest{
+			1: {
+				URL:            "https://example.com/price",
+				Filter:         "$.price",
+				GasForResponse: 10000000,
+			},
+			2: {
+				URL:            "https://example.com/unknown",
+				GasForResponse: 20000000,
+			},
+		},
+	}
+	orc, txs := newTestOracle(t, chain)
+	orc.ProcessRequests()
+	require.Equal(t, 2, len(*txs))
+
+	for _, tx := range *txs {
+		resp := tx.GetOracleResponse()
+		require.NotNil(t, resp)
+		req := chain.reqs[resp.ID]
+		require.NotNil(t, req)
+
+		switch resp.ID {
+		case 1:
+			require.Equal(t, transaction.Success, resp.Code)
+			require.Equal(t, []byte(`[12.345]`), resp.Result)
+		case 2:
+			require.Equal(t, transaction.NotFound, resp.Code)
+			require.Empty(t, resp.Result)
+		}
+
+		require.Equal(t, testResponseScript, tx.Script)
+		require.Equal(t, util.Fixed8(req.GasForResponse), tx.SystemFee)
+		require.Equal(t, pub.GetScriptHash(), tx.Sender)
+		require.Equal(t, chain.height+responseValidIncrement, tx.ValidUntilBlock)
+		require.True(t, tx.NetworkFee > 0)
+
+		require.Equal(t, 1, len(tx.Scripts))
+		require.Equal(t, pub.GetVerificationScript(), tx.Scripts[0].VerificationScript)
+		sig := tx.Scripts[0].InvocationScript[2:]
+		require.True(t, pub.Verify(sig, hash.Sha256(tx.GetSignedPart()).BytesBE()))
+	}
+
+	t.Run("AlreadyProcessed", func(t *testing.T) {
+		*txs = nil
+		orc.ProcessRequests()
+		require.Equal(t, 0, len(*txs))
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		*txs = nil
+		delete(chain.reqs, 2)
+		chain.height += responseValidIncrement
+		orc.ProcessRequests()
+		require.Equal(t, 1, len(*txs))
+		require.EqualValues(t, 1, (*txs)[0].GetOracleResponse().ID)
+	})
+
+	t.Run("NotOracleNode", func(t *testing.T) {
+		*txs = nil
+		orc, txs := newTestOracle(t, &testChain{reqs: chain.reqs})
+		orc.ProcessRequests()
+		require.Equal(t, 0, len(*txs))
+	})
+
+	t.Run("NotResponder", func(t *testing.T) {
+		other, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		chain := &testChain{
+			height: 10,
+			nodes:  keys.PublicKeys{pub, other.PublicKey()},
+			reqs: map[uint64]*state.OracleRequest{
+				2: {URL: "https://example.com/price", GasForResponse: 10000000},
+				3: {URL: "https://example.com/price", GasForResponse: 10000000},
+			},
+		}
+		orc, txs := newTestOracle(t, chain)
+		orc.ProcessRequests()
+		require.Equal(t, 1, len(*txs))
+		require.EqualValues(t, 2, (*txs)[0].GetOracleResponse().ID)
+	})
+}
+
+func TestFilter(t *testing.T) {
+	data := []byte(`{"a": [1, 2, {"b": "c"}], "big": 123456789012345678901234567890}`)
+	testCases := []struct {
+		path   string
+		result string
+		code   transaction.OracleResponseCode
+	}{
+		{"", string(data), transaction.Success},
+		{"$.a[0]", `[1]`, transaction.Success},
+		{"$..b", `["c"]`, transaction.Success},
+		{"$.big", `[123456789012345678901234567890]`, transaction.Success},
+		{"$.unknown", `[]`, transaction.Success},
+		{"$.a[", "", transaction.Error},
+	}
+	for _, tc := range testCases {
+		res, code := filter(data, tc.path)
+		require.Equal(t, tc.code, code, tc.path)
+		require.Equal(t, tc.result, string(res), tc.path)
+	}
+
+	_, code := filter([]byte("not a json"), "$")
+	require.Equal(t, transaction.Error, code)
+}
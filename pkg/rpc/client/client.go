@@ -33,6 +33,7 @@ type Client struct {
 	ctx      context.Context
 	opts     Options
 	requestF func(*request.Raw) (*response.Raw, error)
+	batchF   func([]request.Raw) ([]response.Raw, error)
 	wifMu    *sync.Mutex
 	wif      *keys.WIF
 	cache    cache
@@ -99,6 +100,7 @@ func New(ctx context.Context, endpoint string, opts Options) (*Client, error) {
 	}
 	cl.opts = opts
 	cl.requestF = cl.makeHTTPRequest
+	cl.batchF = cl.makeHTTPBatchRequest
 	return cl, nil
 }
 
@@ -147,29 +149,115 @@ func (c *Client) performRequest(method string, p request.RawParams, v interface{
 	return json.Unmarshal(raw.Result, v)
 }
 
+// BatchCall is a single call of a batch request (see PerformBatch).
+type BatchCall struct {
+	Method string
+	Params request.RawParams
+	// Result is a pointer to the value the result of the call is to be
+	// unmarshaled into.
+	Result interface{}
+	// Err is set by PerformBatch if the call has failed.
+	Err error
+}
+
+// PerformBatch sends all of the given calls to the server in a single
+// JSON-RPC batch request and then sets either Result or Err for every one of
+// them. The error returned is only set when the batch as a whole has failed.
+// The number of calls in a batch is limited by the server (100 by default).
+func (c *Client) PerformBatch(calls []BatchCall) error {
+	if len(calls) == 0 {
+		return nil
+	}
+	reqs := make([]request.Raw, len(calls))
+	for i := range calls {
+		reqs[i] = request.Raw{
+			JSONRPC:   request.JSONRPCVersion,
+			Method:    calls[i].Method,
+			RawParams: calls[i].Params.Values,
+			ID:        i + 1,
+		}
+	}
+
+	raws, err := c.batchF(reqs)
+	if err != nil {
+		return err
+	}
+	byID := make(map[int]*response.Raw, len(raws))
+	for i := range raws {
+		var id int
+		if err := json.Unmarshal(raws[i].ID, &id); err == nil {
+			byID[id] = &raws[i]
+		}
+	}
+	for i := range calls {
+		raw, ok := byID[i+1]
+		switch {
+		case !ok:
+			calls[i].Err = errors.New("no response returned")
+		case raw.Error != nil:
+			calls[i].Err = raw.Error
+		case raw.Result == nil:
+			calls[i].Err = errors.New("no result returned")
+		default:
+			calls[i].Err = json.Unmarshal(raw.Result, calls[i].Result)
+		}
+	}
+	return nil
+}
+
+// unmarshalBatchResponse decodes response to a batch request which is either
+// an array of responses or a single error response.
+func unmarshalBatchResponse(data []byte) ([]response.Raw, error) {
+	var raws []response.Raw
+	if err := json.Unmarshal(data, &raws); err == nil {
+		return raws, nil
+	}
+	raw := new(response.Raw)
+	if err := json.Unmarshal(data, raw); err != nil {
+		return nil, errors.Wrap(err, "JSON decoding")
+	}
+	if raw.Error != nil {
+		return nil, raw.Error
+	}
+	return nil, errors.New("unexpected response to batch request")
+}
+
 func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
-	var (
-		buf = new(bytes.Buffer)
-		raw = new(response.Raw)
-	)
+	raw := new(response.Raw)
+	if err := c.doHTTPRequest(r, raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
 
-	if err := json.NewEncoder(buf).Encode(r); err != nil {
+func (c *Client) makeHTTPBatchRequest(r []request.Raw) ([]response.Raw, error) {
+	var data json.RawMessage
+	if err := c.doHTTPRequest(r, &data); err != nil {
 		return nil, err
 	}
+	return unmarshalBatchResponse(data)
+}
+
+// doHTTPRequest sends r to the server and decodes the response into v.
+func (c *Client) doHTTPRequest(r interface{}, v interface{}) error {
+	buf := new(bytes.Buffer)
+	if err := json.NewEncoder(buf).Encode(r); err != nil {
+		return err
+	}
 
 	req, err := http.NewRequest("POST", c.endpoint.String(), buf)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	resp, err := c.cli.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	// The node might send us proper JSON anyway, so look there first and if
 	// it parses, then it has more relevant data than HTTP error code.
-	err = json.NewDecoder(resp.Body).Decode(raw)
+	err = json.NewDecoder(resp.Body).Decode(v)
 	if err != nil {
 		if resp.StatusCode != http.StatusOK {
 			err = fmt.Errorf("HTTP %d/%s", resp.StatusCode, http.StatusText(resp.StatusCode))
@@ -177,10 +265,7 @@ func (c *Client) makeHTTPRequest(r *request.Raw) (*response.Raw, error) {
 			err = errors.Wrap(err, "JSON decoding")
 		}
 	}
-	if err != nil {
-		return nil, err
-	}
-	return raw, nil
+	return err
 }
 
 // Ping attempts to create a connection to the endpoint.
@@ -25,10 +25,13 @@ Supported methods
 	getblockhash
 	getblockheader
 	getblocksysfee
+	getcandidates
+	getcommittee
 	getconnectioncount
 	getcontractstate
 	getnep5balances
 	getnep5transfers
+	getnextblockvalidators
 	getpeers
 	getrawmempool
 	getrawtransaction
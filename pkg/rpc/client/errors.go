@@ -0,0 +1,93 @@
+package client
+
+import (
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/pkg/errors"
+)
+
+// Transaction rejection errors, SubmitError returned by SendRawTransaction
+// has one of them as its cause, so they can be checked with errors.Cause.
+var (
+	// ErrTxAlreadyExists is returned for transactions that are already
+	// in the chain or in the memory pool.
+	ErrTxAlreadyExists = errors.New("transaction already exists")
+	// ErrTxOutOfMemory is returned when the memory pool is full and the
+	// transaction fee is too low to replace any of its transactions.
+	ErrTxOutOfMemory = errors.New("memory pool is full")
+	// ErrTxUnableToVerify is returned when the transaction can't be verified.
+	ErrTxUnableToVerify = errors.New("unable to verify transaction")
+	// ErrTxValidationFailed is returned for invalid transactions.
+	ErrTxValidationFailed = errors.New("transaction validation failed")
+	// ErrTxPolicyFail is returned for transactions not allowed by the node
+	// policy.
+	ErrTxPolicyFail = errors.New("transaction is not allowed by policy")
+	// ErrTxConflict is returned when the sender can't pay for all of its
+	// transactions in the memory pool.
+	ErrTxConflict = errors.New("transaction conflicts with the memory pool")
+	// ErrTxDuplicate is returned when the transaction is already in the
+	// memory pool.
+	ErrTxDuplicate = errors.New("transaction is already in the memory pool")
+	// ErrTxUnknown is returned for rejections with unknown reason.
+	ErrTxUnknown = errors.New("transaction is rejected with unknown reason")
+)
+
+// submitErrors maps submit error codes to transaction rejection errors.
+var submitErrors = map[int64]error{
+	response.ErrAlreadyExists.Code:    ErrTxAlreadyExists,
+	response.ErrOutOfMemory.Code:      ErrTxOutOfMemory,
+	response.ErrUnableToVerify.Code:   ErrTxUnableToVerify,
+	response.ErrValidationFailed.Code: ErrTxValidationFailed,
+	response.ErrPolicyFail.Code:       ErrTxPolicyFail,
+	response.ErrConflict.Code:         ErrTxConflict,
+	response.ErrDuplicate.Code:        ErrTxDuplicate,
+	response.ErrUnknown.Code:          ErrTxUnknown,
+}
+
+// SubmitError is returned when the node rejects the transaction.
+type SubmitError struct {
+	// Err is one of ErrTx* errors describing the rejection reason.
+	Err error
+	// Code is the JSON-RPC error code returned by the node.
+	Code int64
+	// Details is the description of the failure given by the node (if
+	// any).
+	Details string
+}
+
+// Error implements the error interface.
+func (e *SubmitError) Error() string {
+	if e.Details == "" {
+		return e.Err.Error()
+	}
+	return e.Err.Error() + ": " + e.Details
+}
+
+// Cause returns the rejection reason error, it allows to use errors.Cause.
+func (e *SubmitError) Cause() error {
+	return e.Err
+}
+
+// Unwrap returns the rejection reason error.
+func (e *SubmitError) Unwrap() error {
+	return e.Err
+}
+
+// newSubmitError converts JSON-RPC error returned by sendrawtransaction into
+// SubmitError, other errors are returned as is.
+func newSubmitError(err error) error {
+	rpcErr, ok := err.(*response.Error)
+	if !ok {
+		return err
+	}
+	reason, ok := submitErrors[rpcErr.Code]
+	if !ok {
+		return err
+	}
+	var details string
+	if i := strings.Index(rpcErr.Data, ": "); i >= 0 {
+		details = rpcErr.Data[i+2:]
+	}
+	return &SubmitError{Err: reason, Code: rpcErr.Code, Details: details}
+}
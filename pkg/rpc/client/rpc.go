@@ -6,7 +6,9 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
@@ -176,6 +178,35 @@ func (c *Client) GetConnectionCount() (int, error) {
 	return resp, nil
 }
 
+// GetAccountTransactions returns the given page of transactions (limit
+// transactions per page, newest first) the account with the specified
+// address was a sender or a cosigner of. It requires account transaction
+// index to be enabled on the server.
+func (c *Client) GetAccountTransactions(address string, page, limit int) (*result.AccountTransactions, error) {
+	var (
+		params = request.NewRawParams(address, page, limit)
+		resp   = &result.AccountTransactions{}
+	)
+	if err := c.performRequest("getaccounttransactions", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetContractNotifications returns the given page of notifications with the
+// specified event name emitted by the contract in blocks from start to stop
+// (inclusive). It requires notification index to be enabled on the server.
+func (c *Client) GetContractNotifications(contract util.Uint160, name string, start, stop uint32, page int) (*result.ContractNotifications, error) {
+	var (
+		params = request.NewRawParams(contract.StringLE(), name, start, stop, page)
+		resp   = &result.ContractNotifications{}
+	)
+	if err := c.performRequest("getcontractnotifications", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetContractState queries contract information, according to the contract script hash.
 func (c *Client) GetContractState(hash util.Uint160) (*result.ContractState, error) {
 	var (
@@ -198,9 +229,41 @@ func (c *Client) GetNEP5Balances(address util.Uint160) (*result.NEP5Balances, er
 	return resp, nil
 }
 
-// GetNEP5Transfers is a wrapper for getnep5transfers RPC.
+// GetNEP5Transfers is a wrapper for getnep5transfers RPC. It returns all
+// transfers of the address, use GetNEP5TransfersPage to get them page by page.
 func (c *Client) GetNEP5Transfers(address string) (*result.NEP5Transfers, error) {
+	return c.GetNEP5TransfersPage(address, nil, nil, nil, nil)
+}
+
+// GetNEP5TransfersPage is a wrapper for getnep5transfers RPC with optional
+// parameters. Transfers are returned in reverse chronological order. Optional
+// start and stop parameters limit the range of block timestamps, limit and
+// page parameters allow to get transfers page by page. Each of the optional
+// parameters requires all of the preceding ones to be set.
+func (c *Client) GetNEP5TransfersPage(address string, start, stop *uint64, limit, page *int) (*result.NEP5Transfers, error) {
 	params := request.NewRawParams(address)
+	var missing bool
+	for _, p := range []interface{}{start, stop, limit, page} {
+		var v interface{}
+		switch p := p.(type) {
+		case *uint64:
+			if p != nil {
+				v = *p
+			}
+		case *int:
+			if p != nil {
+				v = *p
+			}
+		}
+		if v == nil {
+			missing = true
+			continue
+		}
+		if missing {
+			return nil, errors.New("bad parameters")
+		}
+		params.Values = append(params.Values, v)
+	}
 	resp := new(result.NEP5Transfers)
 	if err := c.performRequest("getnep5transfers", params, resp); err != nil {
 		return nil, err
@@ -220,6 +283,19 @@ func (c *Client) GetPeers() (*result.GetPeers, error) {
 	return resp, nil
 }
 
+// GetProof returns existence proof of storage item state by the given stateroot
+// historical contract hash and historical item key.
+func (c *Client) GetProof(stateroot util.Uint256, historicalContractHash util.Uint160, historicalKey []byte) (*result.GetProof, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), historicalContractHash.StringLE(), hex.EncodeToString(historicalKey))
+		resp   = &result.GetProof{}
+	)
+	if err := c.performRequest("getproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawMemPool returns the list of unconfirmed transactions in memory.
 func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	var (
@@ -268,6 +344,18 @@ func (c *Client) GetRawTransactionVerbose(hash util.Uint256) (*result.Transactio
 	return resp, nil
 }
 
+// GetStateRootByHeight returns state root for the specified height.
+func (c *Client) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
+	var (
+		params = request.NewRawParams(height)
+		resp   = &state.MPTRoot{}
+	)
+	if err := c.performRequest("getstateroot", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetStorage returns the stored value, according to the contract script hash and the stored key.
 func (c *Client) GetStorage(hash util.Uint160, key []byte) ([]byte, error) {
 	var (
@@ -284,6 +372,39 @@ func (c *Client) GetStorage(hash util.Uint160, key []byte) ([]byte, error) {
 	return res, nil
 }
 
+// GetStorageAt returns the stored value as it was after processing the block
+// with the given index, according to the contract script hash and the stored
+// key.
+func (c *Client) GetStorageAt(height uint32, hash util.Uint160, key []byte) ([]byte, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE(), hex.EncodeToString(key), height)
+		resp   string
+	)
+	if err := c.performRequest("getstorage", params, &resp); err != nil {
+		return nil, err
+	}
+	res, err := hex.DecodeString(resp)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// FindStorage returns at most count contract storage items with the given key
+// prefix (sorted by key) starting from the start key (which can be nil to
+// start from the first one). The server may return less items than
+// requested, use Next and Truncated fields of the result to get the next page.
+func (c *Client) FindStorage(hash util.Uint160, prefix []byte, start []byte, count int) (*result.FindStorage, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE(), hex.EncodeToString(prefix), hex.EncodeToString(start), count)
+		resp   = &result.FindStorage{}
+	)
+	if err := c.performRequest("findstorage", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetTransactionHeight returns the block index in which the transaction is found.
 func (c *Client) GetTransactionHeight(hash util.Uint256) (uint32, error) {
 	var (
@@ -312,6 +433,42 @@ func (c *Client) GetUnclaimedGas(address string) (util.Fixed8, error) {
 	return util.Fixed8(i), nil
 }
 
+// GetCandidates returns registered validator candidates with their votes.
+func (c *Client) GetCandidates() ([]result.Validator, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new([]result.Validator)
+	)
+	if err := c.performRequest("getcandidates", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
+// GetNextBlockValidators returns next block validators with their votes.
+func (c *Client) GetNextBlockValidators() ([]result.Validator, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new([]result.Validator)
+	)
+	if err := c.performRequest("getnextblockvalidators", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
+// GetCommittee returns public keys of the committee members.
+func (c *Client) GetCommittee() (keys.PublicKeys, error) {
+	var (
+		params = request.NewRawParams()
+		resp   = new(keys.PublicKeys)
+	)
+	if err := c.performRequest("getcommittee", params, resp); err != nil {
+		return nil, err
+	}
+	return *resp, nil
+}
+
 // GetValidators returns the current NEO consensus nodes information and voting status.
 func (c *Client) GetValidators() ([]result.Validator, error) {
 	var (
@@ -349,6 +506,34 @@ func (c *Client) InvokeScript(script string) (*result.Invoke, error) {
 	return resp, nil
 }
 
+// InvokeScriptAt returns the result of the given script run against the state
+// as it was after processing the block with the given index.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptAt(height uint32, script string) (*result.Invoke, error) {
+	var (
+		params = request.NewRawParams(script, height)
+		resp   = &result.Invoke{}
+	)
+	if err := c.performRequest("invokescript", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// InvokeScriptWithSigners is similar to InvokeScript, but passes the given
+// signers to the invocation, so that witness checks can succeed for them.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithSigners(script string, signers []request.Signer) (*result.Invoke, error) {
+	var (
+		params = request.NewRawParams(script, signers)
+		resp   = &result.Invoke{}
+	)
+	if err := c.performRequest("invokescript", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // InvokeFunction returns the results after calling the smart contract scripthash
 // with the given operation and parameters.
 // NOTE: this is test invoke and will not affect the blockchain.
@@ -363,6 +548,35 @@ func (c *Client) InvokeFunction(script, operation string, params []smartcontract
 	return resp, nil
 }
 
+// InvokeFunctionAt is similar to InvokeFunction, but uses the state as it was
+// after processing the block with the given index.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeFunctionAt(height uint32, script, operation string, params []smartcontract.Parameter) (*result.Invoke, error) {
+	var (
+		p    = request.NewRawParams(script, operation, params, height)
+		resp = &result.Invoke{}
+	)
+	if err := c.performRequest("invokefunction", p, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// InvokeFunctionWithSigners is similar to InvokeFunction, but passes the
+// given signers to the invocation, so that witness checks can succeed for
+// them.
+// NOTE: this is test invoke and will not affect the blockchain.
+func (c *Client) InvokeFunctionWithSigners(script, operation string, params []smartcontract.Parameter, signers []request.Signer) (*result.Invoke, error) {
+	var (
+		p    = request.NewRawParams(script, operation, params, signers)
+		resp = &result.Invoke{}
+	)
+	if err := c.performRequest("invokefunction", p, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // Invoke returns the results after calling the smart contract scripthash
 // with the given parameters.
 func (c *Client) Invoke(script string, params []smartcontract.Parameter) (*result.Invoke, error) {
@@ -386,7 +600,7 @@ func (c *Client) SendRawTransaction(rawTX *transaction.Transaction) error {
 		resp   bool
 	)
 	if err := c.performRequest("sendrawtransaction", params, &resp); err != nil {
-		return err
+		return newSubmitError(err)
 	}
 	if !resp {
 		return errors.New("sendrawtransaction returned false")
@@ -455,6 +669,61 @@ func (c *Client) SignAndPushInvocationTx(script []byte, acc *wallet.Account, sys
 	return txHash, nil
 }
 
+// VerifyProof returns value by the given stateroot and proof.
+func (c *Client) VerifyProof(stateroot util.Uint256, proof *result.ProofWithKey) ([]byte, error) {
+	var (
+		params = request.NewRawParams(stateroot.StringLE(), proof.String())
+		resp   = &result.VerifyProof{}
+	)
+	if err := c.performRequest("verifyproof", params, resp); err != nil {
+		return nil, err
+	}
+	if resp.Value == nil {
+		return nil, errors.New("invalid proof")
+	}
+	return resp.Value, nil
+}
+
+// TraceTransaction executes the transaction with the given hash once again
+// on the server and returns all the instructions executed.
+func (c *Client) TraceTransaction(hash util.Uint256) (*result.TransactionTrace, error) {
+	var (
+		params = request.NewRawParams(hash.StringLE())
+		resp   = &result.TransactionTrace{}
+	)
+	if err := c.performRequest("tracetransaction", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TraverseIterator returns at most count next items of the iterator with the
+// given ID (its position on the resulting stack) from the iterator session
+// returned by one of the invocation methods.
+func (c *Client) TraverseIterator(session string, iteratorID int, count int) ([]smartcontract.Parameter, error) {
+	var (
+		params = request.NewRawParams(session, iteratorID, count)
+		resp   []smartcontract.Parameter
+	)
+	if err := c.performRequest("traverseiterator", params, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// TerminateSession drops the given iterator session on the server, it returns
+// false if there was no such session.
+func (c *Client) TerminateSession(session string) (bool, error) {
+	var (
+		params = request.NewRawParams(session)
+		resp   bool
+	)
+	if err := c.performRequest("terminatesession", params, &resp); err != nil {
+		return false, err
+	}
+	return resp, nil
+}
+
 // ValidateAddress verifies that the address is a correct NEO address.
 func (c *Client) ValidateAddress(address string) error {
 	var (
@@ -525,6 +794,22 @@ func (c *Client) AddNetworkFee(tx *transaction.Transaction, acc *wallet.Account)
 	return nil
 }
 
+// CalculateNetworkFee returns the network fee needed for the given unsigned
+// transaction calculated by the server. Unlike AddNetworkFee it handles
+// witnesses with arbitrary verification scripts (which are then expected
+// to be present in tx.Scripts along with the invocation scripts needed to
+// run them) and witnesses of deployed contracts.
+func (c *Client) CalculateNetworkFee(tx *transaction.Transaction) (util.Fixed8, error) {
+	var (
+		params = request.NewRawParams(hex.EncodeToString(tx.Bytes()))
+		resp   = new(result.NetworkFee)
+	)
+	if err := c.performRequest("calculatenetworkfee", params, resp); err != nil {
+		return 0, err
+	}
+	return resp.Value, nil
+}
+
 // GetFeePerByte returns transaction network fee per byte
 func (c *Client) GetFeePerByte() util.Fixed8 {
 	// TODO: make it a part of policy contract
@@ -11,7 +11,9 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
@@ -19,6 +21,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -93,6 +96,38 @@ func getTxMoveNeo() *result.TransactionOutputRaw {
 // published in official C# JSON-RPC API v2.10.3 reference
 // (see https://docs.neo.org/docs/en-us/reference/rpc/latest-version/api.html)
 var rpcClientTestCases = map[string][]rpcClientTestCase{
+	"calculatenetworkfee": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.CalculateNetworkFee(transaction.New([]byte{byte(opcode.PUSH1)}, 0))
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"networkfee":"0.0125247"}}`,
+			result: func(c *Client) interface{} {
+				return util.Fixed8(1252470)
+			},
+		},
+	},
+	"findstorage": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				hash, err := util.Uint160DecodeStringLE("03febccf81ac85e3d795bc5cbd4e84e907812aa3")
+				if err != nil {
+					panic(err)
+				}
+				return c.FindStorage(hash, []byte{0x74}, nil, 1)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"results":[{"key":"746573746b6579","value":"746573746b6579"}],"next":"746573746b657a","truncated":true}}`,
+			result: func(c *Client) interface{} {
+				return &result.FindStorage{
+					Results:   []result.KeyValue{{Key: "746573746b6579", Value: "746573746b6579"}},
+					Next:      "746573746b657a",
+					Truncated: true,
+				}
+			},
+		},
+	},
 	"getapplicationlog": {
 		{
 			name: "positive",
@@ -289,6 +324,69 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getaccounttransactions": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetAccountTransactions("AKkkumHbBipZ46UMZJoFynJMXzSRnBvKcs", 0, 10)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"address":"AKkkumHbBipZ46UMZJoFynJMXzSRnBvKcs","transactions":[{"txid":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","blockindex":5,"timestamp":1591366176005}],"truncated":true}}`,
+			result: func(c *Client) interface{} {
+				txHash, err := util.Uint256DecodeStringLE("17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521")
+				if err != nil {
+					panic(err)
+				}
+				return &result.AccountTransactions{
+					Address: "AKkkumHbBipZ46UMZJoFynJMXzSRnBvKcs",
+					Transactions: []result.AccountTransaction{{
+						TxHash:     txHash,
+						BlockIndex: 5,
+						Timestamp:  1591366176005,
+					}},
+					Truncated: true,
+				}
+			},
+		},
+	},
+	"getcontractnotifications": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				hash, err := util.Uint160DecodeStringLE("03febccf81ac85e3d795bc5cbd4e84e907812aa3")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetContractNotifications(hash, "transfer", 0, 10, 0)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"notifications":[{"blockindex":5,"txid":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","contract":"0x03febccf81ac85e3d795bc5cbd4e84e907812aa3","state":{"type":"Array","value":[{"type":"ByteArray","value":"dHJhbnNmZXI="}]}}],"truncated":false}}`,
+			result: func(c *Client) interface{} {
+				txHash, err := util.Uint256DecodeStringLE("17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521")
+				if err != nil {
+					panic(err)
+				}
+				hash, err := util.Uint160DecodeStringLE("03febccf81ac85e3d795bc5cbd4e84e907812aa3")
+				if err != nil {
+					panic(err)
+				}
+				return &result.ContractNotifications{
+					Notifications: []result.ContractNotification{{
+						BlockIndex: 5,
+						TxHash:     txHash,
+						NotificationEvent: result.NotificationEvent{
+							Contract: hash,
+							Item: smartcontract.Parameter{
+								Type: smartcontract.ArrayType,
+								Value: []smartcontract.Parameter{{
+									Type:  smartcontract.ByteArrayType,
+									Value: []byte("transfer"),
+								}},
+							},
+						},
+					}},
+				}
+			},
+		},
+	},
 	"getcontractstate": {
 		{
 			name: "positive",
@@ -389,6 +487,22 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "with parameters",
+			invoke: func(c *Client) (interface{}, error) {
+				start, stop := uint64(1555651816), uint64(1555651817)
+				limit, page := 10, 1
+				return c.GetNEP5TransfersPage("AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF", &start, &stop, &limit, &page)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"sent":[],"received":[],"address":"AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF"}}`,
+			result: func(c *Client) interface{} {
+				return &result.NEP5Transfers{
+					Sent:     []result.NEP5Transfer{},
+					Received: []result.NEP5Transfer{},
+					Address:  "AbHgdBaWEnHkCiLtDZXjhvhaAK2cwFh5pF",
+				}
+			},
+		},
 	},
 	"getpeers": {
 		{
@@ -421,6 +535,26 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getproof": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("e1bd4f5b9f74b5b2bf2b0e89d3f7d5e7ff0e4a5c41d1b0f7b7e1fd2a8f0a8e43")
+				sc, _ := util.Uint160DecodeStringLE("1b4357bff5a01bdf2a6581247cf9ed1e24629176")
+				return c.GetProof(root, sc, []byte("testkey"))
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"proof":"0301020302020102020304","success":true}}`,
+			result: func(c *Client) interface{} {
+				return &result.GetProof{
+					Result: result.ProofWithKey{
+						Key:   []byte{1, 2, 3},
+						Proof: [][]byte{{1, 2}, {3, 4}},
+					},
+					Success: true,
+				}
+			},
+		},
+	},
 	"getrawmempool": {
 		{
 			name: "positive",
@@ -468,6 +602,22 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getstateroot": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetStateRootByHeight(5)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"index":5,"stateroot":"0xe1bd4f5b9f74b5b2bf2b0e89d3f7d5e7ff0e4a5c41d1b0f7b7e1fd2a8f0a8e43"}}`,
+			result: func(c *Client) interface{} {
+				root, _ := util.Uint256DecodeStringLE("e1bd4f5b9f74b5b2bf2b0e89d3f7d5e7ff0e4a5c41d1b0f7b7e1fd2a8f0a8e43")
+				return &state.MPTRoot{
+					Index: 5,
+					Root:  root,
+				}
+			},
+		},
+	},
 	"getstorage": {
 		{
 			name: "positive",
@@ -491,6 +641,28 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				return value
 			},
 		},
+		{
+			name: "positive, at height",
+			invoke: func(c *Client) (interface{}, error) {
+				hash, err := util.Uint160DecodeStringLE("03febccf81ac85e3d795bc5cbd4e84e907812aa3")
+				if err != nil {
+					panic(err)
+				}
+				key, err := hex.DecodeString("5065746572")
+				if err != nil {
+					panic(err)
+				}
+				return c.GetStorageAt(1, hash, key)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":"4c696e"}`,
+			result: func(c *Client) interface{} {
+				value, err := hex.DecodeString("4c696e")
+				if err != nil {
+					panic(err)
+				}
+				return value
+			},
+		},
 	},
 	"gettransactionheight": {
 		{
@@ -520,6 +692,50 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getcandidates": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCandidates()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"publickey":"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","votes":"100","active":true},{"publickey":"02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e","votes":"0","active":false}]}`,
+			result: func(c *Client) interface{} {
+				k1, _ := keys.NewPublicKeyFromString("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2")
+				k2, _ := keys.NewPublicKeyFromString("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e")
+				return []result.Validator{
+					{PublicKey: *k1, Votes: 100, Active: true},
+					{PublicKey: *k2, Votes: 0, Active: false},
+				}
+			},
+		},
+	},
+	"getnextblockvalidators": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNextBlockValidators()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":[{"publickey":"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","votes":"100","active":true}]}`,
+			result: func(c *Client) interface{} {
+				k, _ := keys.NewPublicKeyFromString("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2")
+				return []result.Validator{{PublicKey: *k, Votes: 100, Active: true}}
+			},
+		},
+	},
+	"getcommittee": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCommittee()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":["02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2","02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e"]}`,
+			result: func(c *Client) interface{} {
+				k1, _ := keys.NewPublicKeyFromString("02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2")
+				k2, _ := keys.NewPublicKeyFromString("02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e")
+				return keys.PublicKeys{k1, k2}
+			},
+		},
+	},
 	"getvalidators": {
 		{
 			name: "positive",
@@ -611,6 +827,33 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, with signers",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.InvokeScriptWithSigners("0c14870958fd19ee3f6c7dc3c2df399d013910856e3141f827ec8c", []request.Signer{{
+					Cosigner: transaction.Cosigner{
+						Account: util.Uint160{1, 2, 3},
+						Scopes:  transaction.Global,
+					},
+				}})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"0c14870958fd19ee3f6c7dc3c2df399d013910856e3141f827ec8c","state":"FAULT","gas_consumed":"0.0030007","stack":[],"exception":"at instruction 27 (ASSERT): ASSERT failed","logs":[{"contract":"0x1b4357bff5a01bdf2a6581247cf9ed1e24629176","message":"hello"}],"storage_changes":[{"contract":"0x1b4357bff5a01bdf2a6581247cf9ed1e24629176","key":"6b6579","deleted":true}]}}`,
+			result: func(c *Client) interface{} {
+				h, err := util.Uint160DecodeStringLE("1b4357bff5a01bdf2a6581247cf9ed1e24629176")
+				if err != nil {
+					panic(err)
+				}
+				return &result.Invoke{
+					State:          "FAULT",
+					GasConsumed:    "0.0030007",
+					Script:         "0c14870958fd19ee3f6c7dc3c2df399d013910856e3141f827ec8c",
+					Stack:          []smartcontract.Parameter{},
+					FaultException: "at instruction 27 (ASSERT): ASSERT failed",
+					Logs:           []result.Log{{Contract: h, Message: "hello"}},
+					StorageChanges: []result.StorageChange{{Contract: h, Key: "6b6579", Deleted: true}},
+				}
+			},
+		},
 	},
 	"sendrawtransaction": {
 		{
@@ -642,6 +885,77 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"terminatesession": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.TerminateSession("6e8a9e4c8b4c8e5d2e5d8c0c1e2f3a4b")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":true}`,
+			result: func(c *Client) interface{} {
+				return true
+			},
+		},
+	},
+	"traverseiterator": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.TraverseIterator("6e8a9e4c8b4c8e5d2e5d8c0c1e2f3a4b", 0, 2)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"type":"Array","value":[{"type":"Integer","value":"0"},{"type":"ByteArray","value":"YQ=="}]}]}`,
+			result: func(c *Client) interface{} {
+				return []smartcontract.Parameter{{
+					Type: smartcontract.ArrayType,
+					Value: []smartcontract.Parameter{
+						{Type: smartcontract.IntegerType, Value: int64(0)},
+						{Type: smartcontract.ByteArrayType, Value: []byte("a")},
+					},
+				}}
+			},
+		},
+	},
+	"tracetransaction": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.TraceTransaction(util.Uint256{})
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"txid":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","vmstate":"HALT","gas_consumed":"0.0000003","stack":[{"type":"Integer","value":1}],"steps":[{"ip":0,"opcode":"PUSH1","contract":"0xb9fa3b421eb749d5dd585fe1c1133b311a14bcb1","gas_consumed":"0","stack_depth":0},{"ip":1,"opcode":"RET","contract":"0xb9fa3b421eb749d5dd585fe1c1133b311a14bcb1","gas_consumed":"0.0000003","stack_depth":1}],"truncated":false}}`,
+			result: func(c *Client) interface{} {
+				txHash, err := util.Uint256DecodeStringLE("17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521")
+				if err != nil {
+					panic(err)
+				}
+				scriptHash, err := util.Uint160DecodeStringLE("b9fa3b421eb749d5dd585fe1c1133b311a14bcb1")
+				if err != nil {
+					panic(err)
+				}
+				return &result.TransactionTrace{
+					TxHash:      txHash,
+					VMState:     "HALT",
+					GasConsumed: 30,
+					Stack:       []smartcontract.Parameter{{Type: smartcontract.IntegerType, Value: int64(1)}},
+					Steps: []result.TraceStep{
+						{IP: 0, Opcode: "PUSH1", ScriptHash: scriptHash, GasConsumed: 0, StackDepth: 0},
+						{IP: 1, Opcode: "RET", ScriptHash: scriptHash, GasConsumed: 30, StackDepth: 1},
+					},
+				}
+			},
+		},
+	},
+	"verifyproof": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.VerifyProof(util.Uint256{}, &result.ProofWithKey{Key: []byte{1}})
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":"7465737476616c7565"}`,
+			result: func(c *Client) interface{} {
+				return []byte("testvalue")
+			},
+		},
+	},
 	"validateaddress": {
 		{
 			name: "positive",
@@ -690,6 +1004,12 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 				return c.GetRawTransaction(hash)
 			},
 		},
+		{
+			name: "verifyproof_not_a_hex_response",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.VerifyProof(util.Uint256{}, &result.ProofWithKey{Key: []byte{1}})
+			},
+		},
 		{
 			name: "getstorage_not_a_hex_response",
 			invoke: func(c *Client) (interface{}, error) {
@@ -842,6 +1162,13 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 				return c.GetNEP5Transfers("")
 			},
 		},
+		{
+			name: "getnep5transfers_missing_start_error",
+			invoke: func(c *Client) (interface{}, error) {
+				limit := 10
+				return c.GetNEP5TransfersPage("", nil, nil, &limit, nil)
+			},
+		},
 		{
 			name: "getrawtransaction_invalid_params_error",
 			invoke: func(c *Client) (interface{}, error) {
@@ -1036,6 +1363,24 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 				return c.GetUnclaimedGas("")
 			},
 		},
+		{
+			name: "getcandidates_unmarshalling_error",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCandidates()
+			},
+		},
+		{
+			name: "getnextblockvalidators_unmarshalling_error",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetNextBlockValidators()
+			},
+		},
+		{
+			name: "getcommittee_unmarshalling_error",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetCommittee()
+			},
+		},
 		{
 			name: "getvalidators_unmarshalling_error",
 			invoke: func(c *Client) (interface{}, error) {
@@ -1234,3 +1579,86 @@ func TestCalculateValidUntilBlock(t *testing.T) {
 	assert.Equal(t, 2, getBlockCountCalled)
 	assert.Equal(t, 1, getValidatorsCalled)
 }
+
+func TestPerformBatch(t *testing.T) {
+	newClients := map[string]func(t *testing.T, endpoint string) *Client{
+		"Client": func(t *testing.T, endpoint string) *Client {
+			c, err := New(context.TODO(), endpoint, Options{})
+			require.NoError(t, err)
+			return c
+		},
+		"WSClient": func(t *testing.T, endpoint string) *Client {
+			wsc, err := NewWS(context.TODO(), httpURLtoWS(endpoint), Options{})
+			require.NoError(t, err)
+			return &wsc.Client
+		},
+	}
+	for name, newClient := range newClients {
+		t.Run(name, func(t *testing.T) {
+			t.Run("positive", func(t *testing.T) {
+				srv := initTestServer(t, `[{"jsonrpc":"2.0","id":2,"error":{"code":-32601,"message":"Method not found"}},{"jsonrpc":"2.0","id":1,"result":50}]`)
+				defer srv.Close()
+				c := newClient(t, srv.URL)
+
+				var count uint32
+				var unknown string
+				calls := []BatchCall{
+					{Method: "getblockcount", Result: &count},
+					{Method: "unknownmethod", Params: request.NewRawParams(1), Result: &unknown},
+				}
+				require.NoError(t, c.PerformBatch(calls))
+				require.NoError(t, calls[0].Err)
+				require.EqualValues(t, 50, count)
+				require.Error(t, calls[1].Err)
+			})
+			t.Run("batch error", func(t *testing.T) {
+				srv := initTestServer(t, `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}`)
+				defer srv.Close()
+				c := newClient(t, srv.URL)
+
+				var count uint32
+				require.Error(t, c.PerformBatch([]BatchCall{{Method: "getblockcount", Result: &count}}))
+			})
+		})
+	}
+}
+
+func TestSendRawTransactionErrors(t *testing.T) {
+	testCases := []struct {
+		resp    string
+		err     error
+		details string
+	}{
+		{`{"jsonrpc":"2.0","id":1,"error":{"code":-501,"message":"Block or transaction already exists and cannot be sent repeatedly.","data":"already_exists: already exists"}}`, ErrTxAlreadyExists, "already exists"},
+		{`{"jsonrpc":"2.0","id":1,"error":{"code":-502,"message":"The memory pool is full and no more transactions can be sent.","data":"out_of_memory: no space left in the memory pool"}}`, ErrTxOutOfMemory, "no space left in the memory pool"},
+		{`{"jsonrpc":"2.0","id":1,"error":{"code":-504,"message":"Block or transaction validation failed.","data":"validation_failed: insufficient funds"}}`, ErrTxValidationFailed, "insufficient funds"},
+		{`{"jsonrpc":"2.0","id":1,"error":{"code":-505,"message":"One of the Policy filters failed.","data":"policy_fail"}}`, ErrTxPolicyFail, ""},
+		{`{"jsonrpc":"2.0","id":1,"error":{"code":-506,"message":"Transaction conflicts with the memory pool.","data":"conflict: conflicts with the memory pool"}}`, ErrTxConflict, "conflicts with the memory pool"},
+		{`{"jsonrpc":"2.0","id":1,"error":{"code":-507,"message":"Transaction is already in the memory pool.","data":"duplicate: already in the memory pool"}}`, ErrTxDuplicate, "already in the memory pool"},
+	}
+	for _, tc := range testCases {
+		srv := initTestServer(t, tc.resp)
+		c, err := New(context.TODO(), srv.URL, Options{})
+		require.NoError(t, err)
+
+		err = c.SendRawTransaction(transaction.New([]byte{byte(opcode.PUSH1)}, 0))
+		srv.Close()
+		require.Error(t, err)
+		require.Equal(t, tc.err, errors.Cause(err))
+		subErr, ok := err.(*SubmitError)
+		require.True(t, ok)
+		require.Equal(t, tc.details, subErr.Details)
+	}
+
+	t.Run("not a submit error", func(t *testing.T) {
+		srv := initTestServer(t, `{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"Invalid Params"}}`)
+		defer srv.Close()
+		c, err := New(context.TODO(), srv.URL, Options{})
+		require.NoError(t, err)
+
+		err = c.SendRawTransaction(transaction.New([]byte{byte(opcode.PUSH1)}, 0))
+		require.Error(t, err)
+		_, ok := err.(*SubmitError)
+		require.False(t, ok)
+	})
+}
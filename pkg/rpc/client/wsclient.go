@@ -29,17 +29,20 @@ type WSClient struct {
 	// be closed, so make sure to handle this.
 	Notifications chan Notification
 
-	ws            *websocket.Conn
-	done          chan struct{}
-	responses     chan *response.Raw
-	requests      chan *request.Raw
+	ws             *websocket.Conn
+	done           chan struct{}
+	responses      chan *response.Raw
+	batchResponses chan []response.Raw
+	// requests is either *request.Raw or []request.Raw.
+	requests      chan interface{}
 	shutdown      chan struct{}
 	subscriptions map[string]bool
 }
 
 // Notification represents server-generated notification for client subscriptions.
-// Value can be one of block.Block, result.ApplicationLog, result.NotificationEvent
-// or transaction.Transaction based on Type.
+// Value can be one of block.Block, block.Header, result.ApplicationLog,
+// result.NotificationEvent or transaction.Transaction (for new transactions and
+// mempool events) based on Type.
 type Notification struct {
 	Type  response.EventID
 	Value interface{}
@@ -86,16 +89,18 @@ func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error
 		Client:        *cl,
 		Notifications: make(chan Notification),
 
-		ws:            ws,
-		shutdown:      make(chan struct{}),
-		done:          make(chan struct{}),
-		responses:     make(chan *response.Raw),
-		requests:      make(chan *request.Raw),
-		subscriptions: make(map[string]bool),
+		ws:             ws,
+		shutdown:       make(chan struct{}),
+		done:           make(chan struct{}),
+		responses:      make(chan *response.Raw),
+		batchResponses: make(chan []response.Raw),
+		requests:       make(chan interface{}),
+		subscriptions:  make(map[string]bool),
 	}
 	go wsc.wsReader()
 	go wsc.wsWriter()
 	wsc.requestF = wsc.makeWsRequest
+	wsc.batchF = wsc.makeWsBatchRequest
 	return wsc, nil
 }
 
@@ -113,20 +118,34 @@ func (c *WSClient) Close() {
 func (c *WSClient) wsReader() {
 	c.ws.SetReadLimit(wsReadLimit)
 	c.ws.SetPongHandler(func(string) error { c.ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
-readloop:
 	for {
-		rr := new(requestResponse)
+		var data json.RawMessage
 		c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
-		err := c.ws.ReadJSON(rr)
+		err := c.ws.ReadJSON(&data)
 		if err != nil {
 			// Timeout/connection loss/malformed response.
 			break
 		}
+		if len(data) != 0 && data[0] == '[' {
+			var batch []response.Raw
+			if err := json.Unmarshal(data, &batch); err != nil {
+				// Malformed batch response.
+				break
+			}
+			c.batchResponses <- batch
+			continue
+		}
+		rr := new(requestResponse)
+		if err := json.Unmarshal(data, rr); err != nil {
+			// Malformed response.
+			break
+		}
 		if rr.RawID == nil && rr.Method != "" {
 			event, err := response.GetEventIDFromString(rr.Method)
 			if err != nil {
-				// Bad event received.
-				break
+				// Unknown event (like the one registered by some
+				// server extension), it's not an error.
+				continue
 			}
 			var slice []json.RawMessage
 			err = json.Unmarshal(rr.RawParams, &slice)
@@ -138,8 +157,10 @@ readloop:
 			switch event {
 			case response.BlockEventID:
 				val = new(block.Block)
-			case response.TransactionEventID:
+			case response.TransactionEventID, response.MempoolAddedEventID, response.MempoolRemovedEventID:
 				val = new(transaction.Transaction)
+			case response.HeaderEventID:
+				val = new(block.Header)
 			case response.NotificationEventID:
 				val = new(result.NotificationEvent)
 			case response.ExecutionEventID:
@@ -147,8 +168,8 @@ readloop:
 			case response.MissedEventID:
 				// No value.
 			default:
-				// Bad event received.
-				break readloop
+				// Known, but not supported event, skip it.
+				continue
 			}
 			if event != response.MissedEventID {
 				err = json.Unmarshal(slice[0], val)
@@ -172,6 +193,7 @@ readloop:
 	}
 	close(c.done)
 	close(c.responses)
+	close(c.batchResponses)
 	close(c.Notifications)
 }
 
@@ -217,6 +239,26 @@ func (c *WSClient) makeWsRequest(r *request.Raw) (*response.Raw, error) {
 	}
 }
 
+func (c *WSClient) makeWsBatchRequest(r []request.Raw) ([]response.Raw, error) {
+	select {
+	case <-c.done:
+		return nil, errors.New("connection lost")
+	case c.requests <- r:
+	}
+	select {
+	case <-c.done:
+		return nil, errors.New("connection lost")
+	case resp := <-c.responses:
+		// Batch-level error.
+		if resp.Error != nil {
+			return nil, resp.Error
+		}
+		return nil, errors.New("unexpected response to batch request")
+	case resps := <-c.batchResponses:
+		return resps, nil
+	}
+}
+
 func (c *WSClient) performSubscription(params request.RawParams) (string, error) {
 	var resp string
 
@@ -247,22 +289,62 @@ func (c *WSClient) performUnsubscription(id string) error {
 // of client. It can filtered by primary consensus node index, nil value doesn't
 // add any filters.
 func (c *WSClient) SubscribeForNewBlocks(primary *int) (string, error) {
+	return c.SubscribeForNewBlocksWithOptions(primary, nil)
+}
+
+// SubscribeForNewBlocksWithOptions is the same as SubscribeForNewBlocks, but
+// also accepts subscription options, nil options are ignored. Non-nil
+// opts.FromBlock makes server send events for stored blocks starting from the
+// given one before the new ones.
+func (c *WSClient) SubscribeForNewBlocksWithOptions(primary *int, opts *request.SubscriptionOptions) (string, error) {
 	params := request.NewRawParams("block_added")
 	if primary != nil {
 		params.Values = append(params.Values, request.BlockFilter{Primary: *primary})
 	}
-	return c.performSubscription(params)
+	return c.performSubscriptionWithOptions(params, opts)
+}
+
+// SubscribeForNewHeaders adds subscription for new header events to this
+// instance of client.
+func (c *WSClient) SubscribeForNewHeaders() (string, error) {
+	return c.performSubscription(request.NewRawParams("header_added"))
 }
 
 // SubscribeForNewTransactions adds subscription for new transaction events to
 // this instance of client. It can be filtered by sender and/or cosigner, nil
 // value is treated as missing filter.
 func (c *WSClient) SubscribeForNewTransactions(sender *util.Uint160, cosigner *util.Uint160) (string, error) {
-	params := request.NewRawParams("transaction_added")
+	return c.SubscribeForNewTransactionsWithOptions(sender, cosigner, nil)
+}
+
+// SubscribeForNewTransactionsWithOptions is the same as
+// SubscribeForNewTransactions, but also accepts subscription options the same
+// way as SubscribeForNewBlocksWithOptions.
+func (c *WSClient) SubscribeForNewTransactionsWithOptions(sender *util.Uint160, cosigner *util.Uint160, opts *request.SubscriptionOptions) (string, error) {
+	return c.subscribeForTransactionEvents("transaction_added", sender, cosigner, opts)
+}
+
+// SubscribeForMempoolAdditions adds subscription for transactions added to
+// the server's memory pool. It can be filtered by sender and/or cosigner the
+// same way as SubscribeForNewTransactions.
+func (c *WSClient) SubscribeForMempoolAdditions(sender *util.Uint160, cosigner *util.Uint160) (string, error) {
+	return c.subscribeForTransactionEvents("mempool_added", sender, cosigner, nil)
+}
+
+// SubscribeForMempoolRemovals adds subscription for transactions removed from
+// the server's memory pool (because they're included into a block, become
+// invalid or are evicted by better ones). It can be filtered by sender and/or
+// cosigner the same way as SubscribeForNewTransactions.
+func (c *WSClient) SubscribeForMempoolRemovals(sender *util.Uint160, cosigner *util.Uint160) (string, error) {
+	return c.subscribeForTransactionEvents("mempool_removed", sender, cosigner, nil)
+}
+
+func (c *WSClient) subscribeForTransactionEvents(event string, sender *util.Uint160, cosigner *util.Uint160, opts *request.SubscriptionOptions) (string, error) {
+	params := request.NewRawParams(event)
 	if sender != nil || cosigner != nil {
 		params.Values = append(params.Values, request.TxFilter{Sender: sender, Cosigner: cosigner})
 	}
-	return c.performSubscription(params)
+	return c.performSubscriptionWithOptions(params, opts)
 }
 
 // SubscribeForExecutionNotifications adds subscription for notifications
@@ -270,11 +352,19 @@ func (c *WSClient) SubscribeForNewTransactions(sender *util.Uint160, cosigner *u
 // filtered by contract's hash (that emits notifications), nil value puts no such
 // restrictions.
 func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160) (string, error) {
+	return c.SubscribeForExecutionNotificationsWithOptions(contract, nil)
+}
+
+// SubscribeForExecutionNotificationsWithOptions is the same as
+// SubscribeForExecutionNotifications, but also accepts subscription options
+// the same way as SubscribeForNewBlocksWithOptions. Non-nil opts.Name
+// additionally filters notifications by event name.
+func (c *WSClient) SubscribeForExecutionNotificationsWithOptions(contract *util.Uint160, opts *request.SubscriptionOptions) (string, error) {
 	params := request.NewRawParams("notification_from_execution")
 	if contract != nil {
 		params.Values = append(params.Values, request.NotificationFilter{Contract: *contract})
 	}
-	return c.performSubscription(params)
+	return c.performSubscriptionWithOptions(params, opts)
 }
 
 // SubscribeForTransactionExecutions adds subscription for application execution
@@ -282,6 +372,13 @@ func (c *WSClient) SubscribeForExecutionNotifications(contract *util.Uint160) (s
 // be filtered by state (HALT/FAULT) to check for successful or failing
 // transactions, nil value means no filtering.
 func (c *WSClient) SubscribeForTransactionExecutions(state *string) (string, error) {
+	return c.SubscribeForTransactionExecutionsWithOptions(state, nil)
+}
+
+// SubscribeForTransactionExecutionsWithOptions is the same as
+// SubscribeForTransactionExecutions, but also accepts subscription options
+// the same way as SubscribeForNewBlocksWithOptions.
+func (c *WSClient) SubscribeForTransactionExecutionsWithOptions(state *string, opts *request.SubscriptionOptions) (string, error) {
 	params := request.NewRawParams("transaction_executed")
 	if state != nil {
 		if *state != "HALT" && *state != "FAULT" {
@@ -289,6 +386,15 @@ func (c *WSClient) SubscribeForTransactionExecutions(state *string) (string, err
 		}
 		params.Values = append(params.Values, request.ExecutionFilter{State: *state})
 	}
+	return c.performSubscriptionWithOptions(params, opts)
+}
+
+// performSubscriptionWithOptions appends non-empty subscription options to
+// the parameters and performs the subscription.
+func (c *WSClient) performSubscriptionWithOptions(params request.RawParams, opts *request.SubscriptionOptions) (string, error) {
+	if opts != nil && (opts.FromBlock != nil || opts.Name != nil) {
+		params.Values = append(params.Values, *opts)
+	}
 	return c.performSubscription(params)
 }
 
@@ -27,9 +27,18 @@ func TestWSClientSubscription(t *testing.T) {
 		"blocks": func(wsc *WSClient) (string, error) {
 			return wsc.SubscribeForNewBlocks(nil)
 		},
+		"headers": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForNewHeaders()
+		},
 		"transactions": func(wsc *WSClient) (string, error) {
 			return wsc.SubscribeForNewTransactions(nil, nil)
 		},
+		"mempool additions": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForMempoolAdditions(nil, nil)
+		},
+		"mempool removals": func(wsc *WSClient) (string, error) {
+			return wsc.SubscribeForMempoolRemovals(nil, nil)
+		},
 		"notifications": func(wsc *WSClient) (string, error) {
 			return wsc.SubscribeForExecutionNotifications(nil)
 		},
@@ -119,6 +128,9 @@ func TestWSClientEvents(t *testing.T) {
 		`{"jsonrpc":"2.0","method":"notification_from_execution","params":[{"contract":"0x1b4357bff5a01bdf2a6581247cf9ed1e24629176","state":{"type":"Array","value":[{"type":"ByteArray","value":"Y29udHJhY3QgY2FsbA=="},{"type":"ByteArray","value":"dHJhbnNmZXI="},{"type":"Array","value":[{"type":"ByteArray","value":"dpFiJB7t+XwkgWUq3xug9b9XQxs="},{"type":"ByteArray","value":"MW6FEDkBnTnfwsN9bD/uGf1YCYc="},{"type":"Integer","value":"1000"}]}]}}]}`,
 		`{"jsonrpc":"2.0","method":"transaction_added","params":[{"txid":"0x1c615d4043c98fc0e285c2f40cc3601cf4ebe1cf9d2b404dfc67c9cd085444ec","size":265,"version":0,"nonce":9,"sender":"ALHF9wsXZVEuCGgmDA6ZNsCLtrb4A1g4yG","sys_fee":"0","net_fee":"0.0036521","valid_until_block":1200,"attributes":[],"cosigners":[{"account":"0x870958fd19ee3f6c7dc3c2df399d013910856e31","scopes":1}],"script":"AHsMFCBygnSvr8NvQ6Bx0yjPo+Yp2cuwDBQxboUQOQGdOd/Cw31sP+4Z/VgJhxPADAh0cmFuc2ZlcgwUdpFiJB7t+XwkgWUq3xug9b9XQxtBYn1bUjg=","scripts":[{"invocation":"DEA00C87l6Ig/+eWQOSCuIfsDkTcyV5xn14rQ7KZh/DJgiua8EmdkAlMatO6GR5DSj313TeNO3MxjPR8ny1tgBzI","verification":"DCECs2Ir9AF73+MXxYrtX0x1PyBrfbiWBG+n13S7xL9/jcILQQqQatQ="}]}]}`,
 		`{"jsonrpc":"2.0","method":"block_added","params":[{"hash":"0x765ea65b4de6addfee29b1c90ac922d1901c8d7ab7f2366da9a8ad3dd71ca703","version":0,"previousblockhash":"0xbdeed527a43ab72d5d8cecf1dc6ee142112ff8a8eaaaebc7206d3df3bf3c1169","merkleroot":"0xa1b321f59b127cddd23b0cd47fc9ec7920647d30d7ab23318a106597b9c9abad","time":1591366176006,"index":6,"nextconsensus":"AXSvJVzydxXuL9da4GVwK25zdesCrVKkHL","witnesses":[{"invocation":"DEDaH0tUaopg6WWWNRI013CTkYZrs1kKKQEzvAxFg38drGNR7jJQan4Lv2/LzD7AEiLM/oS8HUBxIh9MQy6/VptiDEDuWQYygBKopKQR5/ojqouiH+24GxFYHloofK2WH6NtKiCyBpVJpaFIYNnprjZA6iD5GR1gq3wq7d9D7dbavlWMDED1OR5559YvfMqpAFEdUw+J3hg/pRvEr3RL2oH3Y+FN3X+5U+abCQFmDUdS8kDVJpNE0LZLULEk0aMWrXJIbaFeDEABL3c/rvKu5K9Z4IO0Q+vmz0BNEvSdMpZsX0jywgPihEKWFaotNMgnNW1Vw74WEvZ6W3Jfb/Sbm5Wx9gMGpytx","verification":"EwwhAhA6f33QFlWFl/eWDSfFFqQ5T9loueZRVetLAT5AQEBuDCECp7xV/oaE4BGXaNEEujB5W9zIZhnoZK3SYVZyPtGFzWIMIQKzYiv0AXvf4xfFiu1fTHU/IGt9uJYEb6fXdLvEv3+NwgwhA9kMB99j5pDOd5EuEKtRrMlEtmhgI3tgjE+PgwnnHuaZFAtBMHOzuw=="}],"consensus_data":{"primary":0,"nonce":"0000000000000457"},"tx":[{"txid":"0x1c615d4043c98fc0e285c2f40cc3601cf4ebe1cf9d2b404dfc67c9cd085444ec","size":265,"version":0,"nonce":9,"sender":"ALHF9wsXZVEuCGgmDA6ZNsCLtrb4A1g4yG","sys_fee":"0","net_fee":"0.0036521","valid_until_block":1200,"attributes":[],"cosigners":[{"account":"0x870958fd19ee3f6c7dc3c2df399d013910856e31","scopes":1}],"script":"AHsMFCBygnSvr8NvQ6Bx0yjPo+Yp2cuwDBQxboUQOQGdOd/Cw31sP+4Z/VgJhxPADAh0cmFuc2ZlcgwUdpFiJB7t+XwkgWUq3xug9b9XQxtBYn1bUjg=","scripts":[{"invocation":"DEA00C87l6Ig/+eWQOSCuIfsDkTcyV5xn14rQ7KZh/DJgiua8EmdkAlMatO6GR5DSj313TeNO3MxjPR8ny1tgBzI","verification":"DCECs2Ir9AF73+MXxYrtX0x1PyBrfbiWBG+n13S7xL9/jcILQQqQatQ="}]}]}]}`,
+		`{"jsonrpc":"2.0","method":"header_added","params":[{"hash":"0x765ea65b4de6addfee29b1c90ac922d1901c8d7ab7f2366da9a8ad3dd71ca703","version":0,"previousblockhash":"0xbdeed527a43ab72d5d8cecf1dc6ee142112ff8a8eaaaebc7206d3df3bf3c1169","merkleroot":"0xa1b321f59b127cddd23b0cd47fc9ec7920647d30d7ab23318a106597b9c9abad","time":1591366176006,"index":6,"nextconsensus":"AXSvJVzydxXuL9da4GVwK25zdesCrVKkHL","witnesses":[{"invocation":"DEDaH0tUaopg6WWWNRI013CTkYZrs1kKKQEzvAxFg38drGNR7jJQan4Lv2/LzD7AEiLM/oS8HUBxIh9MQy6/VptiDEDuWQYygBKopKQR5/ojqouiH+24GxFYHloofK2WH6NtKiCyBpVJpaFIYNnprjZA6iD5GR1gq3wq7d9D7dbavlWMDED1OR5559YvfMqpAFEdUw+J3hg/pRvEr3RL2oH3Y+FN3X+5U+abCQFmDUdS8kDVJpNE0LZLULEk0aMWrXJIbaFeDEABL3c/rvKu5K9Z4IO0Q+vmz0BNEvSdMpZsX0jywgPihEKWFaotNMgnNW1Vw74WEvZ6W3Jfb/Sbm5Wx9gMGpytx","verification":"EwwhAhA6f33QFlWFl/eWDSfFFqQ5T9loueZRVetLAT5AQEBuDCECp7xV/oaE4BGXaNEEujB5W9zIZhnoZK3SYVZyPtGFzWIMIQKzYiv0AXvf4xfFiu1fTHU/IGt9uJYEb6fXdLvEv3+NwgwhA9kMB99j5pDOd5EuEKtRrMlEtmhgI3tgjE+PgwnnHuaZFAtBMHOzuw=="}]}]}`,
+		`{"jsonrpc":"2.0","method":"mempool_added","params":[{"txid":"0x1c615d4043c98fc0e285c2f40cc3601cf4ebe1cf9d2b404dfc67c9cd085444ec","size":265,"version":0,"nonce":9,"sender":"ALHF9wsXZVEuCGgmDA6ZNsCLtrb4A1g4yG","sys_fee":"0","net_fee":"0.0036521","valid_until_block":1200,"attributes":[],"cosigners":[{"account":"0x870958fd19ee3f6c7dc3c2df399d013910856e31","scopes":1}],"script":"AHsMFCBygnSvr8NvQ6Bx0yjPo+Yp2cuwDBQxboUQOQGdOd/Cw31sP+4Z/VgJhxPADAh0cmFuc2ZlcgwUdpFiJB7t+XwkgWUq3xug9b9XQxtBYn1bUjg=","scripts":[{"invocation":"DEA00C87l6Ig/+eWQOSCuIfsDkTcyV5xn14rQ7KZh/DJgiua8EmdkAlMatO6GR5DSj313TeNO3MxjPR8ny1tgBzI","verification":"DCECs2Ir9AF73+MXxYrtX0x1PyBrfbiWBG+n13S7xL9/jcILQQqQatQ="}]}]}`,
+		`{"jsonrpc":"2.0","method":"mempool_removed","params":[{"txid":"0x1c615d4043c98fc0e285c2f40cc3601cf4ebe1cf9d2b404dfc67c9cd085444ec","size":265,"version":0,"nonce":9,"sender":"ALHF9wsXZVEuCGgmDA6ZNsCLtrb4A1g4yG","sys_fee":"0","net_fee":"0.0036521","valid_until_block":1200,"attributes":[],"cosigners":[{"account":"0x870958fd19ee3f6c7dc3c2df399d013910856e31","scopes":1}],"script":"AHsMFCBygnSvr8NvQ6Bx0yjPo+Yp2cuwDBQxboUQOQGdOd/Cw31sP+4Z/VgJhxPADAh0cmFuc2ZlcgwUdpFiJB7t+XwkgWUq3xug9b9XQxtBYn1bUjg=","scripts":[{"invocation":"DEA00C87l6Ig/+eWQOSCuIfsDkTcyV5xn14rQ7KZh/DJgiua8EmdkAlMatO6GR5DSj313TeNO3MxjPR8ny1tgBzI","verification":"DCECs2Ir9AF73+MXxYrtX0x1PyBrfbiWBG+n13S7xL9/jcILQQqQatQ="}]}]}`,
 		`{"jsonrpc":"2.0","method":"event_missed","params":[]}`,
 	}
 	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -126,6 +138,9 @@ func TestWSClientEvents(t *testing.T) {
 			var upgrader = websocket.Upgrader{}
 			ws, err := upgrader.Upgrade(w, req, nil)
 			require.NoError(t, err)
+			// Unknown events are skipped by the client.
+			ws.SetWriteDeadline(time.Now().Add(2 * time.Second))
+			require.NoError(t, ws.WriteMessage(1, []byte(`{"jsonrpc":"2.0","method":"test_event","params":[{"value":42}]}`)))
 			for _, event := range events {
 				ws.SetWriteDeadline(time.Now().Add(2 * time.Second))
 				err = ws.WriteMessage(1, []byte(event))
@@ -188,6 +203,24 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				filt, ok := param.Value.(request.BlockFilter)
 				require.Equal(t, true, ok)
 				require.Equal(t, 3, filt.Primary)
+				_, ok = p.Value(2)
+				require.False(t, ok)
+			},
+		},
+		{"blocks from block",
+			func(t *testing.T, wsc *WSClient) {
+				from := uint32(5)
+				_, err := wsc.SubscribeForNewBlocksWithOptions(nil, &request.SubscriptionOptions{FromBlock: &from})
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param, ok := p.Value(1)
+				require.Equal(t, true, ok)
+				require.Equal(t, request.SubscriptionOptionsT, param.Type)
+				opts, ok := param.Value.(request.SubscriptionOptions)
+				require.Equal(t, true, ok)
+				require.Equal(t, uint32(5), *opts.FromBlock)
+				require.Nil(t, opts.Name)
 			},
 		},
 		{"transactions sender",
@@ -239,6 +272,67 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, util.Uint160{0, 42}, *filt.Cosigner)
 			},
 		},
+		{"transactions sender from block",
+			func(t *testing.T, wsc *WSClient) {
+				sender := util.Uint160{1, 2, 3, 4, 5}
+				from := uint32(1)
+				_, err := wsc.SubscribeForNewTransactionsWithOptions(&sender, nil, &request.SubscriptionOptions{FromBlock: &from})
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param, ok := p.Value(1)
+				require.Equal(t, true, ok)
+				require.Equal(t, request.TxFilterT, param.Type)
+				filt, ok := param.Value.(request.TxFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, util.Uint160{1, 2, 3, 4, 5}, *filt.Sender)
+				require.Nil(t, filt.Cosigner)
+				param, ok = p.Value(2)
+				require.Equal(t, true, ok)
+				require.Equal(t, request.SubscriptionOptionsT, param.Type)
+				opts, ok := param.Value.(request.SubscriptionOptions)
+				require.Equal(t, true, ok)
+				require.Equal(t, uint32(1), *opts.FromBlock)
+			},
+		},
+		{"mempool additions sender",
+			func(t *testing.T, wsc *WSClient) {
+				sender := util.Uint160{1, 2, 3, 4, 5}
+				_, err := wsc.SubscribeForMempoolAdditions(&sender, nil)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				name, ok := p.Value(0)
+				require.Equal(t, true, ok)
+				require.Equal(t, "mempool_added", name.Value)
+				param, ok := p.Value(1)
+				require.Equal(t, true, ok)
+				require.Equal(t, request.TxFilterT, param.Type)
+				filt, ok := param.Value.(request.TxFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, util.Uint160{1, 2, 3, 4, 5}, *filt.Sender)
+				require.Nil(t, filt.Cosigner)
+			},
+		},
+		{"mempool removals cosigner",
+			func(t *testing.T, wsc *WSClient) {
+				cosigner := util.Uint160{0, 42}
+				_, err := wsc.SubscribeForMempoolRemovals(nil, &cosigner)
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				name, ok := p.Value(0)
+				require.Equal(t, true, ok)
+				require.Equal(t, "mempool_removed", name.Value)
+				param, ok := p.Value(1)
+				require.Equal(t, true, ok)
+				require.Equal(t, request.TxFilterT, param.Type)
+				filt, ok := param.Value.(request.TxFilter)
+				require.Equal(t, true, ok)
+				require.Nil(t, filt.Sender)
+				require.Equal(t, util.Uint160{0, 42}, *filt.Cosigner)
+			},
+		},
 		{"notifications",
 			func(t *testing.T, wsc *WSClient) {
 				contract := util.Uint160{1, 2, 3, 4, 5}
@@ -254,6 +348,30 @@ func TestWSFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, util.Uint160{1, 2, 3, 4, 5}, filt.Contract)
 			},
 		},
+		{"notifications name",
+			func(t *testing.T, wsc *WSClient) {
+				contract := util.Uint160{1, 2, 3, 4, 5}
+				name := "transfer"
+				from := uint32(3)
+				_, err := wsc.SubscribeForExecutionNotificationsWithOptions(&contract, &request.SubscriptionOptions{Name: &name, FromBlock: &from})
+				require.NoError(t, err)
+			},
+			func(t *testing.T, p *request.Params) {
+				param, ok := p.Value(1)
+				require.Equal(t, true, ok)
+				require.Equal(t, request.NotificationFilterT, param.Type)
+				filt, ok := param.Value.(request.NotificationFilter)
+				require.Equal(t, true, ok)
+				require.Equal(t, util.Uint160{1, 2, 3, 4, 5}, filt.Contract)
+				param, ok = p.Value(2)
+				require.Equal(t, true, ok)
+				require.Equal(t, request.SubscriptionOptionsT, param.Type)
+				opts, ok := param.Value.(request.SubscriptionOptions)
+				require.Equal(t, true, ok)
+				require.Equal(t, "transfer", *opts.Name)
+				require.Equal(t, uint32(3), *opts.FromBlock)
+			},
+		},
 		{"executions",
 			func(t *testing.T, wsc *WSClient) {
 				state := "FAULT"
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -35,14 +37,15 @@ type (
 		Primary int `json:"primary"`
 	}
 	// TxFilter is a wrapper structure for transaction event filter. It
-	// allows to filter transactions by senders and cosigners.
+	// allows to filter transactions by senders and cosigners, it's also
+	// used for mempool events.
 	TxFilter struct {
 		Sender   *util.Uint160 `json:"sender,omitempty"`
 		Cosigner *util.Uint160 `json:"cosigner,omitempty"`
 	}
 	// NotificationFilter is a wrapper structure representing filter used for
 	// notifications generated during transaction execution. Notifications can
-	// only be filtered by contract hash.
+	// only be filtered by contract hash (see also SubscriptionOptions).
 	NotificationFilter struct {
 		Contract util.Uint160 `json:"contract"`
 	}
@@ -52,6 +55,29 @@ type (
 	ExecutionFilter struct {
 		State string `json:"state"`
 	}
+	// SubscriptionOptions is a wrapper structure for additional subscription
+	// parameters passed after the event filter (or instead of it). FromBlock
+	// requests events from stored blocks starting with the given one to be
+	// sent before the new ones, it's accepted for block, transaction,
+	// notification and execution events. Name filters notifications by
+	// event name.
+	SubscriptionOptions struct {
+		FromBlock *uint32 `json:"fromBlock,omitempty"`
+		Name      *string `json:"name,omitempty"`
+	}
+	// Signer is a wrapper structure for transaction signer used for test
+	// invocations. It's a cosigner with an optional witness.
+	Signer struct {
+		transaction.Cosigner
+		transaction.Witness
+	}
+	// Transfer is a wrapper structure for transfer outputs used by wallet
+	// methods. Value is a decimal string amount of the asset.
+	Transfer struct {
+		Asset   util.Uint160 `json:"asset"`
+		Address string       `json:"address"`
+		Value   string       `json:"value"`
+	}
 )
 
 // These are parameter types accepted by RPC server.
@@ -65,6 +91,9 @@ const (
 	TxFilterT
 	NotificationFilterT
 	ExecutionFilterT
+	SubscriptionOptionsT
+	SignerT
+	TransferT
 )
 
 func (p Param) String() string {
@@ -107,7 +136,7 @@ func (p Param) GetUint256() (util.Uint256, error) {
 		return util.Uint256{}, err
 	}
 
-	return util.Uint256DecodeStringLE(s)
+	return util.Uint256DecodeStringLE(strings.TrimPrefix(s, "0x"))
 }
 
 // GetUint160FromHex returns Uint160 value of the parameter encoded in hex.
@@ -143,6 +172,24 @@ func (p Param) GetFuncParam() (FuncParam, error) {
 	return fp, nil
 }
 
+// GetSigner returns current parameter as a test invocation signer.
+func (p Param) GetSigner() (Signer, error) {
+	sgn, ok := p.Value.(Signer)
+	if !ok {
+		return Signer{}, errors.New("not a signer")
+	}
+	return sgn, nil
+}
+
+// GetTransfer returns current parameter as a transfer output.
+func (p Param) GetTransfer() (Transfer, error) {
+	tr, ok := p.Value.(Transfer)
+	if !ok {
+		return Transfer{}, errors.New("not a transfer")
+	}
+	return tr, nil
+}
+
 // GetBytesHex returns []byte value of the parameter if
 // it is a hex-encoded string.
 func (p Param) GetBytesHex() ([]byte, error) {
@@ -167,6 +214,9 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 		{TxFilterT, &TxFilter{}},
 		{NotificationFilterT, &NotificationFilter{}},
 		{ExecutionFilterT, &ExecutionFilter{}},
+		{SubscriptionOptionsT, &SubscriptionOptions{}},
+		{SignerT, &Signer{}},
+		{TransferT, &Transfer{}},
 		{ArrayT, &[]Param{}},
 	}
 
@@ -196,6 +246,12 @@ func (p *Param) UnmarshalJSON(data []byte) error {
 				} else {
 					continue
 				}
+			case *SubscriptionOptions:
+				p.Value = *val
+			case *Signer:
+				p.Value = *val
+			case *Transfer:
+				p.Value = *val
 			case *[]Param:
 				p.Value = *val
 			}
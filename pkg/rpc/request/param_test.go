@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -19,9 +20,17 @@ func TestParam_UnmarshalJSON(t *testing.T) {
                  {"cosigner": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
                  {"sender": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "cosigner": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
                  {"contract": "f84d6a337fbc3d3a201d41da99e86b479e7a2554"},
-                 {"state": "HALT"}]`
+                 {"state": "HALT"},
+                 {"fromBlock": 5},
+                 {"name": "Transfer", "fromBlock": 5},
+                 {"account": "0xf84d6a337fbc3d3a201d41da99e86b479e7a2554", "scopes": 1, "verification": "EQ=="},
+                 {"asset": "f84d6a337fbc3d3a201d41da99e86b479e7a2554", "address": "AKkkumHbBipZ46UMZJoFynJMXzSRnBvKcs", "value": "1.5"}]`
 	contr, err := util.Uint160DecodeStringLE("f84d6a337fbc3d3a201d41da99e86b479e7a2554")
 	require.NoError(t, err)
+	var (
+		name      = "Transfer"
+		fromBlock = uint32(5)
+	)
 	expected := Params{
 		{
 			Type:  StringT,
@@ -83,6 +92,34 @@ func TestParam_UnmarshalJSON(t *testing.T) {
 			Type:  ExecutionFilterT,
 			Value: ExecutionFilter{State: "HALT"},
 		},
+		{
+			Type:  SubscriptionOptionsT,
+			Value: SubscriptionOptions{FromBlock: &fromBlock},
+		},
+		{
+			Type:  SubscriptionOptionsT,
+			Value: SubscriptionOptions{Name: &name, FromBlock: &fromBlock},
+		},
+		{
+			Type: SignerT,
+			Value: Signer{
+				Cosigner: transaction.Cosigner{
+					Account: contr,
+					Scopes:  transaction.CalledByEntry,
+				},
+				Witness: transaction.Witness{
+					VerificationScript: []byte{0x11},
+				},
+			},
+		},
+		{
+			Type: TransferT,
+			Value: Transfer{
+				Asset:   contr,
+				Address: "AKkkumHbBipZ46UMZJoFynJMXzSRnBvKcs",
+				Value:   "1.5",
+			},
+		},
 	}
 
 	var ps Params
@@ -198,6 +235,30 @@ func TestParamGetFuncParam(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestParamGetSigner(t *testing.T) {
+	sgn := Signer{Cosigner: transaction.Cosigner{Account: util.Uint160{1, 2, 3}}}
+	p := Param{SignerT, sgn}
+	newsgn, err := p.GetSigner()
+	require.NoError(t, err)
+	require.Equal(t, sgn, newsgn)
+
+	p = Param{StringT, "jajaja"}
+	_, err = p.GetSigner()
+	require.Error(t, err)
+}
+
+func TestParamGetTransfer(t *testing.T) {
+	tr := Transfer{Asset: util.Uint160{1, 2, 3}, Address: "AKkkumHbBipZ46UMZJoFynJMXzSRnBvKcs", Value: "42"}
+	p := Param{TransferT, tr}
+	newtr, err := p.GetTransfer()
+	require.NoError(t, err)
+	require.Equal(t, tr, newtr)
+
+	p = Param{StringT, "jajaja"}
+	_, err = p.GetTransfer()
+	require.Error(t, err)
+}
+
 func TestParamGetBytesHex(t *testing.T) {
 	in := "602c79718b16e442de58778e148d0b1084e3b2dffd5de6b7b16cee7969282de7"
 	inb, _ := hex.DecodeString(in)
@@ -1,6 +1,7 @@
 package request
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 
@@ -46,6 +47,57 @@ type In struct {
 	RawID     json.RawMessage `json:"id,omitempty"`
 }
 
+// IsNotification returns true if r is a notification, that is a request
+// without an id which must not be replied to.
+func (r *In) IsNotification() bool {
+	return len(r.RawID) == 0
+}
+
+// Batch represents a standard JSON-RPC 2.0
+// batch: https://www.jsonrpc.org/specification#batch.
+type Batch []In
+
+// Request is either a single JSON-RPC 2.0 request or a batch of them, it's
+// used in server to represent incoming queries. Exactly one of In and Batch
+// is set for successfully decoded request.
+type Request struct {
+	In    *In
+	Batch Batch
+}
+
+// DecodeData decodes the given reader into the request (which can be either
+// a single request or a batch).
+func (r *Request) DecodeData(data io.ReadCloser) error {
+	defer data.Close()
+
+	err := json.NewDecoder(data).Decode(r)
+	if err != nil {
+		return errors.Errorf("error parsing JSON payload: %s", err)
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface.
+func (r *Request) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) != 0 && data[0] == '[' {
+		var batch Batch
+		if err := json.Unmarshal(data, &batch); err != nil {
+			return err
+		}
+		r.In = nil
+		r.Batch = batch
+		return nil
+	}
+	in := new(In)
+	if err := json.Unmarshal(data, in); err != nil {
+		return err
+	}
+	r.In = in
+	r.Batch = nil
+	return nil
+}
+
 // NewIn creates a new Request struct.
 func NewIn() *In {
 	return &In{
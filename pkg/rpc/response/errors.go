@@ -30,8 +30,29 @@ var (
 	ErrValidationFailed = NewSubmitError(-504, "Block or transaction validation failed.")
 	// ErrPolicyFail represents SubmitError with code -505
 	ErrPolicyFail = NewSubmitError(-505, "One of the Policy filters failed.")
+	// ErrConflict represents SubmitError with code -506
+	ErrConflict = NewSubmitError(-506, "Transaction conflicts with the memory pool.")
+	// ErrDuplicate represents SubmitError with code -507
+	ErrDuplicate = NewSubmitError(-507, "Transaction is already in the memory pool.")
 	// ErrUnknown represents SubmitError with code -500
 	ErrUnknown = NewSubmitError(-500, "Unknown error.")
+	// ErrUnauthorized is returned for requests without valid credentials.
+	ErrUnauthorized = NewError(-32001, http.StatusUnauthorized, "Unauthorized", "", nil)
+	// ErrRateLimitExceeded is returned when client makes too many calls.
+	ErrRateLimitExceeded = NewError(-32003, http.StatusTooManyRequests, "Rate limit exceeded", "", nil)
+)
+
+// Reasons of transaction rejection used as data of SubmitErrors returned by
+// sendrawtransaction, they can be followed by ": " and error details.
+const (
+	ReasonAlreadyExists    = "already_exists"
+	ReasonOutOfMemory      = "out_of_memory"
+	ReasonUnableToVerify   = "unable_to_verify"
+	ReasonValidationFailed = "validation_failed"
+	ReasonPolicyFail       = "policy_fail"
+	ReasonConflict         = "conflict"
+	ReasonDuplicate        = "duplicate"
+	ReasonUnknown          = "unknown"
 )
 
 // NewError is an Error constructor that takes Error contents from its
@@ -77,6 +98,13 @@ func NewInternalServerError(data string, cause error) *Error {
 	return NewError(-32603, http.StatusInternalServerError, "Internal error", data, cause)
 }
 
+// NewForbiddenMethodError creates a new error with
+// code -32002, it's returned for methods disabled by the server
+// configuration.
+func NewForbiddenMethodError(data string) *Error {
+	return NewError(-32002, http.StatusForbidden, "Method is not allowed", data, nil)
+}
+
 // NewRPCError creates a new error with
 // code -100
 func NewRPCError(message string, data string, cause error) *Error {
@@ -23,6 +23,12 @@ const (
 	NotificationEventID
 	// ExecutionEventID is used for `transaction_executed` events.
 	ExecutionEventID
+	// HeaderEventID is a `header_added` event.
+	HeaderEventID
+	// MempoolAddedEventID corresponds to `mempool_added` event.
+	MempoolAddedEventID
+	// MempoolRemovedEventID corresponds to `mempool_removed` event.
+	MempoolRemovedEventID
 	// MissedEventID notifies user of missed events.
 	MissedEventID EventID = 255
 )
@@ -38,6 +44,12 @@ func (e EventID) String() string {
 		return "notification_from_execution"
 	case ExecutionEventID:
 		return "transaction_executed"
+	case HeaderEventID:
+		return "header_added"
+	case MempoolAddedEventID:
+		return "mempool_added"
+	case MempoolRemovedEventID:
+		return "mempool_removed"
 	case MissedEventID:
 		return "event_missed"
 	default:
@@ -56,6 +68,12 @@ func GetEventIDFromString(s string) (EventID, error) {
 		return NotificationEventID, nil
 	case "transaction_executed":
 		return ExecutionEventID, nil
+	case "header_added":
+		return HeaderEventID, nil
+	case "mempool_added":
+		return MempoolAddedEventID, nil
+	case "mempool_removed":
+		return MempoolRemovedEventID, nil
 	case "event_missed":
 		return MissedEventID, nil
 	default:
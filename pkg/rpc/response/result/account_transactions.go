@@ -0,0 +1,21 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// AccountTransactions is a result of getaccounttransactions call.
+type AccountTransactions struct {
+	Address      string               `json:"address"`
+	Transactions []AccountTransaction `json:"transactions"`
+	// Truncated is set if there are more transactions after this page.
+	Truncated bool `json:"truncated"`
+}
+
+// AccountTransaction is a reference to the transaction account was a sender
+// or a cosigner of.
+type AccountTransaction struct {
+	TxHash     util.Uint256 `json:"txid"`
+	BlockIndex uint32       `json:"blockindex"`
+	Timestamp  uint64       `json:"timestamp"`
+}
@@ -0,0 +1,39 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ContractNotifications is a page of contract notifications returned by
+// getcontractnotifications call.
+type ContractNotifications struct {
+	Notifications []ContractNotification `json:"notifications"`
+	// Truncated is set if there are more notifications after this page.
+	Truncated bool `json:"truncated"`
+}
+
+// ContractNotification is a notification along with the block and the
+// transaction it was emitted in.
+type ContractNotification struct {
+	BlockIndex uint32       `json:"blockindex"`
+	TxHash     util.Uint256 `json:"txid"`
+	NotificationEvent
+}
+
+// NewContractNotifications creates ContractNotifications from the given
+// notifications.
+func NewContractNotifications(ns []state.ContractNotification, truncated bool) *ContractNotifications {
+	res := &ContractNotifications{
+		Notifications: make([]ContractNotification, 0, len(ns)),
+		Truncated:     truncated,
+	}
+	for _, n := range ns {
+		res.Notifications = append(res.Notifications, ContractNotification{
+			BlockIndex:        n.BlockIndex,
+			TxHash:            n.TxHash,
+			NotificationEvent: StateEventToResultNotification(n.NotificationEvent),
+		})
+	}
+	return res
+}
@@ -0,0 +1,18 @@
+package result
+
+// FindStorage is a page of contract storage items returned by findstorage
+// call.
+type FindStorage struct {
+	Results []KeyValue `json:"results"`
+	// Next is the hex-encoded key the next page starts with, it's empty if
+	// there are no more items.
+	Next string `json:"next,omitempty"`
+	// Truncated is set if there are more items after this page.
+	Truncated bool `json:"truncated"`
+}
+
+// KeyValue is a contract storage item with hex-encoded key and value.
+type KeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
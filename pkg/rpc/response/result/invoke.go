@@ -1,14 +1,67 @@
 package result
 
 import (
+	"encoding/hex"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
 // Invoke represents code invocation result and is used by several RPC calls
 // that invoke functions, scripts and generic bytecode.
 type Invoke struct {
-	State       string                    `json:"state"`
-	GasConsumed string                    `json:"gas_consumed"`
-	Script      string                    `json:"script"`
-	Stack       []smartcontract.Parameter `json:"stack"`
+	State          string                    `json:"state"`
+	GasConsumed    string                    `json:"gas_consumed"`
+	Script         string                    `json:"script"`
+	Stack          []smartcontract.Parameter `json:"stack"`
+	FaultException string                    `json:"exception,omitempty"`
+	Notifications  []NotificationEvent       `json:"notifications,omitempty"`
+	Logs           []Log                     `json:"logs,omitempty"`
+	StorageChanges []StorageChange           `json:"storage_changes,omitempty"`
+	// Session is an ID of iterator session, it's only set if there are
+	// iterators on the resulting stack (see traverseiterator).
+	Session string `json:"session,omitempty"`
+}
+
+// Log represents a message logged during invocation.
+type Log struct {
+	Contract util.Uint160 `json:"contract"`
+	Message  string       `json:"message"`
+}
+
+// StorageChange represents a contract storage change made during invocation,
+// key and value are hex-encoded.
+type StorageChange struct {
+	Contract util.Uint160 `json:"contract"`
+	Key      string       `json:"key"`
+	Value    string       `json:"value,omitempty"`
+	Deleted  bool         `json:"deleted,omitempty"`
+}
+
+// NewInvoke creates Invoke from the given test execution result of the
+// script.
+func NewInvoke(res *state.TestExecResult, script []byte) *Invoke {
+	inv := &Invoke{
+		State:          res.VMState,
+		GasConsumed:    res.GasConsumed.String(),
+		Script:         hex.EncodeToString(script),
+		Stack:          res.Stack,
+		FaultException: res.FaultException,
+	}
+	for _, e := range res.Events {
+		inv.Notifications = append(inv.Notifications, StateEventToResultNotification(e))
+	}
+	for _, l := range res.Logs {
+		inv.Logs = append(inv.Logs, Log{Contract: l.ScriptHash, Message: l.Message})
+	}
+	for _, c := range res.StorageChanges {
+		inv.StorageChanges = append(inv.StorageChanges, StorageChange{
+			Contract: c.ScriptHash,
+			Key:      hex.EncodeToString(c.Key),
+			Value:    hex.EncodeToString(c.Value),
+			Deleted:  c.Value == nil,
+		})
+	}
+	return inv
 }
@@ -31,6 +31,9 @@ type NEP5Transfers struct {
 	Sent     []NEP5Transfer `json:"sent"`
 	Received []NEP5Transfer `json:"received"`
 	Address  string         `json:"address"`
+	// Truncated is set if there are more transfers in the requested time
+	// range than the limit allows to return.
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 // NEP5Transfer represents single NEP5 transfer event.
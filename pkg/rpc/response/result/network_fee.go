@@ -0,0 +1,10 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NetworkFee represents a result of calculatenetworkfee RPC call.
+type NetworkFee struct {
+	Value util.Fixed8 `json:"networkfee"`
+}
@@ -0,0 +1,54 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// TransactionTrace is a result of the tracetransaction call, it contains
+// the transaction execution result along with the instructions executed.
+type TransactionTrace struct {
+	TxHash      util.Uint256              `json:"txid"`
+	VMState     string                    `json:"vmstate"`
+	GasConsumed util.Fixed8               `json:"gas_consumed"`
+	Stack       []smartcontract.Parameter `json:"stack"`
+	Steps       []TraceStep               `json:"steps"`
+	// Truncated is true if there were more instructions executed than
+	// returned in Steps.
+	Truncated bool `json:"truncated"`
+}
+
+// TraceStep represents a single instruction executed.
+type TraceStep struct {
+	IP          int          `json:"ip"`
+	Opcode      string       `json:"opcode"`
+	ScriptHash  util.Uint160 `json:"contract"`
+	GasConsumed util.Fixed8  `json:"gas_consumed"`
+	StackDepth  int          `json:"stack_depth"`
+}
+
+// NewTraceStep converts vm.TraceStep to TraceStep.
+func NewTraceStep(s vm.TraceStep) TraceStep {
+	return TraceStep{
+		IP:          s.IP,
+		Opcode:      s.Opcode.String(),
+		ScriptHash:  s.ScriptHash,
+		GasConsumed: s.GasConsumed,
+		StackDepth:  s.StackDepth,
+	}
+}
+
+// NewTransactionTrace creates a new TransactionTrace from the transaction
+// execution result and steps traced.
+func NewTransactionTrace(aer *state.AppExecResult, steps []TraceStep, truncated bool) *TransactionTrace {
+	return &TransactionTrace{
+		TxHash:      aer.TxHash,
+		VMState:     aer.VMState,
+		GasConsumed: aer.GasConsumed,
+		Stack:       aer.Stack,
+		Steps:       steps,
+		Truncated:   truncated,
+	}
+}
@@ -0,0 +1,15 @@
+package result
+
+// WalletAddress is an account of the wallet opened by the RPC server.
+type WalletAddress struct {
+	Address   string `json:"address"`
+	HasKey    bool   `json:"haskey"`
+	Label     string `json:"label"`
+	WatchOnly bool   `json:"watchonly"`
+}
+
+// WalletBalance is a balance of some asset summed over all accounts of the
+// wallet opened by the RPC server.
+type WalletBalance struct {
+	Balance string `json:"balance"`
+}
@@ -26,6 +26,30 @@ type Raw struct {
 	Result json.RawMessage `json:"result,omitempty"`
 }
 
+// AbstractResult is either a single JSON-RPC 2.0 response (Raw) or a batch
+// of them (RawBatch).
+type AbstractResult interface {
+	// RunForErrors calls f for every error contained in the result.
+	RunForErrors(f func(jsonErr *Error))
+}
+
+// RunForErrors implements AbstractResult interface.
+func (r Raw) RunForErrors(f func(jsonErr *Error)) {
+	if r.Error != nil {
+		f(r.Error)
+	}
+}
+
+// RawBatch represents a standard JSON-RPC 2.0 batch response.
+type RawBatch []Raw
+
+// RunForErrors implements AbstractResult interface.
+func (rb RawBatch) RunForErrors(f func(jsonErr *Error)) {
+	for _, r := range rb {
+		r.RunForErrors(f)
+	}
+}
+
 // GetRawTx represents verbose output of `getrawtransaction` RPC call.
 type GetRawTx struct {
 	HeaderAndError
@@ -2,17 +2,109 @@ package rpc
 
 import "github.com/nspcc-dev/neo-go/pkg/util"
 
+const (
+	// DefaultMaxBatchSize is the default maximum number of requests in a
+	// single JSON-RPC batch.
+	DefaultMaxBatchSize = 100
+	// DefaultMaxFindResultItems is the default maximum number of items
+	// returned by a single findstorage call.
+	DefaultMaxFindResultItems = 100
+	// DefaultMaxIteratorResultItems is the default maximum number of items
+	// returned by a single traverseiterator call.
+	DefaultMaxIteratorResultItems = 100
+	// DefaultMaxReplayBlocks is the default maximum number of stored blocks
+	// events can be replayed from for a websocket subscription.
+	DefaultMaxReplayBlocks = 1000
+	// DefaultMaxReplays is the default maximum number of concurrent event
+	// replays per server.
+	DefaultMaxReplays = 16
+	// DefaultSessionExpirationTime is the default iterator session lifetime
+	// (in seconds) since the last access.
+	DefaultSessionExpirationTime = 60
+	// DefaultSessionPoolSize is the default maximum number of concurrent
+	// iterator sessions.
+	DefaultSessionPoolSize = 20
+)
+
 type (
 	// Config is an RPC service configuration information
 	Config struct {
-		Address              string `yaml:"Address"`
-		Enabled              bool   `yaml:"Enabled"`
-		EnableCORSWorkaround bool   `yaml:"EnableCORSWorkaround"`
+		Address string `yaml:"Address"`
+		// AllowedMethods is a list of methods served, all methods are
+		// served if it's empty.
+		AllowedMethods []string `yaml:"AllowedMethods"`
+		// Auth contains credentials required to use the server.
+		Auth AuthConfig `yaml:"Auth"`
+		// DisallowedMethods is a list of methods that are never served.
+		DisallowedMethods    []string `yaml:"DisallowedMethods"`
+		Enabled              bool     `yaml:"Enabled"`
+		EnableCORSWorkaround bool     `yaml:"EnableCORSWorkaround"`
+		// EnableHistoricalState allows to perform invocations and
+		// getstorage calls against the state of some past block.
+		EnableHistoricalState bool `yaml:"EnableHistoricalState"`
+		// EnableWalletMethods enables wallet methods (like
+		// sendtoaddress) working with the wallet opened by openwallet
+		// call or with the node's UnlockWallet.
+		EnableWalletMethods bool `yaml:"EnableWalletMethods"`
+		// MaxBatchSize is a maximum number of requests in a single
+		// JSON-RPC batch, DefaultMaxBatchSize is used if it's 0.
+		MaxBatchSize int `yaml:"MaxBatchSize"`
+		// MaxFindResultItems is a maximum number of items returned by
+		// findstorage, DefaultMaxFindResultItems is used if it's 0.
+		MaxFindResultItems int `yaml:"MaxFindResultItems"`
 		// MaxGasInvoke is a maximum amount of gas which
 		// can be spent during RPC call.
 		MaxGasInvoke util.Fixed8 `yaml:"MaxGasInvoke"`
-		Port         uint16      `yaml:"Port"`
-		TLSConfig    TLSConfig   `yaml:"TLSConfig"`
+		// MaxIteratorResultItems is a maximum number of items returned
+		// by traverseiterator, DefaultMaxIteratorResultItems is used if
+		// it's 0.
+		MaxIteratorResultItems int `yaml:"MaxIteratorResultItems"`
+		// MaxReplayBlocks is a maximum number of stored blocks events
+		// can be replayed from (with fromBlock subscription option),
+		// DefaultMaxReplayBlocks is used if it's 0.
+		MaxReplayBlocks int `yaml:"MaxReplayBlocks"`
+		// MaxReplays is a maximum number of concurrent event replays
+		// for all websocket clients, DefaultMaxReplays is used if it's
+		// 0.
+		MaxReplays int    `yaml:"MaxReplays"`
+		Port       uint16 `yaml:"Port"`
+		// RateLimit is a per-IP limit on the number of calls.
+		RateLimit RateLimitConfig `yaml:"RateLimit"`
+		// ReadOnly disables methods changing the node state (like
+		// sendrawtransaction or submitblock).
+		ReadOnly bool `yaml:"ReadOnly"`
+		// SessionExpirationTime is a number of seconds iterator session
+		// is kept alive since the last access to it,
+		// DefaultSessionExpirationTime is used if it's 0.
+		SessionExpirationTime int `yaml:"SessionExpirationTime"`
+		// SessionPoolSize is a maximum number of concurrent iterator
+		// sessions, DefaultSessionPoolSize is used if it's 0.
+		SessionPoolSize int       `yaml:"SessionPoolSize"`
+		TLSConfig       TLSConfig `yaml:"TLSConfig"`
+		// WalletDirectory is a directory wallets can be opened from
+		// with openwallet call, if it's empty only the node's
+		// UnlockWallet can be (re)opened.
+		WalletDirectory string `yaml:"WalletDirectory"`
+	}
+
+	// AuthConfig describes RPC server credentials, if any of them are
+	// configured every request must be authenticated with one of them
+	// either using X-API-Key header (or Authorization header with Bearer
+	// scheme) for API keys or using basic HTTP authentication.
+	AuthConfig struct {
+		APIKeys []string `yaml:"APIKeys"`
+		// BasicAuth maps user names to passwords.
+		BasicAuth map[string]string `yaml:"BasicAuth"`
+	}
+
+	// RateLimitConfig describes per-IP rate limiting, every call (including
+	// calls in batches) is counted. Rate limiting is disabled if
+	// RequestsPerSecond is 0.
+	RateLimitConfig struct {
+		RequestsPerSecond float64 `yaml:"RequestsPerSecond"`
+		// Burst is the maximum number of calls that can be made at
+		// once, it's RequestsPerSecond (but at least 1) if not set.
+		Burst int `yaml:"Burst"`
 	}
 
 	// TLSConfig describes SSL/TLS configuration.
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"encoding/json"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/pkg/errors"
+)
+
+type (
+	// Handler is an RPC method handler that can be registered with
+	// RegisterHandler. It gets request parameters and the chain the server
+	// works with, it returns a result that is marshaled to JSON or an error.
+	Handler func(chain blockchainer.Blockchainer, params request.Params) (interface{}, *response.Error)
+
+	// EventSender sends an event registered with RegisterEvent to all
+	// websocket clients subscribed to it. The payload is marshaled to JSON
+	// and it's the only element of notification parameters. It blocks until
+	// the event is processed by the server, events sent before the server is
+	// started are delivered after it's started and events sent after the
+	// server is shut down are dropped.
+	EventSender func(payload interface{})
+
+	// StartHook is called with the server's chain when the server is
+	// started, an error returned from it stops the server start.
+	StartHook func(chain blockchainer.Blockchainer) error
+
+	// ShutdownHook is called when the server is shut down.
+	ShutdownHook func()
+
+	// shutdownHook is a ShutdownHook along with the number of start hooks
+	// added before it, these have to succeed for it to be called.
+	shutdownHook struct {
+		hook   ShutdownHook
+		starts int
+	}
+
+	// extensionEvent is an event sent via EventSender.
+	extensionEvent struct {
+		id      response.EventID
+		payload interface{}
+	}
+)
+
+// firstExtensionEventID is the first event ID used for registered events,
+// they take IDs up to MissedEventID (not inclusive).
+const firstExtensionEventID response.EventID = 128
+
+// extensionName returns the name of namespaced method or event.
+func extensionName(namespace, name string) (string, error) {
+	if namespace == "" || name == "" {
+		return "", errors.New("empty namespace or name")
+	}
+	return namespace + "_" + name, nil
+}
+
+// checkRegistration returns an error if extensions can't be registered
+// anymore.
+func (s *Server) checkRegistration() error {
+	if s.started.Load() {
+		return errors.New("server is already started")
+	}
+	return nil
+}
+
+// RegisterHandler adds a new RPC method named "namespace_name" served by the
+// given handler. Method names can't clash with standard methods or other
+// registered ones. Registered methods are subject to the same access control
+// as standard ones (so they can be enabled or disabled with AllowedMethods
+// and DisallowedMethods settings). It must be called before Start.
+func (s *Server) RegisterHandler(namespace, name string, h Handler) error {
+	if err := s.checkRegistration(); err != nil {
+		return err
+	}
+	if h == nil {
+		return errors.New("nil handler")
+	}
+	method, err := extensionName(namespace, name)
+	if err != nil {
+		return err
+	}
+	_, std := rpcHandlers[method]
+	_, wallet := rpcWalletHandlers[method]
+	_, ws := rpcWsHandlers[method]
+	if std || wallet || ws || s.extHandlers[method] != nil {
+		return errors.Errorf("method %s is already registered", method)
+	}
+	s.extHandlers[method] = h
+	return nil
+}
+
+// RegisterEvent adds a new websocket event named "namespace_name" that
+// clients can subscribe to (without filters) and returns a function sending
+// these events. Event names can't clash with standard events or other
+// registered ones. It must be called before Start.
+func (s *Server) RegisterEvent(namespace, name string) (EventSender, error) {
+	if err := s.checkRegistration(); err != nil {
+		return nil, err
+	}
+	event, err := extensionName(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := response.GetEventIDFromString(event); err == nil {
+		return nil, errors.Errorf("event %s is already registered", event)
+	}
+	if _, ok := s.extEvents[event]; ok {
+		return nil, errors.Errorf("event %s is already registered", event)
+	}
+	id := firstExtensionEventID + response.EventID(len(s.extEvents))
+	if id == response.MissedEventID {
+		return nil, errors.New("too many events registered")
+	}
+	s.extEvents[event] = id
+	s.extEventNames[id] = event
+	return func(payload interface{}) {
+		select {
+		case s.extensionCh <- extensionEvent{id: id, payload: payload}:
+		case <-s.shutdown:
+		}
+	}, nil
+}
+
+// AddStartHook adds a function that is called when the server is started
+// (in the order they're added). It must be called before Start.
+func (s *Server) AddStartHook(h StartHook) error {
+	if err := s.checkRegistration(); err != nil {
+		return err
+	}
+	s.startHooks = append(s.startHooks, h)
+	return nil
+}
+
+// AddShutdownHook adds a function that is called when the server is shut
+// down (in the reverse order), it's only called if the server was started
+// and all start hooks added before it have succeeded. It must be called
+// before Start.
+func (s *Server) AddShutdownHook(h ShutdownHook) error {
+	if err := s.checkRegistration(); err != nil {
+		return err
+	}
+	s.shutdownHooks = append(s.shutdownHooks, shutdownHook{hook: h, starts: len(s.startHooks)})
+	return nil
+}
+
+// newExtensionMessage prepares notification message for the registered
+// event, it's different from newNotificationMessage in that event name
+// is not known to response.EventID.
+func (s *Server) newExtensionMessage(e extensionEvent) (*websocket.PreparedMessage, error) {
+	b, err := json.Marshal(struct {
+		JSONRPC string        `json:"jsonrpc"`
+		Event   string        `json:"method"`
+		Payload []interface{} `json:"params"`
+	}{
+		JSONRPC: request.JSONRPCVersion,
+		Event:   s.extEventNames[e.id],
+		Payload: []interface{}{e.payload},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return websocket.NewPreparedMessage(websocket.TextMessage, b)
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+func TestExtensions(t *testing.T) {
+	chain, cfg, logger := getUnitTestChain(t)
+	defer chain.Close()
+	netSrv, err := network.NewServer(network.NewServerConfig(cfg), chain, logger)
+	require.NoError(t, err)
+	rpcSrv := New(chain, cfg.ApplicationConfiguration.RPC, netSrv, logger)
+
+	height := func(bc blockchainer.Blockchainer, _ request.Params) (interface{}, *response.Error) {
+		return bc.BlockHeight(), nil
+	}
+	require.Error(t, rpcSrv.RegisterHandler("", "height", height))
+	require.Error(t, rpcSrv.RegisterHandler("test", "height", nil))
+	require.NoError(t, rpcSrv.RegisterHandler("test", "height", height))
+	require.Error(t, rpcSrv.RegisterHandler("test", "height", height))
+
+	_, err = rpcSrv.RegisterEvent("test", "")
+	require.Error(t, err)
+	send, err := rpcSrv.RegisterEvent("test", "event")
+	require.NoError(t, err)
+	_, err = rpcSrv.RegisterEvent("test", "event")
+	require.Error(t, err)
+
+	started := make(chan struct{})
+	require.NoError(t, rpcSrv.AddStartHook(func(bc blockchainer.Blockchainer) error {
+		require.Equal(t, chain, bc)
+		close(started)
+		return nil
+	}))
+	var stopped bool
+	require.NoError(t, rpcSrv.AddShutdownHook(func() { stopped = true }))
+
+	go rpcSrv.Start(make(chan error, 2))
+	httpSrv := httptest.NewServer(http.HandlerFunc(rpcSrv.handleHTTPRequest))
+	defer httpSrv.Close()
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("start hook wasn't called")
+	}
+	require.Error(t, rpcSrv.RegisterHandler("test", "other", height))
+	_, err = rpcSrv.RegisterEvent("test", "other")
+	require.Error(t, err)
+	require.Error(t, rpcSrv.AddShutdownHook(func() {}))
+
+	t.Run("handler", func(t *testing.T) {
+		body := doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "test_height", "params": []}`, httpSrv.URL, t)
+		var h uint32
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, body, false), &h))
+		require.Equal(t, chain.BlockHeight(), h)
+	})
+	t.Run("event", func(t *testing.T) {
+		dialer := websocket.Dialer{HandshakeTimeout: time.Second}
+		ws, _, err := dialer.Dial("ws"+strings.TrimPrefix(httpSrv.URL, "http")+"/ws", nil)
+		require.NoError(t, err)
+		msgs := make(chan []byte, 16)
+		finished := atomic.NewBool(false)
+		go wsReader(t, ws, msgs, finished)
+		defer func() {
+			finished.Store(true)
+			ws.Close()
+		}()
+
+		resp := callWSGetRaw(t, ws, `{"jsonrpc": "2.0", "method": "subscribe", "params": ["test_event", {}], "id": 1}`, msgs)
+		require.NotNil(t, resp.Error)
+		callSubscribe(t, ws, msgs, `["test_event"]`)
+
+		go send(map[string]int{"value": 42})
+		var ntf struct {
+			Event   string            `json:"method"`
+			Payload []json.RawMessage `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(<-msgs, &ntf))
+		require.Equal(t, "test_event", ntf.Event)
+		require.Equal(t, 1, len(ntf.Payload))
+		require.JSONEq(t, `{"value": 42}`, string(ntf.Payload[0]))
+	})
+
+	require.NoError(t, rpcSrv.Shutdown())
+	require.True(t, stopped)
+	// Events sent after shutdown are dropped.
+	send("dropped")
+}
+
+func TestExtensionsFailedStart(t *testing.T) {
+	chain, cfg, logger := getUnitTestChain(t)
+	defer chain.Close()
+	netSrv, err := network.NewServer(network.NewServerConfig(cfg), chain, logger)
+	require.NoError(t, err)
+	rpcSrv := New(chain, cfg.ApplicationConfiguration.RPC, netSrv, logger)
+
+	var stopped []string
+	require.NoError(t, rpcSrv.AddStartHook(func(blockchainer.Blockchainer) error { return nil }))
+	require.NoError(t, rpcSrv.AddShutdownHook(func() { stopped = append(stopped, "first") }))
+	require.NoError(t, rpcSrv.AddStartHook(func(blockchainer.Blockchainer) error { return errors.New("failed") }))
+	require.NoError(t, rpcSrv.AddShutdownHook(func() { stopped = append(stopped, "second") }))
+
+	errCh := make(chan error, 2)
+	rpcSrv.Start(errCh)
+	require.Error(t, <-errCh)
+	require.NoError(t, rpcSrv.Shutdown())
+	require.Equal(t, []string{"first"}, stopped)
+}
@@ -7,7 +7,25 @@ import (
 )
 
 // Metrics used in monitoring service.
-var rpcCounter = map[string]prometheus.Counter{}
+var (
+	rpcCounter = map[string]prometheus.Counter{}
+
+	rejectedCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of rejected rpc calls",
+			Name:      "rpc_rejected",
+			Namespace: "neogo",
+		},
+		[]string{"reason"},
+	)
+)
+
+// Reasons for call rejection used in rejectedCounter.
+const (
+	rejectUnauthorized = "unauthorized"
+	rejectForbidden    = "forbidden"
+	rejectRateLimit    = "rate_limit"
+)
 
 func incCounter(name string) {
 	ctr, ok := rpcCounter[name]
@@ -16,7 +34,12 @@ func incCounter(name string) {
 	}
 }
 
+func incRejectedCounter(reason string) {
+	rejectedCounter.WithLabelValues(reason).Inc()
+}
+
 func init() {
+	prometheus.MustRegister(rejectedCounter)
 	for call := range rpcHandlers {
 		ctr := prometheus.NewCounter(
 			prometheus.CounterOpts{
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// maxLimiterBuckets is the number of per-IP buckets after which full buckets
+// are dropped by the rateLimiter.
+const maxLimiterBuckets = 10000
+
+// rateLimiter is a simple per-IP token bucket rate limiter.
+type rateLimiter struct {
+	lock    sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates rateLimiter allowing rate calls per second with at
+// most burst calls at once.
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// allow returns true if n more calls can be made from the given IP and
+// accounts them if so.
+func (l *rateLimiter) allow(ip string, n int) bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[ip]
+	if !ok {
+		if len(l.buckets) >= maxLimiterBuckets {
+			l.dropFullBuckets(now)
+		}
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[ip] = b
+	}
+	l.refill(b, now)
+	if b.tokens < float64(n) {
+		return false
+	}
+	b.tokens -= float64(n)
+	return true
+}
+
+func (l *rateLimiter) refill(b *bucket, now time.Time) {
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+}
+
+// dropFullBuckets removes buckets that are full (they're equivalent to the
+// new ones).
+func (l *rateLimiter) dropFullBuckets(now time.Time) {
+	for ip, b := range l.buckets {
+		l.refill(b, now)
+		if b.tokens >= l.burst {
+			delete(l.buckets, ip)
+		}
+	}
+}
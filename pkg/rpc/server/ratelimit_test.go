@@ -0,0 +1,37 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	l := newRateLimiter(2, 3)
+	l.now = func() time.Time { return now }
+
+	require.True(t, l.allow("1.2.3.4", 2))
+	require.True(t, l.allow("1.2.3.4", 1))
+	require.False(t, l.allow("1.2.3.4", 1))
+	require.True(t, l.allow("4.3.2.1", 3))
+
+	now = now.Add(500 * time.Millisecond)
+	require.True(t, l.allow("1.2.3.4", 1))
+	require.False(t, l.allow("1.2.3.4", 1))
+
+	t.Run("bigger than burst", func(t *testing.T) {
+		now = now.Add(time.Hour)
+		require.False(t, l.allow("1.2.3.4", 4))
+		require.True(t, l.allow("1.2.3.4", 3))
+	})
+	t.Run("default burst", func(t *testing.T) {
+		require.Equal(t, float64(1), newRateLimiter(0.5, 0).burst)
+		require.Equal(t, float64(3), newRateLimiter(2.5, 0).burst)
+	})
+	t.Run("drop full buckets", func(t *testing.T) {
+		l.dropFullBuckets(now.Add(time.Hour))
+		require.Equal(t, 0, len(l.buckets))
+	})
+}
@@ -1,7 +1,9 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -9,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -16,7 +19,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -30,7 +37,11 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/pkg/errors"
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -45,17 +56,55 @@ type (
 		https      *http.Server
 		shutdown   chan struct{}
 
+		allowedMethods    map[string]bool
+		disallowedMethods map[string]bool
+		limiter           *rateLimiter
+
+		sessionsLock sync.Mutex
+		sessions     map[string]*session
+
+		// walletLock protects the wallet used by wallet methods, it's
+		// held for the whole call.
+		walletLock sync.Mutex
+		wallet     *wallet.Wallet
+		walletPass string
+		// walletPath is the path of the wallet opened with OpenWallet.
+		walletPath string
+
 		subsLock         sync.RWMutex
 		subscribers      map[*subscriber]bool
 		subsGroup        sync.WaitGroup
 		blockSubs        int
 		executionSubs    int
+		headerSubs       int
+		mempoolSubs      int
 		notificationSubs int
 		transactionSubs  int
+		replays          int
 		blockCh          chan *block.Block
 		executionCh      chan *state.AppExecResult
+		headerCh         chan *block.Header
+		mempoolCh        chan mempool.Event
 		notificationCh   chan *state.NotificationEvent
 		transactionCh    chan *transaction.Transaction
+		extensionCh      chan extensionEvent
+		// lastExecTx is the hash of the transaction from the last
+		// execution event, it's only used by handleSubEvents.
+		lastExecTx util.Uint256
+
+		// Extensions registered with RegisterHandler, RegisterEvent,
+		// AddStartHook and AddShutdownHook, they can only be changed
+		// before the server is started.
+		started       atomic.Bool
+		extHandlers   map[string]Handler
+		extEvents     map[string]response.EventID
+		extEventNames map[response.EventID]string
+		startHooks    []StartHook
+		shutdownHooks []shutdownHook
+		// hooksDone is the number of start hooks that succeeded,
+		// serving is set once handleSubEvents is running.
+		hooksDone atomic.Int32
+		serving   atomic.Bool
 	}
 )
 
@@ -76,34 +125,87 @@ const (
 	// treated like subscriber, so technically it's a limit on websocket
 	// connections.
 	maxSubscribers = 64
+
+	// Maximum number of instructions returned by tracetransaction.
+	maxTraceSteps = 100000
+
+	// Maximum number of transfers returned by getnep5transfers.
+	maxNEP5TransfersLimit = 1000
+
+	// Maximum number of transactions returned by getaccounttransactions.
+	maxAccountTransactionsLimit = 1000
+
+	// Number of notifications returned by getcontractnotifications per
+	// page.
+	notificationsPageSize = 100
 )
 
 var rpcHandlers = map[string]func(*Server, request.Params) (interface{}, *response.Error){
-	"getapplicationlog":    (*Server).getApplicationLog,
-	"getbestblockhash":     (*Server).getBestBlockHash,
-	"getblock":             (*Server).getBlock,
-	"getblockcount":        (*Server).getBlockCount,
-	"getblockhash":         (*Server).getBlockHash,
-	"getblockheader":       (*Server).getBlockHeader,
-	"getblocksysfee":       (*Server).getBlockSysFee,
-	"getconnectioncount":   (*Server).getConnectionCount,
-	"getcontractstate":     (*Server).getContractState,
-	"getnep5balances":      (*Server).getNEP5Balances,
-	"getnep5transfers":     (*Server).getNEP5Transfers,
-	"getpeers":             (*Server).getPeers,
-	"getrawmempool":        (*Server).getRawMempool,
-	"getrawtransaction":    (*Server).getrawtransaction,
-	"getstorage":           (*Server).getStorage,
-	"gettransactionheight": (*Server).getTransactionHeight,
-	"getunclaimedgas":      (*Server).getUnclaimedGas,
-	"getvalidators":        (*Server).getValidators,
-	"getversion":           (*Server).getVersion,
-	"invoke":               (*Server).invoke,
-	"invokefunction":       (*Server).invokeFunction,
-	"invokescript":         (*Server).invokescript,
-	"sendrawtransaction":   (*Server).sendrawtransaction,
-	"submitblock":          (*Server).submitBlock,
-	"validateaddress":      (*Server).validateAddress,
+	"calculatenetworkfee":      (*Server).calculateNetworkFee,
+	"findstorage":              (*Server).findStorage,
+	"getaccounttransactions":   (*Server).getAccountTransactions,
+	"getapplicationlog":        (*Server).getApplicationLog,
+	"getbestblockhash":         (*Server).getBestBlockHash,
+	"getblock":                 (*Server).getBlock,
+	"getblockcount":            (*Server).getBlockCount,
+	"getblockhash":             (*Server).getBlockHash,
+	"getblockheader":           (*Server).getBlockHeader,
+	"getblocksysfee":           (*Server).getBlockSysFee,
+	"getcandidates":            (*Server).getValidators,
+	"getcommittee":             (*Server).getCommittee,
+	"getconnectioncount":       (*Server).getConnectionCount,
+	"getcontractnotifications": (*Server).getContractNotifications,
+	"getcontractstate":         (*Server).getContractState,
+	"getnep5balances":          (*Server).getNEP5Balances,
+	"getnep5transfers":         (*Server).getNEP5Transfers,
+	"getnextblockvalidators":   (*Server).getNextBlockValidators,
+	"getpeers":                 (*Server).getPeers,
+	"getproof":                 (*Server).getProof,
+	"getrawmempool":            (*Server).getRawMempool,
+	"getrawtransaction":        (*Server).getrawtransaction,
+	"getstateroot":             (*Server).getStateRoot,
+	"getstorage":               (*Server).getStorage,
+	"gettransactionheight":     (*Server).getTransactionHeight,
+	"getunclaimedgas":          (*Server).getUnclaimedGas,
+	"getvalidators":            (*Server).getValidators,
+	"getversion":               (*Server).getVersion,
+	"invoke":                   (*Server).invoke,
+	"invokefunction":           (*Server).invokeFunction,
+	"invokescript":             (*Server).invokescript,
+	"sendrawtransaction":       (*Server).sendrawtransaction,
+	"submitblock":              (*Server).submitBlock,
+	"terminatesession":         (*Server).terminateSessionCall,
+	"tracetransaction":         (*Server).traceTransaction,
+	"traverseiterator":         (*Server).traverseIterator,
+	"validateaddress":          (*Server).validateAddress,
+	"verifyproof":              (*Server).verifyProof,
+}
+
+// rpcWalletHandlers are only served if wallet methods are enabled.
+var rpcWalletHandlers = map[string]func(*Server, request.Params) (interface{}, *response.Error){
+	"closewallet":           (*Server).closeWalletCall,
+	"dumpprivkey":           (*Server).dumpPrivKey,
+	"getnewaddress":         (*Server).getNewAddress,
+	"getwalletbalance":      (*Server).getWalletBalance,
+	"getwalletunclaimedgas": (*Server).getWalletUnclaimedGas,
+	"listaddress":           (*Server).listAddress,
+	"openwallet":            (*Server).openWalletCall,
+	"sendfrom":              (*Server).sendFrom,
+	"sendmany":              (*Server).sendMany,
+	"sendtoaddress":         (*Server).sendToAddress,
+}
+
+// stateChangingMethods are methods that are disabled in read-only mode
+// (wallet methods changing or exposing wallet keys are also among them).
+var stateChangingMethods = map[string]bool{
+	"dumpprivkey":        true,
+	"getnewaddress":      true,
+	"openwallet":         true,
+	"sendfrom":           true,
+	"sendmany":           true,
+	"sendrawtransaction": true,
+	"sendtoaddress":      true,
+	"submitblock":        true,
 }
 
 var rpcWsHandlers = map[string]func(*Server, request.Params, *subscriber) (interface{}, *response.Error){
@@ -132,6 +234,33 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		}
 	}
 
+	if conf.MaxBatchSize == 0 {
+		conf.MaxBatchSize = rpc.DefaultMaxBatchSize
+	}
+	if conf.MaxFindResultItems == 0 {
+		conf.MaxFindResultItems = rpc.DefaultMaxFindResultItems
+	}
+	if conf.MaxIteratorResultItems == 0 {
+		conf.MaxIteratorResultItems = rpc.DefaultMaxIteratorResultItems
+	}
+	if conf.MaxReplayBlocks == 0 {
+		conf.MaxReplayBlocks = rpc.DefaultMaxReplayBlocks
+	}
+	if conf.MaxReplays == 0 {
+		conf.MaxReplays = rpc.DefaultMaxReplays
+	}
+	if conf.SessionExpirationTime == 0 {
+		conf.SessionExpirationTime = rpc.DefaultSessionExpirationTime
+	}
+	if conf.SessionPoolSize == 0 {
+		conf.SessionPoolSize = rpc.DefaultSessionPoolSize
+	}
+
+	var limiter *rateLimiter
+	if conf.RateLimit.RequestsPerSecond > 0 {
+		limiter = newRateLimiter(conf.RateLimit.RequestsPerSecond, conf.RateLimit.Burst)
+	}
+
 	return Server{
 		Server:     httpServer,
 		chain:      chain,
@@ -141,12 +270,25 @@ func New(chain blockchainer.Blockchainer, conf rpc.Config, coreServer *network.S
 		https:      tlsServer,
 		shutdown:   make(chan struct{}),
 
+		allowedMethods:    stringSet(conf.AllowedMethods),
+		disallowedMethods: stringSet(conf.DisallowedMethods),
+		limiter:           limiter,
+
+		sessions: make(map[string]*session),
+
 		subscribers: make(map[*subscriber]bool),
 		// These are NOT buffered to preserve original order of events.
 		blockCh:        make(chan *block.Block),
 		executionCh:    make(chan *state.AppExecResult),
+		headerCh:       make(chan *block.Header),
+		mempoolCh:      make(chan mempool.Event),
 		notificationCh: make(chan *state.NotificationEvent),
 		transactionCh:  make(chan *transaction.Transaction),
+		extensionCh:    make(chan extensionEvent),
+
+		extHandlers:   make(map[string]Handler),
+		extEvents:     make(map[string]response.EventID),
+		extEventNames: make(map[response.EventID]string),
 	}
 }
 
@@ -161,6 +303,16 @@ func (s *Server) Start(errChan chan error) {
 	s.Handler = http.HandlerFunc(s.handleHTTPRequest)
 	s.log.Info("starting rpc-server", zap.String("endpoint", s.Addr))
 
+	s.started.Store(true)
+	for _, h := range s.startHooks {
+		if err := h(s.chain); err != nil {
+			s.log.Error("RPC server start hook failed", zap.Error(err))
+			errChan <- err
+			return
+		}
+		s.hooksDone.Inc()
+	}
+	s.serving.Store(true)
 	go s.handleSubEvents()
 	if cfg := s.config.TLSConfig; cfg.Enabled {
 		s.https.Handler = http.HandlerFunc(s.handleHTTPRequest)
@@ -185,6 +337,15 @@ func (s *Server) Start(errChan chan error) {
 func (s *Server) Shutdown() error {
 	var httpsErr error
 
+	if s.started.Load() {
+		done := int(s.hooksDone.Load())
+		for i := len(s.shutdownHooks) - 1; i >= 0; i-- {
+			if s.shutdownHooks[i].starts <= done {
+				s.shutdownHooks[i].hook()
+			}
+		}
+	}
+
 	// Signal to websocket writer routines and handleSubEvents.
 	close(s.shutdown)
 
@@ -196,8 +357,13 @@ func (s *Server) Shutdown() error {
 	s.log.Info("shutting down rpc-server", zap.String("endpoint", s.Addr))
 	err := s.Server.Shutdown(context.Background())
 
-	// Wait for handleSubEvents to finish.
-	<-s.executionCh
+	// Wait for handleSubEvents to finish (if it was started at all).
+	if s.serving.Load() {
+		<-s.executionCh
+	}
+
+	s.dropSessions()
+	s.closeWallet()
 
 	if err == nil {
 		return httpsErr
@@ -208,6 +374,15 @@ func (s *Server) Shutdown() error {
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	req := request.NewIn()
 
+	if !s.authorized(httpRequest) {
+		incRejectedCounter(rejectUnauthorized)
+		if len(s.config.Auth.BasicAuth) != 0 {
+			w.Header().Set("WWW-Authenticate", `Basic realm="neo-go RPC"`)
+		}
+		s.writeHTTPErrorResponse(req, w, response.ErrUnauthorized)
+		return
+	}
+
 	if httpRequest.URL.Path == "/ws" && httpRequest.Method == "GET" {
 		// Technically there is a race between this check and
 		// s.subscribers modification 20 lines below, but it's tiny
@@ -229,7 +404,7 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 			s.log.Info("websocket connection upgrade failed", zap.Error(err))
 			return
 		}
-		resChan := make(chan response.Raw)
+		resChan := make(chan response.AbstractResult)
 		subChan := make(chan *websocket.PreparedMessage, notificationBufSize)
 		subscr := &subscriber{writer: subChan, ws: ws}
 		s.subsLock.Lock()
@@ -251,20 +426,121 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
-	err := req.DecodeData(httpRequest.Body)
+	r := new(request.Request)
+	err := r.DecodeData(httpRequest.Body)
 	if err != nil {
 		s.writeHTTPErrorResponse(req, w, response.NewParseError("Problem parsing JSON-RPC request body", err))
 		return
 	}
 
-	resp := s.handleRequest(req, nil)
-	s.writeHTTPServerResponse(req, w, resp)
+	resp := s.handleRequest(r, nil, remoteIP(httpRequest.RemoteAddr))
+	s.writeHTTPServerResponse(r, w, resp)
+}
+
+// authorized checks request credentials against configured API keys and basic
+// authentication users, any request is authorized if there are none of them.
+func (s *Server) authorized(r *http.Request) bool {
+	auth := s.config.Auth
+	if len(auth.APIKeys) == 0 && len(auth.BasicAuth) == 0 {
+		return true
+	}
+	key := r.Header.Get("X-API-Key")
+	if authz := r.Header.Get("Authorization"); key == "" && strings.HasPrefix(authz, "Bearer ") {
+		key = strings.TrimPrefix(authz, "Bearer ")
+	}
+	if key != "" {
+		for _, k := range auth.APIKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(k)) == 1 {
+				return true
+			}
+		}
+	}
+	user, pass, ok := r.BasicAuth()
+	if ok {
+		expected, found := auth.BasicAuth[user]
+		if found && subtle.ConstantTimeCompare([]byte(pass), []byte(expected)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkMethod returns an error if the method can't be called because of
+// server configuration.
+func (s *Server) checkMethod(method string) *response.Error {
+	if len(s.allowedMethods) != 0 && !s.allowedMethods[method] || s.disallowedMethods[method] {
+		return response.NewForbiddenMethodError(fmt.Sprintf("Method '%s' is disabled", method))
+	}
+	if s.config.ReadOnly && stateChangingMethods[method] {
+		return response.NewForbiddenMethodError(fmt.Sprintf("Method '%s' is disabled in read-only mode", method))
+	}
+	return nil
+}
+
+// handleRequest handles a single request or a batch of them coming from the
+// given IP address.
+func (s *Server) handleRequest(req *request.Request, sub *subscriber, ip string) response.AbstractResult {
+	if s.limiter != nil {
+		calls := len(req.Batch)
+		if req.In != nil || calls == 0 {
+			calls = 1
+		}
+		if !s.limiter.allow(ip, calls) {
+			incRejectedCounter(rejectRateLimit)
+			r := req.In
+			if r == nil {
+				r = request.NewIn()
+			}
+			return s.packResponseToRaw(r, nil, response.ErrRateLimitExceeded)
+		}
+	}
+	if req.In != nil {
+		return s.handleIn(req.In, sub)
+	}
+	if len(req.Batch) == 0 {
+		return s.packResponseToRaw(request.NewIn(), nil, response.NewInvalidRequestError("Empty batch", nil))
+	}
+	if len(req.Batch) > s.config.MaxBatchSize {
+		return s.packResponseToRaw(request.NewIn(), nil, response.NewInvalidRequestError(
+			fmt.Sprintf("Batch is too big, max %d requests allowed", s.config.MaxBatchSize), nil))
+	}
+	resp := make(response.RawBatch, len(req.Batch))
+	for i := range req.Batch {
+		resp[i] = s.handleIn(&req.Batch[i], sub)
+	}
+	return resp
+}
+
+// filterNotifications removes responses to notifications from the batch
+// response as they must not be replied to. It returns nil if there is
+// nothing to reply with (batch consists of notifications only), resp is
+// returned as is for non-batch requests.
+func filterNotifications(req *request.Request, resp response.AbstractResult) response.AbstractResult {
+	batch, ok := resp.(response.RawBatch)
+	if !ok {
+		return resp
+	}
+	res := make(response.RawBatch, 0, len(batch))
+	for i := range batch {
+		if !req.Batch[i].IsNotification() {
+			res = append(res, batch[i])
+		}
+	}
+	if len(res) == 0 {
+		return nil
+	}
+	return res
 }
 
-func (s *Server) handleRequest(req *request.In, sub *subscriber) response.Raw {
+func (s *Server) handleIn(req *request.In, sub *subscriber) response.Raw {
 	var res interface{}
 	var resErr *response.Error
 
+	if req.JSONRPC != request.JSONRPCVersion {
+		return s.packResponseToRaw(req, nil, response.NewInvalidRequestError(
+			fmt.Sprintf("Invalid version, expected 2.0 got: '%s'", req.JSONRPC), nil))
+	}
+
 	reqParams, err := req.Params()
 	if err != nil {
 		return s.packResponseToRaw(req, nil, response.NewInvalidParamsError("Problem parsing request parameters", err))
@@ -274,12 +550,22 @@ func (s *Server) handleRequest(req *request.In, sub *subscriber) response.Raw {
 		zap.String("method", req.Method),
 		zap.String("params", fmt.Sprintf("%v", reqParams)))
 
+	if resErr = s.checkMethod(req.Method); resErr != nil {
+		incRejectedCounter(rejectForbidden)
+		return s.packResponseToRaw(req, nil, resErr)
+	}
+
 	incCounter(req.Method)
 
 	resErr = response.NewMethodNotFoundError(fmt.Sprintf("Method '%s' not supported", req.Method), nil)
 	handler, ok := rpcHandlers[req.Method]
+	if !ok && s.config.EnableWalletMethods {
+		handler, ok = rpcWalletHandlers[req.Method]
+	}
 	if ok {
 		res, resErr = handler(s, *reqParams)
+	} else if h, ok := s.extHandlers[req.Method]; ok {
+		res, resErr = h(s.chain, *reqParams)
 	} else if sub != nil {
 		handler, ok := rpcWsHandlers[req.Method]
 		if ok {
@@ -289,7 +575,7 @@ func (s *Server) handleRequest(req *request.In, sub *subscriber) response.Raw {
 	return s.packResponseToRaw(req, res, resErr)
 }
 
-func (s *Server) handleWsWrites(ws *websocket.Conn, resChan <-chan response.Raw, subChan <-chan *websocket.PreparedMessage) {
+func (s *Server) handleWsWrites(ws *websocket.Conn, resChan <-chan response.AbstractResult, subChan <-chan *websocket.PreparedMessage) {
 	pingTicker := time.NewTicker(wsPingPeriod)
 eventloop:
 	for {
@@ -336,20 +622,25 @@ drainloop:
 	}
 }
 
-func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- response.Raw, subscr *subscriber) {
-	ws.SetReadLimit(wsReadLimit)
+func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- response.AbstractResult, subscr *subscriber) {
+	// Batches can contain up to MaxBatchSize requests each limited by
+	// wsReadLimit.
+	ws.SetReadLimit(int64(wsReadLimit * s.config.MaxBatchSize))
 	ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 	ws.SetPongHandler(func(string) error { ws.SetReadDeadline(time.Now().Add(wsPongLimit)); return nil })
+	ip := remoteIP(ws.RemoteAddr().String())
 requestloop:
 	for {
-		req := new(request.In)
+		req := new(request.Request)
 		err := ws.ReadJSON(req)
 		if err != nil {
 			break
 		}
-		res := s.handleRequest(req, subscr)
-		if res.Error != nil {
-			s.logRequestError(req, res.Error)
+		res := s.handleRequest(req, subscr, ip)
+		s.logRequestErrors(req, res)
+		res = filterNotifications(req, res)
+		if res == nil {
+			continue
 		}
 		select {
 		case <-s.shutdown:
@@ -360,9 +651,10 @@ requestloop:
 	}
 	s.subsLock.Lock()
 	delete(s.subscribers, subscr)
-	for _, e := range subscr.feeds {
-		if e.event != response.InvalidEventID {
-			s.unsubscribeFromChannel(e.event)
+	for i := range subscr.feeds {
+		if subscr.feeds[i].event != response.InvalidEventID {
+			s.dropReplay(&subscr.feeds[i])
+			s.unsubscribeFromChannel(subscr.feeds[i].event)
 		}
 	}
 	s.subsLock.Unlock()
@@ -417,6 +709,9 @@ func (s *Server) getBlock(reqParams request.Params) (interface{}, *response.Erro
 
 	block, err := s.chain.GetBlock(hash)
 	if err != nil {
+		if err == dao.ErrHeaderOnly {
+			return nil, s.newPrunedDataError(fmt.Sprintf("block %s", hash.StringLE()))
+		}
 		return nil, response.NewInternalServerError(fmt.Sprintf("Problem locating block with hash: %s", hash), err)
 	}
 
@@ -428,6 +723,22 @@ func (s *Server) getBlock(reqParams request.Params) (interface{}, *response.Erro
 	return hex.EncodeToString(writer.Bytes()), nil
 }
 
+// prunedDataHint returns a note about removed transactions and logs if the
+// node is configured to remove them.
+func (s *Server) prunedDataHint() string {
+	if !s.chain.RemovesUntraceableBlocks() {
+		return ""
+	}
+	return fmt.Sprintf("transactions older than %d blocks are not stored", s.chain.GetConfig().MaxTraceableBlocks)
+}
+
+// newPrunedDataError returns an error for the data removed because of the
+// RemoveUntraceableBlocks setting.
+func (s *Server) newPrunedDataError(what string) *response.Error {
+	return response.NewRPCError("Data is pruned", fmt.Sprintf("%s is older than %d blocks, only its header is stored",
+		what, s.chain.GetConfig().MaxTraceableBlocks), nil)
+}
+
 func (s *Server) getBlockHash(reqParams request.Params) (interface{}, *response.Error) {
 	param, ok := reqParams.ValueWithType(0, request.NumberT)
 	if !ok {
@@ -488,7 +799,7 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 
 	appExecResult, err := s.chain.GetAppExecResult(txHash)
 	if err != nil {
-		return nil, response.NewRPCError("Unknown transaction", "", nil)
+		return nil, response.NewRPCError("Unknown transaction", s.prunedDataHint(), nil)
 	}
 
 	tx, _, err := s.chain.GetTransaction(txHash)
@@ -501,6 +812,57 @@ func (s *Server) getApplicationLog(reqParams request.Params) (interface{}, *resp
 	return result.NewApplicationLog(appExecResult, scriptHash), nil
 }
 
+// traceTransaction executes the transaction with the specified txid once again
+// and returns all the instructions executed.
+func (s *Server) traceTransaction(reqParams request.Params) (interface{}, *response.Error) {
+	param, ok := reqParams.Value(0)
+	if !ok {
+		return nil, response.ErrInvalidParams
+	}
+
+	txHash, err := param.GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+
+	var (
+		steps     = make([]result.TraceStep, 0)
+		truncated bool
+	)
+	aer, err := s.chain.TraceTransaction(txHash, func(step vm.TraceStep) {
+		if len(steps) >= maxTraceSteps {
+			truncated = true
+			return
+		}
+		steps = append(steps, result.NewTraceStep(step))
+	})
+	if err != nil {
+		return nil, response.NewRPCError("Can't trace transaction", s.prunedDataHint(), err)
+	}
+	return result.NewTransactionTrace(aer, steps, truncated), nil
+}
+
+// calculateNetworkFee implements the `calculatenetworkfee` RPC call.
+func (s *Server) calculateNetworkFee(reqParams request.Params) (interface{}, *response.Error) {
+	param, ok := reqParams.Value(0)
+	if !ok {
+		return nil, response.ErrInvalidParams
+	}
+	byteTx, err := param.GetBytesHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	tx, err := transaction.NewTransactionFromBytes(byteTx)
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	fee, err := s.chain.CalculateTxNetworkFee(tx)
+	if err != nil {
+		return nil, response.NewRPCError("Can't calculate network fee", err.Error(), err)
+	}
+	return result.NetworkFee{Value: fee}, nil
+}
+
 func (s *Server) getNEP5Balances(ps request.Params) (interface{}, *response.Error) {
 	p, ok := ps.ValueWithType(0, request.StringT)
 	if !ok {
@@ -543,15 +905,47 @@ func (s *Server) getNEP5Transfers(ps request.Params) (interface{}, *response.Err
 	if err != nil {
 		return nil, response.ErrInvalidParams
 	}
+	// Optional parameters are start and end timestamps, limit and page.
+	// Transfers are not limited if there is no limit specified.
+	var opts = []int{0, math.MaxInt64, math.MaxInt64, 0}
+	for i := range opts {
+		if len(ps) <= i+1 {
+			break
+		}
+		opts[i], err = ps[i+1].GetInt()
+		if err != nil || opts[i] < 0 {
+			return nil, response.ErrInvalidParams
+		}
+	}
+	start, end, limit, page := uint64(opts[0]), uint64(opts[1]), opts[2], opts[3]
+	if start > end || limit == 0 || (len(ps) > 3 && limit > maxNEP5TransfersLimit) ||
+		page > math.MaxInt64/limit {
+		return nil, response.ErrInvalidParams
+	}
 
 	bs := &result.NEP5Transfers{
 		Address:  address.Uint160ToString(u),
 		Received: []result.NEP5Transfer{},
 		Sent:     []result.NEP5Transfer{},
 	}
-	lg := s.chain.GetNEP5TransferLog(u)
 	cache := make(map[util.Uint160]int64)
-	err = lg.ForEach(func(tr *state.NEP5Transfer) error {
+	skip := page * limit
+	var count int
+	err = s.chain.ForEachNEP5Transfer(u, func(tr *state.NEP5Transfer) (bool, error) {
+		if tr.Timestamp > end {
+			return true, nil
+		}
+		if tr.Timestamp < start {
+			return false, nil
+		}
+		if count >= limit {
+			bs.Truncated = true
+			return false, nil
+		}
+		if skip > 0 {
+			skip--
+			return true, nil
+		}
 		transfer := result.NEP5Transfer{
 			Timestamp: tr.Timestamp,
 			Asset:     tr.Asset,
@@ -560,7 +954,7 @@ func (s *Server) getNEP5Transfers(ps request.Params) (interface{}, *response.Err
 		}
 		d, err := s.getDecimals(tr.Asset, cache)
 		if err != nil {
-			return nil
+			return true, nil
 		}
 		if tr.Amount > 0 { // token was received
 			transfer.Amount = amountToString(tr.Amount, d)
@@ -568,7 +962,8 @@ func (s *Server) getNEP5Transfers(ps request.Params) (interface{}, *response.Err
 				transfer.Address = address.Uint160ToString(tr.From)
 			}
 			bs.Received = append(bs.Received, transfer)
-			return nil
+			count++
+			return true, nil
 		}
 
 		transfer.Amount = amountToString(-tr.Amount, d)
@@ -576,7 +971,8 @@ func (s *Server) getNEP5Transfers(ps request.Params) (interface{}, *response.Err
 			transfer.Address = address.Uint160ToString(tr.To)
 		}
 		bs.Sent = append(bs.Sent, transfer)
-		return nil
+		count++
+		return true, nil
 	})
 	if err != nil {
 		return nil, response.NewInternalServerError("invalid NEP5 transfer log", err)
@@ -615,8 +1011,8 @@ func (s *Server) getDecimals(h util.Uint160, cache map[util.Uint160]int64) (int6
 	if err != nil {
 		return 0, response.NewInternalServerError("Can't create script", err)
 	}
-	res := s.runScriptInVM(script)
-	if res == nil || res.State != "HALT" || len(res.Stack) == 0 {
+	res, respErr := s.runScriptInVM(script, nil)
+	if respErr != nil || res.State != "HALT" || len(res.Stack) == 0 {
 		return 0, response.NewInternalServerError("execution error", errors.New("no result"))
 	}
 
@@ -636,18 +1032,93 @@ func (s *Server) getDecimals(h util.Uint160, cache map[util.Uint160]int64) (int6
 	return d, nil
 }
 
-func (s *Server) getStorage(ps request.Params) (interface{}, *response.Error) {
-	param, ok := ps.Value(0)
-	if !ok {
+func (s *Server) getProof(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) < 3 {
+		return nil, response.ErrInvalidParams
+	}
+	root, err := ps[0].GetUint256()
+	if err != nil {
 		return nil, response.ErrInvalidParams
 	}
+	sc, respErr := contractHashFromParam(&ps[1])
+	if respErr != nil {
+		return nil, respErr
+	}
+	key, err := ps[2].GetBytesHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	skey := dao.MakeStorageItemKey(sc, key)
+	proof, err := s.chain.GetStateProof(root, skey)
+	return &result.GetProof{
+		Result: result.ProofWithKey{
+			Key:   skey,
+			Proof: proof,
+		},
+		Success: err == nil,
+	}, nil
+}
 
-	scriptHash, err := param.GetUint160FromHex()
+func (s *Server) verifyProof(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) < 2 {
+		return nil, response.ErrInvalidParams
+	}
+	root, err := ps[0].GetUint256()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	proofStr, err := ps[1].GetString()
 	if err != nil {
 		return nil, response.ErrInvalidParams
 	}
+	var p result.ProofWithKey
+	if err := p.FromString(proofStr); err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	vp := new(result.VerifyProof)
+	val, ok := mpt.VerifyProof(root, p.Key, p.Proof)
+	if ok {
+		vp.Value = val
+		// Only storage items are unwrapped, other values are returned as is.
+		if len(p.Key) != 0 && p.Key[0] == byte(storage.STStorage) {
+			var si state.StorageItem
+			r := io.NewBinReaderFromBuf(val)
+			si.DecodeBinary(r)
+			if r.Err != nil {
+				return nil, response.NewInternalServerError("invalid item in trie", r.Err)
+			}
+			vp.Value = si.Value
+		}
+	}
+	return vp, nil
+}
 
-	scriptHash = scriptHash.Reverse()
+func (s *Server) getStateRoot(ps request.Params) (interface{}, *response.Error) {
+	param, ok := ps.ValueWithType(0, request.NumberT)
+	if !ok {
+		return nil, response.ErrInvalidParams
+	}
+	height, err := param.GetInt()
+	if err != nil || height < 0 {
+		return nil, response.ErrInvalidParams
+	}
+	root, err := s.chain.GetStateRoot(uint32(height))
+	if err != nil {
+		return nil, response.NewRPCError("Unknown state root", "", err)
+	}
+	return root, nil
+}
+
+func (s *Server) getStorage(ps request.Params) (interface{}, *response.Error) {
+	param, ok := ps.Value(0)
+	if !ok {
+		return nil, response.ErrInvalidParams
+	}
+
+	scriptHash, respErr := contractHashFromParam(param)
+	if respErr != nil {
+		return nil, respErr
+	}
 
 	param, ok = ps.Value(1)
 	if !ok {
@@ -659,7 +1130,19 @@ func (s *Server) getStorage(ps request.Params) (interface{}, *response.Error) {
 		return nil, response.ErrInvalidParams
 	}
 
-	item := s.chain.GetStorageItem(scriptHash.Reverse(), key)
+	height, historic, respErr := s.stateHeightFromParam(ps, 2)
+	if respErr != nil {
+		return nil, respErr
+	}
+	var item *state.StorageItem
+	if historic {
+		item, err = s.chain.GetStorageItemAt(height, scriptHash, key)
+		if err != nil {
+			return nil, response.NewRPCError("Unknown state", "", err)
+		}
+	} else {
+		item = s.chain.GetStorageItem(scriptHash, key)
+	}
 	if item == nil {
 		return nil, nil
 	}
@@ -667,6 +1150,71 @@ func (s *Server) getStorage(ps request.Params) (interface{}, *response.Error) {
 	return hex.EncodeToString(item.Value), nil
 }
 
+// findStorage returns a page of contract storage items with the given key
+// prefix sorted by key.
+func (s *Server) findStorage(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) < 2 || len(ps) > 4 {
+		return nil, response.ErrInvalidParams
+	}
+	scriptHash, respErr := contractHashFromParam(&ps[0])
+	if respErr != nil {
+		return nil, respErr
+	}
+	prefix, err := ps[1].GetBytesHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	var start []byte
+	if len(ps) > 2 {
+		start, err = ps[2].GetBytesHex()
+		if err != nil || (len(start) != 0 && !bytes.HasPrefix(start, prefix)) {
+			return nil, response.ErrInvalidParams
+		}
+		if len(start) != 0 {
+			start = start[len(prefix):]
+		}
+	}
+	count := s.config.MaxFindResultItems
+	if len(ps) > 3 {
+		count, err = ps[3].GetInt()
+		if err != nil || count <= 0 {
+			return nil, response.ErrInvalidParams
+		}
+		if count > s.config.MaxFindResultItems {
+			count = s.config.MaxFindResultItems
+		}
+	}
+
+	res := &result.FindStorage{Results: make([]result.KeyValue, 0)}
+	err = s.chain.SeekStorageItems(scriptHash, prefix, start, func(k []byte, si *state.StorageItem) bool {
+		if len(res.Results) == count {
+			// One more item is fetched to know where the next page starts.
+			res.Next = hex.EncodeToString(k)
+			res.Truncated = true
+			return false
+		}
+		res.Results = append(res.Results, result.KeyValue{
+			Key:   hex.EncodeToString(k),
+			Value: hex.EncodeToString(si.Value),
+		})
+		return true
+	})
+	if err != nil {
+		return nil, response.NewInternalServerError("Failed to get storage items", err)
+	}
+	return res, nil
+}
+
+// contractHashFromParam parses the contract script hash given in hex (LE,
+// optionally prefixed with 0x).
+func contractHashFromParam(p *request.Param) (util.Uint160, *response.Error) {
+	h, err := p.GetUint160FromHex()
+	if err != nil {
+		return h, response.ErrInvalidParams
+	}
+	return h, nil
+}
+
 func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *response.Error) {
 	var resultsErr *response.Error
 	var results interface{}
@@ -677,7 +1225,11 @@ func (s *Server) getrawtransaction(reqParams request.Params) (interface{}, *resp
 		resultsErr = response.ErrInvalidParams
 	} else if tx, height, err := s.chain.GetTransaction(txHash); err != nil {
 		err = errors.Wrapf(err, "Invalid transaction hash: %s", txHash)
-		return nil, response.NewRPCError("Unknown transaction", err.Error(), err)
+		data := err.Error()
+		if hint := s.prunedDataHint(); hint != "" {
+			data += ", " + hint
+		}
+		return nil, response.NewRPCError("Unknown transaction", data, err)
 	} else if len(reqParams) >= 2 {
 		_header := s.chain.GetHeaderHash(int(height))
 		header, err := s.chain.GetHeader(_header)
@@ -717,7 +1269,7 @@ func (s *Server) getTransactionHeight(ps request.Params) (interface{}, *response
 
 	_, height, err := s.chain.GetTransaction(h)
 	if err != nil {
-		return nil, response.NewRPCError("unknown transaction", "", nil)
+		return nil, response.NewRPCError("unknown transaction", s.prunedDataHint(), nil)
 	}
 
 	return height, nil
@@ -743,6 +1295,98 @@ func (s *Server) getContractState(reqParams request.Params) (interface{}, *respo
 	return results, nil
 }
 
+// getAccountTransactions returns a page of transactions the account was a
+// sender or a cosigner of, newest first.
+func (s *Server) getAccountTransactions(ps request.Params) (interface{}, *response.Error) {
+	p, ok := ps.ValueWithType(0, request.StringT)
+	if !ok || len(ps) > 3 {
+		return nil, response.ErrInvalidParams
+	}
+	u, err := p.GetUint160FromAddress()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	// Optional parameters are page and limit.
+	var opts = []int{0, maxAccountTransactionsLimit}
+	for i := range opts {
+		if len(ps) <= i+1 {
+			break
+		}
+		opts[i], err = ps[i+1].GetInt()
+		if err != nil || opts[i] < 0 {
+			return nil, response.ErrInvalidParams
+		}
+	}
+	page, limit := opts[0], opts[1]
+	if limit == 0 || limit > maxAccountTransactionsLimit || page > math.MaxInt64/limit {
+		return nil, response.ErrInvalidParams
+	}
+	txs, more, err := s.chain.GetAccountTransactions(u, page*limit, limit)
+	if err != nil {
+		if err == core.ErrAccountTransactionIndexDisabled {
+			return nil, response.NewRPCError("Account transaction index is disabled", "", err)
+		}
+		return nil, response.NewInternalServerError("Failed to get account transactions", err)
+	}
+	res := &result.AccountTransactions{
+		Address:      address.Uint160ToString(u),
+		Transactions: make([]result.AccountTransaction, 0, len(txs)),
+		Truncated:    more,
+	}
+	for _, tx := range txs {
+		h, err := s.chain.GetHeader(s.chain.GetHeaderHash(int(tx.BlockIndex)))
+		if err != nil {
+			return nil, response.NewInternalServerError("Failed to get block header", err)
+		}
+		res.Transactions = append(res.Transactions, result.AccountTransaction{
+			TxHash:     tx.TxHash,
+			BlockIndex: tx.BlockIndex,
+			Timestamp:  h.Timestamp,
+		})
+	}
+	return res, nil
+}
+
+// getContractNotifications returns a page of notifications with the given
+// event name emitted by the contract in the given range of blocks.
+func (s *Server) getContractNotifications(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) < 2 || len(ps) > 5 {
+		return nil, response.ErrInvalidParams
+	}
+	contract, err := ps[0].GetUint160FromHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	name, err := ps[1].GetString()
+	if err != nil || name == "" {
+		return nil, response.ErrInvalidParams
+	}
+	// Optional parameters are start and end heights and page.
+	var opts = []int{0, int(s.chain.BlockHeight()), 0}
+	for i := range opts {
+		if len(ps) <= i+2 {
+			break
+		}
+		opts[i], err = ps[i+2].GetInt()
+		if err != nil || opts[i] < 0 {
+			return nil, response.ErrInvalidParams
+		}
+	}
+	from, to, page := opts[0], opts[1], opts[2]
+	if from > to {
+		return nil, response.ErrInvalidParams
+	}
+	ns, more, err := s.chain.GetContractNotifications(contract, name, uint32(from), uint32(to),
+		page*notificationsPageSize, notificationsPageSize)
+	if err != nil {
+		if err == core.ErrNotificationIndexDisabled {
+			return nil, response.NewRPCError("Notification index is disabled", "", err)
+		}
+		return nil, response.NewInternalServerError("Failed to get notifications", err)
+	}
+	return result.NewContractNotifications(ns, more), nil
+}
+
 // getBlockSysFee returns the system fees of the block, based on the specified index.
 func (s *Server) getBlockSysFee(reqParams request.Params) (interface{}, *response.Error) {
 	param, ok := reqParams.ValueWithType(0, request.NumberT)
@@ -758,6 +1402,9 @@ func (s *Server) getBlockSysFee(reqParams request.Params) (interface{}, *respons
 	headerHash := s.chain.GetHeaderHash(num)
 	block, errBlock := s.chain.GetBlock(headerHash)
 	if errBlock != nil {
+		if errBlock == dao.ErrHeaderOnly {
+			return 0, s.newPrunedDataError(fmt.Sprintf("block %d", num))
+		}
 		return 0, response.NewRPCError(errBlock.Error(), "", nil)
 	}
 
@@ -828,7 +1475,8 @@ func (s *Server) getUnclaimedGas(ps request.Params) (interface{}, *response.Erro
 	return strconv.FormatInt(int64(gas), 10), nil                                     // It's not represented as Fixed8 in C#.
 }
 
-// getValidators returns the current NEO consensus nodes information and voting status.
+// getValidators returns the current NEO consensus nodes information and voting
+// status, it's used for both getvalidators and getcandidates.
 func (s *Server) getValidators(_ request.Params) (interface{}, *response.Error) {
 	var validators keys.PublicKeys
 
@@ -840,7 +1488,7 @@ func (s *Server) getValidators(_ request.Params) (interface{}, *response.Error)
 	if err != nil {
 		return nil, response.NewRPCError("can't get enrollments", "", err)
 	}
-	var res []result.Validator
+	res := make([]result.Validator, 0, len(enrollments))
 	for _, v := range enrollments {
 		res = append(res, result.Validator{
 			PublicKey: *v.Key,
@@ -851,6 +1499,46 @@ func (s *Server) getValidators(_ request.Params) (interface{}, *response.Error)
 	return res, nil
 }
 
+// getNextBlockValidators returns next block validators with their votes
+// (validators that are not registered as candidates have no votes).
+func (s *Server) getNextBlockValidators(_ request.Params) (interface{}, *response.Error) {
+	validators, err := s.chain.GetValidators()
+	if err != nil {
+		return nil, response.NewRPCError("can't get validators", "", err)
+	}
+	enrollments, err := s.chain.GetEnrollments()
+	if err != nil {
+		return nil, response.NewRPCError("can't get enrollments", "", err)
+	}
+	votes := make(map[string]int64, len(enrollments))
+	for _, v := range enrollments {
+		votes[string(v.Key.Bytes())] = v.Votes.Int64()
+	}
+	res := make([]result.Validator, 0, len(validators))
+	for _, v := range validators {
+		res = append(res, result.Validator{
+			PublicKey: *v,
+			Votes:     votes[string(v.Bytes())],
+			Active:    true,
+		})
+	}
+	return res, nil
+}
+
+// getCommittee returns public keys of the committee members, it's represented
+// by the next block validators at the moment.
+func (s *Server) getCommittee(_ request.Params) (interface{}, *response.Error) {
+	committee, err := s.chain.GetValidators()
+	if err != nil {
+		return nil, response.NewRPCError("can't get committee", "", err)
+	}
+	res := make([]string, 0, len(committee))
+	for _, k := range committee {
+		res = append(res, hex.EncodeToString(k.Bytes()))
+	}
+	return res, nil
+}
+
 // invoke implements the `invoke` RPC call.
 func (s *Server) invoke(reqParams request.Params) (interface{}, *response.Error) {
 	scriptHashHex, ok := reqParams.ValueWithType(0, request.StringT)
@@ -873,7 +1561,7 @@ func (s *Server) invoke(reqParams request.Params) (interface{}, *response.Error)
 	if err != nil {
 		return nil, response.NewInternalServerError("can't create invocation script", err)
 	}
-	return s.runScriptInVM(script), nil
+	return s.runScriptInVM(script, nil)
 }
 
 // invokescript implements the `invokescript` RPC call.
@@ -886,11 +1574,19 @@ func (s *Server) invokeFunction(reqParams request.Params) (interface{}, *respons
 	if err != nil {
 		return nil, response.ErrInvalidParams
 	}
-	script, err := request.CreateFunctionInvocationScript(scriptHash, reqParams[1:])
+	args := reqParams[1:]
+	if len(args) > 2 {
+		args = args[:2]
+	}
+	script, err := request.CreateFunctionInvocationScript(scriptHash, args)
 	if err != nil {
 		return nil, response.NewInternalServerError("can't create invocation script", err)
 	}
-	return s.runScriptInVM(script), nil
+	var opts request.Params
+	if len(reqParams) > 3 {
+		opts = reqParams[3:]
+	}
+	return s.runScriptInVM(script, opts)
 }
 
 // invokescript implements the `invokescript` RPC call.
@@ -903,24 +1599,135 @@ func (s *Server) invokescript(reqParams request.Params) (interface{}, *response.
 	if err != nil {
 		return nil, response.ErrInvalidParams
 	}
+	return s.runScriptInVM(script, reqParams[1:])
+}
 
-	return s.runScriptInVM(script), nil
+// newTestTx creates a fake transaction with the given script to be used as
+// a script container for test invocations. The first of the signers
+// specified by param becomes the sender.
+func (s *Server) newTestTx(script []byte, param request.Param) (*transaction.Transaction, error) {
+	arr, err := param.GetArray()
+	if err != nil {
+		return nil, err
+	}
+	tx := transaction.New(script, 0)
+	tx.ValidUntilBlock = s.chain.BlockHeight() + 1
+	for i := range arr {
+		sgn, err := arr[i].GetSigner()
+		if err != nil {
+			return nil, err
+		}
+		tx.Cosigners = append(tx.Cosigners, sgn.Cosigner)
+		tx.Scripts = append(tx.Scripts, sgn.Witness)
+	}
+	if len(tx.Cosigners) != 0 {
+		tx.Sender = tx.Cosigners[0].Account
+	}
+	return tx, nil
 }
 
-// runScriptInVM runs given script in a new test VM and returns the invocation
-// result.
-func (s *Server) runScriptInVM(script []byte) *result.Invoke {
-	vm := s.chain.GetTestVM()
-	vm.SetGasLimit(s.config.MaxGasInvoke)
-	vm.LoadScript(script)
-	_ = vm.Run()
-	result := &result.Invoke{
-		State:       vm.State(),
-		GasConsumed: vm.GasConsumed().String(),
-		Script:      hex.EncodeToString(script),
-		Stack:       vm.Estack().ToContractParameters(),
+// stateHeightFromParam returns the index of the block specified by the optional
+// block index or hash parameter ps[i]. It returns false if there is no such
+// parameter, which means that the current state is to be used. An error is
+// returned for this parameter if historical state is disabled in the config.
+func (s *Server) stateHeightFromParam(ps request.Params, i int) (uint32, bool, *response.Error) {
+	param, ok := ps.Value(i)
+	if !ok {
+		return 0, false, nil
+	}
+	if !s.config.EnableHistoricalState {
+		return 0, false, response.NewRPCError("Historical state is disabled", "", nil)
+	}
+	hash, respErr := s.blockHashFromParam(param)
+	if respErr != nil {
+		return 0, false, respErr
+	}
+	header, err := s.chain.GetHeader(hash)
+	if err != nil {
+		return 0, false, response.NewRPCError("Unknown block", "", err)
 	}
-	return result
+	return header.Index, true, nil
+}
+
+// runScriptInVM runs given script in a test VM and returns the invocation
+// result. opts are optional parameters following the script ones: an array
+// of signers and then a block index or hash to use the historical state of.
+func (s *Server) runScriptInVM(script []byte, opts request.Params) (*result.Invoke, *response.Error) {
+	var tx *transaction.Transaction
+	if len(opts) != 0 && opts[0].Type == request.ArrayT {
+		var err error
+		tx, err = s.newTestTx(script, opts[0])
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		opts = opts[1:]
+	}
+	height, historic, respErr := s.stateHeightFromParam(opts, 0)
+	if respErr != nil {
+		return nil, respErr
+	}
+	var res *state.TestExecResult
+	if !historic {
+		res = s.chain.TestInvoke(script, tx, s.config.MaxGasInvoke)
+	} else {
+		var err error
+		res, err = s.chain.TestInvokeAt(height, script, tx, s.config.MaxGasInvoke)
+		if err != nil {
+			return nil, response.NewRPCError("Unknown state", "", err)
+		}
+	}
+	inv := result.NewInvoke(res, script)
+	if len(res.Iterators) != 0 {
+		id, err := s.newSession(res.Iterators)
+		if err != nil {
+			return nil, response.NewInternalServerError("Can't create iterator session", err)
+		}
+		inv.Session = id
+	}
+	return inv, nil
+}
+
+// traverseIterator returns next items of the iterator from the given session.
+func (s *Server) traverseIterator(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) != 3 {
+		return nil, response.ErrInvalidParams
+	}
+	id, err := ps[0].GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	iterator, err := ps[1].GetInt()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	count, err := ps[2].GetInt()
+	if err != nil || count <= 0 {
+		return nil, response.ErrInvalidParams
+	}
+	if count > s.config.MaxIteratorResultItems {
+		return nil, response.NewInvalidParamsError(fmt.Sprintf("count is out of range: %d > %d", count, s.config.MaxIteratorResultItems), nil)
+	}
+	items, ok := s.traverseSession(id, iterator, count)
+	if !ok {
+		return nil, response.NewRPCError("Unknown session or iterator", "", nil)
+	}
+	res := make([]smartcontract.Parameter, 0, len(items))
+	for _, item := range items {
+		res = append(res, smartcontract.ParameterFromStackItem(item, make(map[stackitem.Item]bool)))
+	}
+	return res, nil
+}
+
+// terminateSessionCall drops the given iterator session.
+func (s *Server) terminateSessionCall(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) != 1 {
+		return nil, response.ErrInvalidParams
+	}
+	id, err := ps[0].GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	return s.terminateSession(id), nil
 }
 
 // submitBlock broadcasts a raw block over the NEO network.
@@ -964,28 +1771,49 @@ func (s *Server) sendrawtransaction(reqParams request.Params) (interface{}, *res
 		if err != nil {
 			return nil, response.ErrInvalidParams
 		}
-		relayReason := s.coreServer.RelayTxn(tx)
-		switch relayReason {
-		case network.RelaySucceed:
+		resultsErr = s.relayTx(tx)
+		if resultsErr == nil {
 			results = true
-		case network.RelayAlreadyExists:
-			resultsErr = response.ErrAlreadyExists
-		case network.RelayOutOfMemory:
-			resultsErr = response.ErrOutOfMemory
-		case network.RelayUnableToVerify:
-			resultsErr = response.ErrUnableToVerify
-		case network.RelayInvalid:
-			resultsErr = response.ErrValidationFailed
-		case network.RelayPolicyFail:
-			resultsErr = response.ErrPolicyFail
-		default:
-			resultsErr = response.ErrUnknown
 		}
 	}
 
 	return results, resultsErr
 }
 
+// relayTx adds transaction to the memory pool and relays it to the network
+// returning an error if it fails.
+func (s *Server) relayTx(tx *transaction.Transaction) *response.Error {
+	var (
+		e      *response.Error
+		reason string
+	)
+	r, err := s.coreServer.RelayTxnWithError(tx)
+	switch r {
+	case network.RelaySucceed:
+		return nil
+	case network.RelayAlreadyExists:
+		e, reason = response.ErrAlreadyExists, response.ReasonAlreadyExists
+	case network.RelayOutOfMemory:
+		e, reason = response.ErrOutOfMemory, response.ReasonOutOfMemory
+	case network.RelayUnableToVerify:
+		e, reason = response.ErrUnableToVerify, response.ReasonUnableToVerify
+	case network.RelayInvalid:
+		e, reason = response.ErrValidationFailed, response.ReasonValidationFailed
+	case network.RelayPolicyFail:
+		e, reason = response.ErrPolicyFail, response.ReasonPolicyFail
+	case network.RelayConflict:
+		e, reason = response.ErrConflict, response.ReasonConflict
+	case network.RelayDuplicate:
+		e, reason = response.ErrDuplicate, response.ReasonDuplicate
+	default:
+		e, reason = response.ErrUnknown, response.ReasonUnknown
+	}
+	if err != nil {
+		reason += ": " + err.Error()
+	}
+	return response.NewError(e.Code, e.HTTPCode, e.Message, reason, err)
+}
+
 // subscribe handles subscription requests from websocket clients.
 func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface{}, *response.Error) {
 	p, ok := reqParams.Value(0)
@@ -997,32 +1825,60 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 		return nil, response.ErrInvalidParams
 	}
 	event, err := response.GetEventIDFromString(streamName)
-	if err != nil || event == response.MissedEventID {
+	if err != nil {
+		var ok bool
+		if event, ok = s.extEvents[streamName]; !ok {
+			return nil, response.ErrInvalidParams
+		}
+	} else if event == response.MissedEventID {
 		return nil, response.ErrInvalidParams
 	}
-	// Optional filter.
-	var filter interface{}
-	p, ok = reqParams.Value(1)
-	if ok {
+	// Optional filter and options (that can also be passed without a filter).
+	var (
+		filter interface{}
+		opts   request.SubscriptionOptions
+	)
+	next := 1
+	p, ok = reqParams.Value(next)
+	if ok && p.Type != request.SubscriptionOptionsT {
 		switch event {
 		case response.BlockEventID:
-			if p.Type != request.BlockFilterT {
-				return nil, response.ErrInvalidParams
-			}
-		case response.TransactionEventID:
-			if p.Type != request.TxFilterT {
-				return nil, response.ErrInvalidParams
-			}
+			ok = p.Type == request.BlockFilterT
+		case response.TransactionEventID, response.MempoolAddedEventID, response.MempoolRemovedEventID:
+			ok = p.Type == request.TxFilterT
 		case response.NotificationEventID:
-			if p.Type != request.NotificationFilterT {
-				return nil, response.ErrInvalidParams
-			}
+			ok = p.Type == request.NotificationFilterT
 		case response.ExecutionEventID:
-			if p.Type != request.ExecutionFilterT {
-				return nil, response.ErrInvalidParams
-			}
+			ok = p.Type == request.ExecutionFilterT
+		default:
+			ok = false
+		}
+		if !ok {
+			return nil, response.ErrInvalidParams
 		}
 		filter = p.Value
+		next++
+		p, ok = reqParams.Value(next)
+	}
+	if ok {
+		if p.Type != request.SubscriptionOptionsT {
+			return nil, response.ErrInvalidParams
+		}
+		next++
+		opts = p.Value.(request.SubscriptionOptions)
+		if opts.Name != nil && event != response.NotificationEventID {
+			return nil, response.NewInvalidParamsError("name is only supported for notifications", nil)
+		}
+		switch event {
+		case response.BlockEventID, response.TransactionEventID, response.NotificationEventID, response.ExecutionEventID:
+		default:
+			if opts.FromBlock != nil {
+				return nil, response.NewInvalidParamsError("fromBlock is not supported for this event", nil)
+			}
+		}
+	}
+	if _, ok = reqParams.Value(next); ok {
+		return nil, response.ErrInvalidParams
 	}
 
 	s.subsLock.Lock()
@@ -1032,6 +1888,29 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 		return nil, response.NewInternalServerError("server is shutting down", nil)
 	default:
 	}
+	// Height is fixed here, all the events of the following blocks are
+	// going to be delivered via live feed.
+	height := s.chain.BlockHeight()
+	if opts.FromBlock != nil {
+		if *opts.FromBlock > height+1 {
+			return nil, response.NewInvalidParamsError("fromBlock is higher than the next block", nil)
+		}
+		if height+1-*opts.FromBlock > uint32(s.config.MaxReplayBlocks) {
+			return nil, response.NewInvalidParamsError(fmt.Sprintf("fromBlock is too old, at most %d blocks can be replayed", s.config.MaxReplayBlocks), nil)
+		}
+		if s.replays >= s.config.MaxReplays {
+			return nil, response.NewInternalServerError("maximum number of replays is reached", nil)
+		}
+		var replays int
+		for i := range sub.feeds {
+			if sub.feeds[i].replay != nil {
+				replays++
+			}
+		}
+		if replays >= maxClientReplays {
+			return nil, response.NewInternalServerError("maximum number of replays per client is reached", nil)
+		}
+	}
 	var id int
 	for ; id < len(sub.feeds); id++ {
 		if sub.feeds[id].event == response.InvalidEventID {
@@ -1043,7 +1922,19 @@ func (s *Server) subscribe(reqParams request.Params, sub *subscriber) (interface
 	}
 	sub.feeds[id].event = event
 	sub.feeds[id].filter = filter
+	sub.feeds[id].name = opts.Name
 	s.subscribeToChannel(event)
+	if opts.FromBlock != nil {
+		r := &replay{stop: make(chan struct{})}
+		// Notifications are tied to blocks via executions.
+		if event == response.NotificationEventID {
+			s.subscribeToChannel(response.ExecutionEventID)
+			r.execSub = true
+		}
+		sub.feeds[id].replay = r
+		s.replays++
+		go s.replayEvents(sub, sub.feeds[id], r, *opts.FromBlock, height)
+	}
 	return strconv.FormatInt(int64(id), 10), nil
 }
 
@@ -1072,6 +1963,16 @@ func (s *Server) subscribeToChannel(event response.EventID) {
 			s.chain.SubscribeForExecutions(s.executionCh)
 		}
 		s.executionSubs++
+	case response.HeaderEventID:
+		if s.headerSubs == 0 {
+			s.chain.SubscribeForHeaders(s.headerCh)
+		}
+		s.headerSubs++
+	case response.MempoolAddedEventID, response.MempoolRemovedEventID:
+		if s.mempoolSubs == 0 {
+			s.chain.GetMemPool().SubscribeForTransactions(s.mempoolCh)
+		}
+		s.mempoolSubs++
 	}
 }
 
@@ -1091,8 +1992,10 @@ func (s *Server) unsubscribe(reqParams request.Params, sub *subscriber) (interfa
 		return nil, response.ErrInvalidParams
 	}
 	event := sub.feeds[id].event
+	s.dropReplay(&sub.feeds[id])
 	sub.feeds[id].event = response.InvalidEventID
 	sub.feeds[id].filter = nil
+	sub.feeds[id].name = nil
 	s.unsubscribeFromChannel(event)
 	return true, nil
 }
@@ -1122,6 +2025,16 @@ func (s *Server) unsubscribeFromChannel(event response.EventID) {
 		if s.executionSubs == 0 {
 			s.chain.UnsubscribeFromExecutions(s.executionCh)
 		}
+	case response.HeaderEventID:
+		s.headerSubs--
+		if s.headerSubs == 0 {
+			s.chain.UnsubscribeFromHeaders(s.headerCh)
+		}
+	case response.MempoolAddedEventID, response.MempoolRemovedEventID:
+		s.mempoolSubs--
+		if s.mempoolSubs == 0 {
+			s.chain.GetMemPool().UnsubscribeFromTransactions(s.mempoolCh)
+		}
 	}
 }
 
@@ -1154,6 +2067,7 @@ chloop:
 			resp.Event = response.BlockEventID
 			resp.Payload[0] = b
 		case execution := <-s.executionCh:
+			s.lastExecTx = execution.TxHash
 			resp.Event = response.ExecutionEventID
 			resp.Payload[0] = result.NewApplicationLog(execution, util.Uint160{})
 		case notification := <-s.notificationCh:
@@ -1162,24 +2076,48 @@ chloop:
 		case tx := <-s.transactionCh:
 			resp.Event = response.TransactionEventID
 			resp.Payload[0] = tx
+		case h := <-s.headerCh:
+			resp.Event = response.HeaderEventID
+			resp.Payload[0] = h
+		case e := <-s.mempoolCh:
+			resp.Event = response.MempoolAddedEventID
+			if e.Type == mempool.TransactionRemoved {
+				resp.Event = response.MempoolRemovedEventID
+			}
+			resp.Payload[0] = e.Tx
+		case e := <-s.extensionCh:
+			resp.Event = e.id
+			resp.Payload[0] = e.payload
+			msg, err = s.newExtensionMessage(e)
+			if err != nil {
+				s.log.Error("failed to prepare notification message",
+					zap.Error(err),
+					zap.String("type", s.extEventNames[e.id]))
+				continue
+			}
 		}
+		var (
+			index    uint32
+			indexSet bool
+		)
 		s.subsLock.RLock()
 	subloop:
 		for sub := range s.subscribers {
 			if sub.overflown.Load() {
 				continue
 			}
+			var pending *replay
 			for i := range sub.feeds {
 				if sub.feeds[i].Matches(&resp) {
-					if msg == nil {
-						b, err = json.Marshal(resp)
-						if err != nil {
-							s.log.Error("failed to marshal notification",
-								zap.Error(err),
-								zap.String("type", resp.Event.String()))
-							break subloop
+					if sub.feeds[i].replay != nil {
+						if pending == nil {
+							pending = sub.feeds[i].replay
 						}
-						msg, err = websocket.NewPreparedMessage(websocket.TextMessage, b)
+						continue
+					}
+					pending = nil
+					if msg == nil {
+						msg, err = newNotificationMessage(&resp)
 						if err != nil {
 							s.log.Error("failed to prepare notification message",
 								zap.Error(err),
@@ -1201,6 +2139,22 @@ chloop:
 					break
 				}
 			}
+			// Replayed feed matched, but no live one did.
+			if pending != nil {
+				if msg == nil {
+					msg, err = newNotificationMessage(&resp)
+					if err != nil {
+						s.log.Error("failed to prepare notification message",
+							zap.Error(err),
+							zap.String("type", resp.Event.String()))
+						break subloop
+					}
+				}
+				if !indexSet {
+					index, indexSet = s.eventBlockIndex(&resp), true
+				}
+				pending.add(index, msg)
+			}
 		}
 		s.subsLock.RUnlock()
 	}
@@ -1214,14 +2168,19 @@ chloop:
 	s.chain.UnsubscribeFromTransactions(s.transactionCh)
 	s.chain.UnsubscribeFromNotifications(s.notificationCh)
 	s.chain.UnsubscribeFromExecutions(s.executionCh)
+	s.chain.UnsubscribeFromHeaders(s.headerCh)
+	s.chain.GetMemPool().UnsubscribeFromTransactions(s.mempoolCh)
 	s.subsLock.Unlock()
 drainloop:
 	for {
 		select {
 		case <-s.blockCh:
 		case <-s.executionCh:
+		case <-s.headerCh:
+		case <-s.mempoolCh:
 		case <-s.notificationCh:
 		case <-s.transactionCh:
+		case <-s.extensionCh:
 		default:
 			break drainloop
 		}
@@ -1229,11 +2188,170 @@ drainloop:
 	// It's not required closing these, but since they're drained already
 	// this is safe and it also allows to give a signal to Shutdown routine.
 	close(s.blockCh)
+	close(s.headerCh)
+	close(s.mempoolCh)
 	close(s.transactionCh)
 	close(s.notificationCh)
 	close(s.executionCh)
 }
 
+// eventBlockIndex returns the index of the block the event belongs to. It
+// can only be used from handleSubEvents.
+func (s *Server) eventBlockIndex(resp *response.Notification) uint32 {
+	var txHash util.Uint256
+	switch resp.Event {
+	case response.BlockEventID:
+		return resp.Payload[0].(*block.Block).Index
+	case response.TransactionEventID:
+		txHash = resp.Payload[0].(*transaction.Transaction).Hash()
+	case response.ExecutionEventID:
+		txHash = resp.Payload[0].(result.ApplicationLog).TxHash
+	case response.NotificationEventID:
+		txHash = s.lastExecTx
+	default:
+		return 0
+	}
+	_, height, err := s.chain.GetTransaction(txHash)
+	if err != nil {
+		return 0
+	}
+	return height
+}
+
+// blockEvents returns events of the given type generated by the stored
+// block in the same order they're generated by the chain.
+func (s *Server) blockEvents(index uint32, event response.EventID) ([]response.Notification, error) {
+	b, err := s.chain.GetBlock(s.chain.GetHeaderHash(int(index)))
+	if err != nil {
+		return nil, err
+	}
+	var resps []response.Notification
+	add := func(e response.EventID, payload interface{}) {
+		resps = append(resps, response.Notification{
+			JSONRPC: request.JSONRPCVersion,
+			Event:   e,
+			Payload: []interface{}{payload},
+		})
+	}
+	for _, tx := range b.Transactions {
+		if event == response.ExecutionEventID || event == response.NotificationEventID {
+			aer, err := s.chain.GetAppExecResult(tx.Hash())
+			if err != nil {
+				return nil, err
+			}
+			if event == response.ExecutionEventID {
+				add(event, result.NewApplicationLog(aer, util.Uint160{}))
+			} else if aer.VMState == "HALT" {
+				for i := range aer.Events {
+					add(event, result.StateEventToResultNotification(aer.Events[i]))
+				}
+			}
+		}
+		if event == response.TransactionEventID {
+			add(event, tx)
+		}
+	}
+	if event == response.BlockEventID {
+		add(event, b)
+	}
+	return resps, nil
+}
+
+// replayEvents sends the events from stored blocks starting with `from` up
+// to `till` matching the feed to the subscriber, then it sends live events
+// received in the meantime and switches the feed to live mode.
+func (s *Server) replayEvents(sub *subscriber, f feed, r *replay, from, till uint32) {
+	missedMsg, err := newNotificationMessage(&response.Notification{
+		JSONRPC: request.JSONRPCVersion,
+		Event:   response.MissedEventID,
+		Payload: make([]interface{}, 0),
+	})
+	if err != nil {
+		s.log.Error("failed to prepare missed event message", zap.Error(err))
+		return
+	}
+	send := func(msg *websocket.PreparedMessage) bool {
+		select {
+		case sub.writer <- msg:
+			return true
+		case <-r.stop:
+		case <-s.shutdown:
+		}
+		return false
+	}
+blocks:
+	for i := from; i <= till; i++ {
+		resps, err := s.blockEvents(i, f.event)
+		if err != nil {
+			s.log.Error("failed to replay block events", zap.Uint32("block", i), zap.Error(err))
+			s.subsLock.Lock()
+			r.missed = true
+			s.subsLock.Unlock()
+			break
+		}
+		for j := range resps {
+			if !f.Matches(&resps[j]) {
+				continue
+			}
+			msg, err := newNotificationMessage(&resps[j])
+			if err != nil {
+				s.log.Error("failed to prepare notification message", zap.Error(err))
+				s.subsLock.Lock()
+				r.missed = true
+				s.subsLock.Unlock()
+				break blocks
+			}
+			if !send(msg) {
+				return
+			}
+		}
+	}
+	for {
+		s.subsLock.Lock()
+		select {
+		case <-r.stop:
+			s.subsLock.Unlock()
+			return
+		default:
+		}
+		pending, missed := r.pending, r.missed
+		r.pending, r.missed = nil, false
+		if len(pending) == 0 && !missed {
+			for i := range sub.feeds {
+				if sub.feeds[i].replay == r {
+					s.dropReplay(&sub.feeds[i])
+				}
+			}
+			s.subsLock.Unlock()
+			return
+		}
+		s.subsLock.Unlock()
+		if missed && !send(missedMsg) {
+			return
+		}
+		for _, p := range pending {
+			// Events of the replayed blocks are already sent.
+			if p.index > till && !send(p.msg) {
+				return
+			}
+		}
+	}
+}
+
+// dropReplay stops the replay of the feed if there is any. It's supposed to
+// be called with s.subsLock taken by the caller.
+func (s *Server) dropReplay(f *feed) {
+	if f.replay == nil {
+		return
+	}
+	close(f.replay.stop)
+	if f.replay.execSub {
+		s.unsubscribeFromChannel(response.ExecutionEventID)
+	}
+	f.replay = nil
+	s.replays--
+}
+
 func (s *Server) blockHeightFromParam(param *request.Param) (int, *response.Error) {
 	num, err := param.GetInt()
 	if err != nil {
@@ -1250,7 +2368,7 @@ func (s *Server) packResponseToRaw(r *request.In, result interface{}, respErr *r
 	resp := response.Raw{
 		HeaderAndError: response.HeaderAndError{
 			Header: response.Header{
-				JSONRPC: r.JSONRPC,
+				JSONRPC: request.JSONRPCVersion,
 				ID:      r.RawID,
 			},
 		},
@@ -1286,22 +2404,47 @@ func (s *Server) logRequestError(r *request.In, jsonErr *response.Error) {
 	s.log.Error("Error encountered with rpc request", logFields...)
 }
 
+// logRequestErrors logs all errors of the response to the given request.
+func (s *Server) logRequestErrors(r *request.Request, resp response.AbstractResult) {
+	batch, ok := resp.(response.RawBatch)
+	if !ok {
+		in := r.In
+		if in == nil {
+			in = request.NewIn()
+		}
+		resp.RunForErrors(func(jsonErr *response.Error) {
+			s.logRequestError(in, jsonErr)
+		})
+		return
+	}
+	for i := range batch {
+		batch[i].RunForErrors(func(jsonErr *response.Error) {
+			s.logRequestError(&r.Batch[i], jsonErr)
+		})
+	}
+}
+
 // writeHTTPErrorResponse writes an error response to the ResponseWriter.
 func (s *Server) writeHTTPErrorResponse(r *request.In, w http.ResponseWriter, jsonErr *response.Error) {
 	resp := s.packResponseToRaw(r, nil, jsonErr)
-	s.writeHTTPServerResponse(r, w, resp)
+	s.writeHTTPServerResponse(&request.Request{In: r}, w, resp)
 }
 
-func (s *Server) writeHTTPServerResponse(r *request.In, w http.ResponseWriter, resp response.Raw) {
+func (s *Server) writeHTTPServerResponse(r *request.Request, w http.ResponseWriter, resp response.AbstractResult) {
 	// Errors can happen in many places and we can only catch ALL of them here.
-	if resp.Error != nil {
-		s.logRequestError(r, resp.Error)
-		w.WriteHeader(resp.Error.HTTPCode)
-	}
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	s.logRequestErrors(r, resp)
 	if s.config.EnableCORSWorkaround {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-API-Key, X-Requested-With")
+	}
+	resp = filterNotifications(r, resp)
+	if resp == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if raw, ok := resp.(response.Raw); ok && raw.Error != nil {
+		w.WriteHeader(raw.Error.HTTPCode)
 	}
 
 	encoder := json.NewEncoder(w)
@@ -1309,9 +2452,28 @@ func (s *Server) writeHTTPServerResponse(r *request.In, w http.ResponseWriter, r
 
 	if err != nil {
 		s.log.Error("Error encountered while encoding response",
-			zap.String("err", err.Error()),
-			zap.String("method", r.Method))
+			zap.String("err", err.Error()))
+	}
+}
+
+// remoteIP returns IP address part of the given remote address.
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+func stringSet(ss []string) map[string]bool {
+	if len(ss) == 0 {
+		return nil
+	}
+	m := make(map[string]bool, len(ss))
+	for _, s := range ss {
+		m[s] = true
 	}
+	return m
 }
 
 // validateAddress verifies that the address is a correct NEO address
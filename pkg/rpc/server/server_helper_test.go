@@ -12,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -58,12 +59,22 @@ func getTestBlocks(t *testing.T) []*block.Block {
 }
 
 func initClearServerWithInMemoryChain(t *testing.T) (*core.Blockchain, *Server, *httptest.Server) {
+	return initClearServerWithConfig(t, nil)
+}
+
+// initClearServerWithConfig is like initClearServerWithInMemoryChain, but
+// allows to modify RPC server configuration with the given function.
+func initClearServerWithConfig(t *testing.T, f func(*rpc.Config)) (*core.Blockchain, *Server, *httptest.Server) {
 	chain, cfg, logger := getUnitTestChain(t)
 
 	serverConfig := network.NewServerConfig(cfg)
 	server, err := network.NewServer(serverConfig, chain, logger)
 	require.NoError(t, err)
-	rpcServer := New(chain, cfg.ApplicationConfiguration.RPC, server, logger)
+	rpcCfg := cfg.ApplicationConfiguration.RPC
+	if f != nil {
+		f(&rpcCfg)
+	}
+	rpcServer := New(chain, rpcCfg, server, logger)
 	errCh := make(chan error, 2)
 	go rpcServer.Start(errCh)
 
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -17,14 +19,20 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/blockchainer"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
@@ -52,6 +60,233 @@ type rpcTestCase struct {
 const testContractHash = "1b4357bff5a01bdf2a6581247cf9ed1e24629176"
 
 var rpcTestCases = map[string][]rpcTestCase{
+	"calculatenetworkfee": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "not a transaction",
+			params: `["0102"]`,
+			fail:   true,
+		},
+	},
+	"getaccounttransactions": {
+		{
+			name:   "positive",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `"]`,
+			result: func(e *executor) interface{} { return &result.AccountTransactions{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				at := res.(*result.AccountTransactions)
+				require.Equal(t, testchain.PrivateKeyByID(0).Address(), at.Address)
+				require.False(t, at.Truncated)
+				require.True(t, len(at.Transactions) > 1)
+				checkAccountTransactions(t, e, testchain.PrivateKeyByID(0).GetScriptHash(), at.Transactions)
+			},
+		},
+		{
+			name:   "paging",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 1, 1]`,
+			result: func(e *executor) interface{} { return &result.AccountTransactions{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				acc := testchain.PrivateKeyByID(0).GetScriptHash()
+				txs, _, err := e.chain.GetAccountTransactions(acc, 0, 2)
+				require.NoError(t, err)
+				require.Equal(t, 2, len(txs))
+
+				at := res.(*result.AccountTransactions)
+				require.True(t, at.Truncated)
+				require.Equal(t, 1, len(at.Transactions))
+				require.Equal(t, txs[1].TxHash, at.Transactions[0].TxHash)
+				checkAccountTransactions(t, e, acc, at.Transactions)
+			},
+		},
+		{
+			name:   "unknown account",
+			params: `["` + address.Uint160ToString(util.Uint160{1, 2, 3}) + `"]`,
+			result: func(e *executor) interface{} {
+				return &result.AccountTransactions{
+					Address:      address.Uint160ToString(util.Uint160{1, 2, 3}),
+					Transactions: []result.AccountTransaction{},
+				}
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid address",
+			params: `["notanaddress"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid page",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", -1]`,
+			fail:   true,
+		},
+		{
+			name:   "zero limit",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 0]`,
+			fail:   true,
+		},
+		{
+			name:   "big limit",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 1001]`,
+			fail:   true,
+		},
+		{
+			name:   "page overflow",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 10000000000000000, 1000]`,
+			fail:   true,
+		},
+	},
+	"getcontractnotifications": {
+		{
+			name:   "positive",
+			params: fmt.Sprintf(`["%s", "transfer"]`, testContractHash),
+			result: func(e *executor) interface{} { return &result.ContractNotifications{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				cn := res.(*result.ContractNotifications)
+				require.False(t, cn.Truncated)
+				require.Equal(t, 3, len(cn.Notifications))
+				checkContractNotifications(t, e, cn.Notifications)
+				for i := 1; i < len(cn.Notifications); i++ {
+					require.True(t, cn.Notifications[i-1].BlockIndex <= cn.Notifications[i].BlockIndex)
+				}
+			},
+		},
+		{
+			name:   "height range",
+			params: fmt.Sprintf(`["%s", "transfer", 6, 6]`, testContractHash),
+			result: func(e *executor) interface{} { return &result.ContractNotifications{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				cn := res.(*result.ContractNotifications)
+				require.Equal(t, 1, len(cn.Notifications))
+				require.EqualValues(t, 6, cn.Notifications[0].BlockIndex)
+				checkContractNotifications(t, e, cn.Notifications)
+			},
+		},
+		{
+			name:   "page out of range",
+			params: fmt.Sprintf(`["%s", "transfer", 0, 10, 1]`, testContractHash),
+			result: func(e *executor) interface{} {
+				return &result.ContractNotifications{Notifications: []result.ContractNotification{}}
+			},
+		},
+		{
+			name:   "unknown event",
+			params: fmt.Sprintf(`["%s", "nonexistent"]`, testContractHash),
+			result: func(e *executor) interface{} {
+				return &result.ContractNotifications{Notifications: []result.ContractNotification{}}
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahex", "transfer"]`,
+			fail:   true,
+		},
+		{
+			name:   "empty name",
+			params: fmt.Sprintf(`["%s", ""]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "invalid range",
+			params: fmt.Sprintf(`["%s", "transfer", 5, 4]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "negative page",
+			params: fmt.Sprintf(`["%s", "transfer", 0, 4, -1]`, testContractHash),
+			fail:   true,
+		},
+	},
+	"findstorage": {
+		{
+			name:   "positive",
+			params: fmt.Sprintf(`["%s", "746573746b6579"]`, testContractHash),
+			result: func(e *executor) interface{} {
+				return &result.FindStorage{
+					Results: []result.KeyValue{{
+						Key:   hex.EncodeToString([]byte("testkey")),
+						Value: hex.EncodeToString([]byte("testvalue")),
+					}},
+				}
+			},
+		},
+		{
+			name:   "paging",
+			params: fmt.Sprintf(`["%s", "", "%s", 1]`, testContractHash, hex.EncodeToString([]byte("testkey"))),
+			result: func(e *executor) interface{} { return &result.FindStorage{} },
+			check: func(t *testing.T, e *executor, res interface{}) {
+				h, err := util.Uint160DecodeStringLE(testContractHash)
+				require.NoError(t, err)
+				items, err := e.chain.GetStorageItems(h)
+				require.NoError(t, err)
+				keys := make([]string, 0, len(items))
+				for k := range items {
+					if k >= "testkey" {
+						keys = append(keys, k)
+					}
+				}
+				sort.Strings(keys)
+				require.True(t, len(keys) > 1)
+
+				fs := res.(*result.FindStorage)
+				require.Equal(t, []result.KeyValue{{
+					Key:   hex.EncodeToString([]byte(keys[0])),
+					Value: hex.EncodeToString(items[keys[0]].Value),
+				}}, fs.Results)
+				require.Equal(t, hex.EncodeToString([]byte(keys[1])), fs.Next)
+				require.True(t, fs.Truncated)
+			},
+		},
+		{
+			name:   "missing prefix",
+			params: fmt.Sprintf(`["%s", "ffffff"]`, testContractHash),
+			result: func(e *executor) interface{} {
+				return &result.FindStorage{Results: []result.KeyValue{}}
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahex", ""]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid prefix",
+			params: fmt.Sprintf(`["%s", "notahex"]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "invalid start",
+			params: fmt.Sprintf(`["%s", "", "notahex"]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "start without prefix",
+			params: fmt.Sprintf(`["%s", "74", "ff"]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "zero count",
+			params: fmt.Sprintf(`["%s", "", "", 0]`, testContractHash),
+			fail:   true,
+		},
+	},
 	"getapplicationlog": {
 		{
 			name:   "positive",
@@ -174,93 +409,85 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `["` + testchain.PrivateKeyByID(0).Address() + `"]`,
 			result: func(e *executor) interface{} { return &result.NEP5Transfers{} },
 			check: func(t *testing.T, e *executor, acc interface{}) {
-				res, ok := acc.(*result.NEP5Transfers)
+				checkNep5Transfers(t, e, acc, []int{0, 1}, []int{0, 1, 2, 3})
+			},
+		},
+		{
+			name:   "start after end",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 2, 1]`,
+			fail:   true,
+		},
+		{
+			name:   "zero limit",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 2, 0]`,
+			fail:   true,
+		},
+		{
+			name:   "big limit",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 2, 1001]`,
+			fail:   true,
+		},
+		{
+			name:   "negative page",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 2, 1, -1]`,
+			fail:   true,
+		},
+		{
+			name:   "page overflow",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, 2, 1000, 10000000000000000]`,
+			fail:   true,
+		},
+	},
+	"getproof": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid root",
+			params: `["0xabcdef"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid contract",
+			params: `["0000000000000000000000000000000000000000000000000000000000000000", "0xabcdef"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid key",
+			params: `["0000000000000000000000000000000000000000000000000000000000000000", "` + testContractHash + `", "notahex"]`,
+			fail:   true,
+		},
+	},
+	"getstateroot": {
+		{
+			name:   "positive",
+			params: `[0]`,
+			result: func(e *executor) interface{} { return new(state.MPTRoot) },
+			check: func(t *testing.T, e *executor, r interface{}) {
+				res, ok := r.(*state.MPTRoot)
 				require.True(t, ok)
-				rublesHash, err := util.Uint160DecodeStringLE(testContractHash)
-				require.NoError(t, err)
-				blockSendRubles, err := e.chain.GetBlock(e.chain.GetHeaderHash(6))
+				expected, err := e.chain.GetStateRoot(0)
 				require.NoError(t, err)
-				require.Equal(t, 1, len(blockSendRubles.Transactions))
-				txSendRublesHash := blockSendRubles.Transactions[0].Hash()
-				blockReceiveRubles, err := e.chain.GetBlock(e.chain.GetHeaderHash(5))
-				require.NoError(t, err)
-				require.Equal(t, 2, len(blockReceiveRubles.Transactions))
-				txReceiveRublesHash := blockReceiveRubles.Transactions[1].Hash()
-				blockReceiveGAS, err := e.chain.GetBlock(e.chain.GetHeaderHash(1))
-				require.NoError(t, err)
-				require.Equal(t, 2, len(blockReceiveGAS.Transactions))
-				txReceiveNEOHash := blockReceiveGAS.Transactions[0].Hash()
-				txReceiveGASHash := blockReceiveGAS.Transactions[1].Hash()
-				blockSendNEO, err := e.chain.GetBlock(e.chain.GetHeaderHash(4))
-				require.NoError(t, err)
-				require.Equal(t, 1, len(blockSendNEO.Transactions))
-				txSendNEOHash := blockSendNEO.Transactions[0].Hash()
-				expected := result.NEP5Transfers{
-					Sent: []result.NEP5Transfer{
-						{
-							Timestamp:   blockSendRubles.Timestamp,
-							Asset:       rublesHash,
-							Address:     testchain.PrivateKeyByID(1).Address(),
-							Amount:      "1.23",
-							Index:       6,
-							NotifyIndex: 0,
-							TxHash:      txSendRublesHash,
-						},
-						{
-							Timestamp:   blockSendNEO.Timestamp,
-							Asset:       e.chain.GoverningTokenHash(),
-							Address:     testchain.PrivateKeyByID(1).Address(),
-							Amount:      "1000",
-							Index:       4,
-							NotifyIndex: 0,
-							TxHash:      txSendNEOHash,
-						},
-					},
-					Received: []result.NEP5Transfer{
-						{
-							Timestamp:   blockReceiveRubles.Timestamp,
-							Asset:       rublesHash,
-							Address:     address.Uint160ToString(rublesHash),
-							Amount:      "10",
-							Index:       5,
-							NotifyIndex: 0,
-							TxHash:      txReceiveRublesHash,
-						},
-						{
-							Timestamp:   blockSendNEO.Timestamp,
-							Asset:       e.chain.UtilityTokenHash(),
-							Address:     "", // Minted GAS.
-							Amount:      "23.99976000",
-							Index:       4,
-							NotifyIndex: 0,
-							TxHash:      txSendNEOHash,
-						},
-						{
-							Timestamp:   blockReceiveGAS.Timestamp,
-							Asset:       e.chain.UtilityTokenHash(),
-							Address:     testchain.MultisigAddress(),
-							Amount:      "1000",
-							Index:       1,
-							NotifyIndex: 0,
-							TxHash:      txReceiveGASHash,
-						},
-						{
-							Timestamp:   blockReceiveGAS.Timestamp,
-							Asset:       e.chain.GoverningTokenHash(),
-							Address:     testchain.MultisigAddress(),
-							Amount:      "99999000",
-							Index:       1,
-							NotifyIndex: 0,
-							TxHash:      txReceiveNEOHash,
-						},
-					},
-					Address: testchain.PrivateKeyByID(0).Address(),
-				}
-				require.Equal(t, expected.Address, res.Address)
-				require.ElementsMatch(t, expected.Sent, res.Sent)
-				require.ElementsMatch(t, expected.Received, res.Received)
+				require.Equal(t, expected, res)
 			},
 		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid height",
+			params: `["notanumber"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown height",
+			params: `[100500]`,
+			fail:   true,
+		},
 	},
 	"getstorage": {
 		{
@@ -279,6 +506,24 @@ var rpcTestCases = map[string][]rpcTestCase{
 				return &v
 			},
 		},
+		{
+			name:   "before deployment",
+			params: fmt.Sprintf(`["%s", "746573746b6579", 0]`, testContractHash),
+			result: func(e *executor) interface{} {
+				v := ""
+				return &v
+			},
+		},
+		{
+			name:   "invalid height",
+			params: fmt.Sprintf(`["%s", "746573746b6579", 100500]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "unknown block hash",
+			params: fmt.Sprintf(`["%s", "746573746b6579", "%s"]`, testContractHash, util.Uint256{}.StringLE()),
+			fail:   true,
+		},
 		{
 			name:   "no params",
 			params: `[]`,
@@ -562,6 +807,73 @@ var rpcTestCases = map[string][]rpcTestCase{
 			},
 		},
 	},
+	"getcandidates": {
+		{
+			params: "[]",
+			result: func(*executor) interface{} {
+				return &[]result.Validator{}
+			},
+			check: func(t *testing.T, e *executor, candidates interface{}) {
+				var expected []result.Validator
+				sBValidators, err := e.chain.GetStandByValidators()
+				require.NoError(t, err)
+				for _, sbValidator := range sBValidators {
+					expected = append(expected, result.Validator{
+						PublicKey: *sbValidator,
+						Votes:     0,
+						Active:    true,
+					})
+				}
+
+				actual, ok := candidates.(*[]result.Validator)
+				require.True(t, ok)
+
+				assert.ElementsMatch(t, expected, *actual)
+			},
+		},
+	},
+	"getnextblockvalidators": {
+		{
+			params: "[]",
+			result: func(*executor) interface{} {
+				return &[]result.Validator{}
+			},
+			check: func(t *testing.T, e *executor, validators interface{}) {
+				expected, err := e.chain.GetValidators()
+				require.NoError(t, err)
+
+				actual, ok := validators.(*[]result.Validator)
+				require.True(t, ok)
+				require.Equal(t, len(expected), len(*actual))
+				for i := range expected {
+					require.Equal(t, *expected[i], (*actual)[i].PublicKey)
+					require.Equal(t, int64(0), (*actual)[i].Votes)
+					require.True(t, (*actual)[i].Active)
+				}
+			},
+		},
+	},
+	"getcommittee": {
+		{
+			params: "[]",
+			result: func(*executor) interface{} {
+				return &[]string{}
+			},
+			check: func(t *testing.T, e *executor, committee interface{}) {
+				var expected []string
+				sBValidators, err := e.chain.GetStandByValidators()
+				require.NoError(t, err)
+				for _, sbValidator := range sBValidators {
+					expected = append(expected, hex.EncodeToString(sbValidator.Bytes()))
+				}
+
+				actual, ok := committee.(*[]string)
+				require.True(t, ok)
+
+				assert.ElementsMatch(t, expected, *actual)
+			},
+		},
+	},
 	"getversion": {
 		{
 			params: "[]",
@@ -645,6 +957,34 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [{"type": "Integer", "value": "qwerty"}]]`,
 			fail:   true,
 		},
+		{
+			name:   "positive, at height",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], 0]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				// Block index is not a part of the script.
+				assert.Equal(t, "10c00c04746573740c146f459162ceeb248b071ec157d9e4f6fd26fdbe5041627d5b52", res.Script)
+				assert.NotEqual(t, "", res.State)
+			},
+		},
+		{
+			name:   "invalid height",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], 100500]`,
+			fail:   true,
+		},
+		{
+			name:   "positive, with signers",
+			params: `["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], [{"account": "0x316e851039019d39dfc2c37d6c3fee19fd580987", "scopes": 0}], 0]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "10c00c04746573740c146f459162ceeb248b071ec157d9e4f6fd26fdbe5041627d5b52", res.Script)
+				assert.NotEqual(t, "", res.State)
+			},
+		},
 	},
 	"invokescript": {
 		{
@@ -674,6 +1014,74 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `["qwerty"]`,
 			fail:   true,
 		},
+		{
+			name:   "positive, at height",
+			params: `["51c56b0d48656c6c6f2c20776f726c6421680f4e656f2e52756e74696d652e4c6f67616c7566", 0]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "51c56b0d48656c6c6f2c20776f726c6421680f4e656f2e52756e74696d652e4c6f67616c7566", res.Script)
+				assert.NotEqual(t, "", res.State)
+			},
+		},
+		{
+			name:   "invalid height",
+			params: `["51c56b0d48656c6c6f2c20776f726c6421680f4e656f2e52756e74696d652e4c6f67616c7566", 100500]`,
+			fail:   true,
+		},
+		{
+			name:   "no signers",
+			params: `["0c14870958fd19ee3f6c7dc3c2df399d013910856e3141f827ec8c"]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				// There is no script container to check witnesses against.
+				assert.Equal(t, "FAULT", res.State)
+				assert.NotEmpty(t, res.FaultException)
+			},
+		},
+		{
+			name:   "positive, with signers",
+			params: `["0c14870958fd19ee3f6c7dc3c2df399d013910856e3141f827ec8c", [{"account": "0x316e851039019d39dfc2c37d6c3fee19fd580987", "scopes": 0}]]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State)
+				require.Equal(t, 1, len(res.Stack))
+				assert.Equal(t, true, res.Stack[0].Value)
+			},
+		},
+		{
+			name:   "positive, with signers at height",
+			params: `["0c14870958fd19ee3f6c7dc3c2df399d013910856e3141f827ec8c", [{"account": "0x316e851039019d39dfc2c37d6c3fee19fd580987", "scopes": 0}], 0]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State)
+				require.Equal(t, 1, len(res.Stack))
+				assert.Equal(t, true, res.Stack[0].Value)
+			},
+		},
+		{
+			name:   "invalid signers",
+			params: `["0c14870958fd19ee3f6c7dc3c2df399d013910856e3141f827ec8c", [42]]`,
+			fail:   true,
+		},
+		{
+			name:   "fault with exception",
+			params: `["1038"]`,
+			result: func(e *executor) interface{} { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv interface{}) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "FAULT", res.State)
+				assert.NotEmpty(t, res.FaultException)
+			},
+		},
 	},
 	"sendrawtransaction": {
 		{
@@ -722,6 +1130,60 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"tracetransaction": {
+		{
+			name:   "positive",
+			params: `["5878052c7e9843786d64a9aeab16e74fabffd5abad9a0404aaf4f4bf2b6213e9"]`,
+			result: func(e *executor) interface{} { return &result.TransactionTrace{} },
+			check: func(t *testing.T, e *executor, tr interface{}) {
+				res, ok := tr.(*result.TransactionTrace)
+				require.True(t, ok)
+				expectedTxHash, err := util.Uint256DecodeStringLE("5878052c7e9843786d64a9aeab16e74fabffd5abad9a0404aaf4f4bf2b6213e9")
+				require.NoError(t, err)
+				aer, err := e.chain.GetAppExecResult(expectedTxHash)
+				require.NoError(t, err)
+				assert.Equal(t, expectedTxHash, res.TxHash)
+				assert.Equal(t, aer.VMState, res.VMState)
+				assert.Equal(t, aer.GasConsumed, res.GasConsumed)
+				assert.False(t, res.Truncated)
+				require.True(t, len(res.Steps) > 0)
+				assert.Equal(t, 0, res.Steps[0].IP)
+				assert.Equal(t, "RET", res.Steps[len(res.Steps)-1].Opcode)
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahash"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown transaction",
+			params: `["d24cc1d52b5c0216cbf3835bb5bac8ccf32639fa1ab6627ec4e2b9f33f7ec02f"]`,
+			fail:   true,
+		},
+	},
+	"verifyproof": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid root",
+			params: `["0xabcdef"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid proof",
+			params: `["0000000000000000000000000000000000000000000000000000000000000000", "notahex"]`,
+			fail:   true,
+		},
+	},
 	"validateaddress": {
 		{
 			name:   "positive",
@@ -793,6 +1255,37 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		})
 	}
 
+	t.Run("getnep5transfers with parameters", func(t *testing.T) {
+		blockTime := func(index int) uint64 {
+			b, err := e.chain.GetBlock(e.chain.GetHeaderHash(index))
+			require.NoError(t, err)
+			return b.Timestamp
+		}
+		// Transfers happen in blocks 1, 4, 5 and 6.
+		addr := testchain.PrivateKeyByID(0).Address()
+		testCases := []struct {
+			name      string
+			params    string
+			sent      []int
+			received  []int
+			truncated bool
+		}{
+			{"time range", fmt.Sprintf(`["%s", %d, %d]`, addr, blockTime(5), blockTime(6)), []int{0}, []int{0}, false},
+			{"limit", fmt.Sprintf(`["%s", 0, %d, 1]`, addr, blockTime(6)), []int{0}, []int{}, true},
+			{"page", fmt.Sprintf(`["%s", 0, %d, 2, 1]`, addr, blockTime(6)), []int{1}, []int{1}, true},
+			{"last page", fmt.Sprintf(`["%s", 0, %d, 4, 1]`, addr, blockTime(6)), []int{}, []int{2, 3}, false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getnep5transfers", "params": ` + tc.params + `}`
+				res := new(result.NEP5Transfers)
+				require.NoError(t, json.Unmarshal(checkErrGetResult(t, doRPCCall(rpc, httpSrv.URL, t), false), res))
+				checkNep5Transfers(t, e, res, tc.sent, tc.received)
+				require.Equal(t, tc.truncated, res.Truncated)
+			})
+		}
+	})
+
 	t.Run("submit", func(t *testing.T) {
 		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "submitblock", "params": ["%s"]}`
 		t.Run("invalid signature", func(t *testing.T) {
@@ -961,6 +1454,243 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 
 		assert.ElementsMatch(t, expected, actual)
 	})
+
+	t.Run("getproof", func(t *testing.T) {
+		r, err := chain.GetStateRoot(chain.BlockHeight())
+		require.NoError(t, err)
+
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "getproof", "params": ["%s", "%s", "%x"]}`,
+			r.Root.StringLE(), testContractHash, []byte("testkey"))
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		rawRes := checkErrGetResult(t, body, false)
+		res := new(result.GetProof)
+		require.NoError(t, json.Unmarshal(rawRes, res))
+		require.True(t, res.Success)
+
+		t.Run("verifyproof", func(t *testing.T) {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "verifyproof", "params": ["%s", "%s"]}`,
+				r.Root.StringLE(), res.Result.String())
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false)
+			vp := new(result.VerifyProof)
+			require.NoError(t, json.Unmarshal(rawRes, vp))
+			require.Equal(t, []byte("testvalue"), vp.Value)
+		})
+
+		t.Run("verifyproof, contract", func(t *testing.T) {
+			h, err := util.Uint160DecodeStringLE(testContractHash)
+			require.NoError(t, err)
+			key := append([]byte{byte(storage.STContract)}, h.BytesBE()...)
+			proof, err := chain.GetStateProof(r.Root, key)
+			require.NoError(t, err)
+			p := result.ProofWithKey{Key: key, Proof: proof}
+
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "verifyproof", "params": ["%s", "%s"]}`,
+				r.Root.StringLE(), p.String())
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false)
+			vp := new(result.VerifyProof)
+			require.NoError(t, json.Unmarshal(rawRes, vp))
+			expected, err := testserdes.EncodeBinary(chain.GetContractState(h))
+			require.NoError(t, err)
+			require.Equal(t, expected, vp.Value)
+		})
+
+		t.Run("verifyproof, wrong root", func(t *testing.T) {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "verifyproof", "params": ["%s", "%s"]}`,
+				util.Uint256{1, 2, 3}.StringLE(), res.Result.String())
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false)
+			vp := new(result.VerifyProof)
+			require.NoError(t, json.Unmarshal(rawRes, vp))
+			require.Nil(t, vp.Value)
+		})
+	})
+
+	t.Run("batch", func(t *testing.T) {
+		t.Run("positive", func(t *testing.T) {
+			rpc := `[{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []},
+				{"jsonrpc": "2.0", "id": 2, "method": "getbestblockhash", "params": []},
+				{"jsonrpc": "1.0", "id": 3, "method": "getblockcount", "params": []},
+				{"jsonrpc": "2.0", "id": 4, "method": "unknownmethod", "params": []}]`
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			var res []response.Raw
+			require.NoErrorf(t, json.Unmarshal(body, &res), "could not parse response: %s", body)
+			require.Equal(t, 4, len(res))
+			for i := range res {
+				require.Equal(t, strconv.Itoa(i+1), string(res[i].ID))
+			}
+
+			var count uint32
+			require.Nil(t, res[0].Error)
+			require.NoError(t, json.Unmarshal(res[0].Result, &count))
+			require.Equal(t, chain.BlockHeight()+1, count)
+
+			var hash string
+			require.Nil(t, res[1].Error)
+			require.NoError(t, json.Unmarshal(res[1].Result, &hash))
+			require.Equal(t, "0x"+chain.CurrentBlockHash().StringLE(), hash)
+
+			require.NotNil(t, res[2].Error)
+			require.NotNil(t, res[3].Error)
+		})
+		t.Run("notifications", func(t *testing.T) {
+			rpc := `[{"jsonrpc": "2.0", "method": "getblockcount", "params": []},
+				{"jsonrpc": "2.0", "id": 2, "method": "getbestblockhash", "params": []},
+				{"jsonrpc": "2.0", "method": "unknownmethod", "params": []}]`
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			var res []response.Raw
+			require.NoErrorf(t, json.Unmarshal(body, &res), "could not parse response: %s", body)
+			require.Equal(t, 1, len(res))
+			require.Equal(t, "2", string(res[0].ID))
+			require.Nil(t, res[0].Error)
+		})
+		t.Run("notifications only", func(t *testing.T) {
+			rpc := `[{"jsonrpc": "2.0", "method": "getblockcount", "params": []},
+				{"jsonrpc": "2.0", "method": "getbestblockhash", "params": []}]`
+			cl := http.Client{Timeout: time.Second}
+			resp, err := cl.Post(httpSrv.URL, "application/json", strings.NewReader(rpc))
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			require.Equal(t, http.StatusNoContent, resp.StatusCode)
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, 0, len(body))
+		})
+		t.Run("empty", func(t *testing.T) {
+			body := doRPCCall(`[]`, httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		})
+		t.Run("too big", func(t *testing.T) {
+			reqs := make([]string, rpcSrv.config.MaxBatchSize+1)
+			for i := range reqs {
+				reqs[i] = fmt.Sprintf(`{"jsonrpc": "2.0", "id": %d, "method": "getblockcount", "params": []}`, i)
+			}
+			body := doRPCCall("["+strings.Join(reqs, ",")+"]", httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		})
+	})
+
+	t.Run("iterator sessions", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.String(w.BinWriter, "c")
+		emit.String(w.BinWriter, "b")
+		emit.String(w.BinWriter, "a")
+		emit.Int(w.BinWriter, 3)
+		emit.Opcode(w.BinWriter, opcode.PACK)
+		emit.Syscall(w.BinWriter, "Neo.Iterator.Create")
+		require.NoError(t, w.Err)
+
+		rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["%s"]}`, hex.EncodeToString(w.Bytes()))
+		body := doRPCCall(rpc, httpSrv.URL, t)
+		inv := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, body, false), inv))
+		require.Equal(t, "HALT", inv.State)
+		require.Equal(t, 1, len(inv.Stack))
+		require.NotEmpty(t, inv.Session)
+
+		traverse := func(t *testing.T, session string, count int, fail bool) []smartcontract.Parameter {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "traverseiterator", "params": ["%s", 0, %d]}`, session, count)
+			res := checkErrGetResult(t, doRPCCall(rpc, httpSrv.URL, t), fail)
+			if fail {
+				return nil
+			}
+			var items []smartcontract.Parameter
+			require.NoError(t, json.Unmarshal(res, &items))
+			return items
+		}
+		pair := func(k int64, v string) smartcontract.Parameter {
+			return smartcontract.Parameter{Type: smartcontract.ArrayType, Value: []smartcontract.Parameter{
+				{Type: smartcontract.IntegerType, Value: k},
+				{Type: smartcontract.ByteArrayType, Value: []byte(v)},
+			}}
+		}
+		require.Equal(t, []smartcontract.Parameter{pair(0, "a"), pair(1, "b")}, traverse(t, inv.Session, 2, false))
+		require.Equal(t, []smartcontract.Parameter{pair(2, "c")}, traverse(t, inv.Session, 2, false))
+		require.Equal(t, []smartcontract.Parameter{}, traverse(t, inv.Session, 2, false))
+		traverse(t, inv.Session, rpcSrv.config.MaxIteratorResultItems+1, true)
+		traverse(t, "unknown", 1, true)
+
+		terminate := `{"jsonrpc": "2.0", "id": 1, "method": "terminatesession", "params": ["` + inv.Session + `"]}`
+		var ok bool
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, doRPCCall(terminate, httpSrv.URL, t), false), &ok))
+		require.True(t, ok)
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, doRPCCall(terminate, httpSrv.URL, t), false), &ok))
+		require.False(t, ok)
+		traverse(t, inv.Session, 1, true)
+	})
+
+	t.Run("calculatenetworkfee", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "calculatenetworkfee", "params": ["%x"]}`
+		acc0, err := wallet.NewAccountFromWIF(testchain.PrivateKeyByID(0).WIF())
+		require.NoError(t, err)
+
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 10
+		tx.Sender = acc0.Contract.ScriptHash()
+		size := io.GetVarSize(tx)
+		expected, sizeDelta := core.CalculateNetworkFee(acc0.Contract.Script)
+		expected += util.Fixed8(int64(size+sizeDelta) * int64(chain.FeePerByte()))
+
+		tx.Scripts = []transaction.Witness{{VerificationScript: acc0.Contract.Script}}
+		body := doRPCCall(fmt.Sprintf(rpc, tx.Bytes()), httpSrv.URL, t)
+		rawRes := checkErrGetResult(t, body, false)
+		res := new(result.NetworkFee)
+		require.NoError(t, json.Unmarshal(rawRes, res))
+		require.Equal(t, expected, res.Value)
+
+		t.Run("no verification script", func(t *testing.T) {
+			tx.Scripts = nil
+			body := doRPCCall(fmt.Sprintf(rpc, tx.Bytes()), httpSrv.URL, t)
+			checkErrGetResult(t, body, true)
+		})
+	})
+
+	t.Run("sendrawtransaction errors", func(t *testing.T) {
+		rpc := `{"jsonrpc": "2.0", "id": 1, "method": "sendrawtransaction", "params": ["%x"]}`
+		acc0, err := wallet.NewAccountFromWIF(testchain.PrivateKeyByID(0).WIF())
+		require.NoError(t, err)
+
+		newTx := func(nonce uint32, sysFee util.Fixed8) *transaction.Transaction {
+			tx := transaction.New([]byte{byte(opcode.PUSH1)}, sysFee)
+			tx.Nonce = nonce
+			tx.ValidUntilBlock = chain.BlockHeight() + 10
+			tx.Sender = acc0.Contract.ScriptHash()
+			size := io.GetVarSize(tx)
+			netFee, sizeDelta := core.CalculateNetworkFee(acc0.Contract.Script)
+			tx.NetworkFee = netFee + util.Fixed8(int64(size+sizeDelta)*int64(chain.FeePerByte()))
+			require.NoError(t, acc0.SignTx(tx))
+			return tx
+		}
+		checkSubmitError := func(t *testing.T, tx *transaction.Transaction, expected *response.Error, reason string) {
+			body := doRPCCall(fmt.Sprintf(rpc, tx.Bytes()), httpSrv.URL, t)
+			var resp response.Raw
+			require.NoError(t, json.Unmarshal(body, &resp))
+			require.NotNil(t, resp.Error)
+			require.Equal(t, expected.Code, resp.Error.Code)
+			require.Equal(t, expected.Message, resp.Error.Message)
+			require.True(t, strings.HasPrefix(resp.Error.Data, reason+": "), resp.Error.Data)
+		}
+
+		t.Run("already exists", func(t *testing.T) {
+			tx := newTx(100500, 0)
+			body := doRPCCall(fmt.Sprintf(rpc, tx.Bytes()), httpSrv.URL, t)
+			checkErrGetResult(t, body, false)
+			checkSubmitError(t, tx, response.ErrAlreadyExists, response.ReasonAlreadyExists)
+		})
+		t.Run("validation failed", func(t *testing.T) {
+			tx := newTx(100501, 0)
+			tx.Scripts[0].InvocationScript[10] ^= 0xff
+			checkSubmitError(t, tx, response.ErrValidationFailed, response.ReasonValidationFailed)
+		})
+		t.Run("conflict", func(t *testing.T) {
+			balance := chain.GetUtilityTokenBalance(acc0.Contract.ScriptHash())
+			tx := newTx(100502, balance*6/10)
+			body := doRPCCall(fmt.Sprintf(rpc, tx.Bytes()), httpSrv.URL, t)
+			checkErrGetResult(t, body, false)
+			checkSubmitError(t, newTx(100503, balance*6/10), response.ErrConflict, response.ReasonConflict)
+		})
+	})
 }
 
 func (e *executor) getHeader(s string) *block.Header {
@@ -1008,6 +1738,319 @@ func newBlock(t *testing.T, bc blockchainer.Blockchainer, index uint32, primary
 	return b
 }
 
+func TestCandidateVotes(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := priv.PublicKey()
+	owner := testchain.MultisigScriptHash()
+	neoHash := chain.GoverningTokenHash()
+
+	invoke := func(t *testing.T, script []byte) {
+		tx := transaction.New(script, 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 1
+		tx.Sender = owner
+		tx.Cosigners = []transaction.Cosigner{{
+			Account: owner,
+			Scopes:  transaction.Global,
+		}}
+		netFee, sizeDelta := core.CalculateNetworkFee(testchain.MultisigVerificationScript())
+		tx.NetworkFee = netFee + util.Fixed8(int64(io.GetVarSize(tx)+sizeDelta)*int64(chain.FeePerByte()))
+		tx.Scripts = []transaction.Witness{{
+			InvocationScript:   testchain.Sign(tx.GetSignedPart()),
+			VerificationScript: testchain.MultisigVerificationScript(),
+		}}
+		require.NoError(t, chain.AddBlock(newBlock(t, chain, 1, 0, tx)))
+		aer, err := chain.GetAppExecResult(tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, "HALT", aer.VMState)
+	}
+	getCandidate := func(t *testing.T) result.Validator {
+		body := doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "getcandidates", "params": []}`, httpSrv.URL, t)
+		var candidates []result.Validator
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, body, false), &candidates))
+		for _, c := range candidates {
+			if c.PublicKey.Equal(pub) {
+				return c
+			}
+		}
+		require.FailNow(t, "candidate is not registered")
+		return result.Validator{}
+	}
+
+	w := io.NewBufBinWriter()
+	emit.AppCallWithOperationAndArgs(w.BinWriter, neoHash, "registerValidator", pub.Bytes())
+	emit.Opcode(w.BinWriter, opcode.ASSERT)
+	require.NoError(t, w.Err)
+	invoke(t, w.Bytes())
+
+	c := getCandidate(t)
+	require.Equal(t, int64(0), c.Votes)
+	require.False(t, c.Active)
+
+	// vote takes an array of keys, so arguments are packed by hand.
+	w.Reset()
+	emit.Bytes(w.BinWriter, pub.Bytes())
+	emit.Int(w.BinWriter, 1)
+	emit.Opcode(w.BinWriter, opcode.PACK)
+	emit.Bytes(w.BinWriter, owner.BytesBE())
+	emit.Int(w.BinWriter, 2)
+	emit.Opcode(w.BinWriter, opcode.PACK)
+	emit.String(w.BinWriter, "vote")
+	emit.AppCall(w.BinWriter, neoHash)
+	emit.Opcode(w.BinWriter, opcode.ASSERT)
+	require.NoError(t, w.Err)
+	invoke(t, w.Bytes())
+
+	balance, _ := chain.GetGoverningTokenBalance(owner)
+	c = getCandidate(t)
+	require.Equal(t, int64(balance), c.Votes)
+	require.True(t, c.Active)
+}
+
+func TestRPCAccessControl(t *testing.T) {
+	const getCount = `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+
+	doCall := func(t *testing.T, url string, body string, hdr http.Header) (int, *response.Raw) {
+		req, err := http.NewRequest("POST", url, strings.NewReader(body))
+		require.NoError(t, err)
+		for k, v := range hdr {
+			req.Header[k] = v
+		}
+		cl := http.Client{Timeout: time.Second}
+		resp, err := cl.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var raw response.Raw
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&raw))
+		return resp.StatusCode, &raw
+	}
+	checkError := func(t *testing.T, code int64, raw *response.Raw) {
+		require.NotNil(t, raw.Error)
+		require.Equal(t, code, raw.Error.Code)
+	}
+
+	t.Run("auth", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithConfig(t, func(c *rpc.Config) {
+			c.Auth.APIKeys = []string{"secret"}
+			c.Auth.BasicAuth = map[string]string{"user": "pass"}
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		code, raw := doCall(t, httpSrv.URL, getCount, nil)
+		require.Equal(t, http.StatusUnauthorized, code)
+		checkError(t, response.ErrUnauthorized.Code, raw)
+
+		code, raw = doCall(t, httpSrv.URL, getCount, http.Header{"X-Api-Key": {"wrong"}})
+		require.Equal(t, http.StatusUnauthorized, code)
+		checkError(t, response.ErrUnauthorized.Code, raw)
+
+		for name, hdr := range map[string]http.Header{
+			"api key": {"X-Api-Key": {"secret"}},
+			"bearer":  {"Authorization": {"Bearer secret"}},
+			"basic":   {"Authorization": {"Basic dXNlcjpwYXNz"}},
+		} {
+			code, raw = doCall(t, httpSrv.URL, getCount, hdr)
+			require.Equal(t, http.StatusOK, code, name)
+			require.Nil(t, raw.Error, name)
+		}
+
+		t.Run("websocket", func(t *testing.T) {
+			dialer := websocket.Dialer{HandshakeTimeout: time.Second}
+			url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+			_, resp, err := dialer.Dial(url, nil)
+			require.Error(t, err)
+			require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+			c, _, err := dialer.Dial(url, http.Header{"X-Api-Key": {"secret"}})
+			require.NoError(t, err)
+			c.Close()
+		})
+	})
+
+	t.Run("methods", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithConfig(t, func(c *rpc.Config) {
+			c.AllowedMethods = []string{"getblockcount", "getversion", "sendrawtransaction"}
+			c.DisallowedMethods = []string{"getversion"}
+			c.ReadOnly = true
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		code, raw := doCall(t, httpSrv.URL, getCount, nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Nil(t, raw.Error)
+
+		for _, method := range []string{"getversion", "getpeers", "sendrawtransaction", "submitblock"} {
+			body := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": []}`, method)
+			code, raw = doCall(t, httpSrv.URL, body, nil)
+			require.Equal(t, http.StatusForbidden, code, method)
+			checkError(t, -32002, raw)
+		}
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithConfig(t, func(c *rpc.Config) {
+			c.RateLimit.RequestsPerSecond = 0.001
+			c.RateLimit.Burst = 3
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		code, raw := doCall(t, httpSrv.URL, getCount, nil)
+		require.Equal(t, http.StatusOK, code)
+		require.Nil(t, raw.Error)
+
+		// Batch of 3 calls doesn't fit into the remaining 2 tokens.
+		code, raw = doCall(t, httpSrv.URL, "["+getCount+","+getCount+","+getCount+"]", nil)
+		require.Equal(t, http.StatusTooManyRequests, code)
+		checkError(t, response.ErrRateLimitExceeded.Code, raw)
+
+		body := doRPCCallOverWS(getCount, httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+		body = doRPCCallOverWS(getCount, httpSrv.URL, t)
+		checkErrGetResult(t, body, false)
+		body = doRPCCallOverWS(getCount, httpSrv.URL, t)
+		raw = new(response.Raw)
+		require.NoError(t, json.Unmarshal(body, raw))
+		checkError(t, response.ErrRateLimitExceeded.Code, raw)
+	})
+}
+
+func TestHistoricalStateDisabled(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithConfig(t, func(c *rpc.Config) {
+		c.EnableHistoricalState = false
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	script := "51c56b0d48656c6c6f2c20776f726c6421680f4e656f2e52756e74696d652e4c6f67616c7566"
+	for _, params := range []string{
+		`"invokescript", "params": ["` + script + `", 0]`,
+		`"invokefunction", "params": ["50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [], 0]`,
+		`"getstorage", "params": ["` + testContractHash + `", "746573746b6579", 0]`,
+	} {
+		body := doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": `+params+`}`, httpSrv.URL, t)
+		checkErrGetResult(t, body, true)
+	}
+
+	// Current state is still available.
+	body := doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "invokescript", "params": ["`+script+`"]}`, httpSrv.URL, t)
+	checkErrGetResult(t, body, false)
+}
+
+func checkNep5Transfers(t *testing.T, e *executor, acc interface{}, sent, received []int) {
+	res, ok := acc.(*result.NEP5Transfers)
+	require.True(t, ok)
+	rublesHash, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+	blockSendRubles, err := e.chain.GetBlock(e.chain.GetHeaderHash(6))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(blockSendRubles.Transactions))
+	txSendRublesHash := blockSendRubles.Transactions[0].Hash()
+	blockReceiveRubles, err := e.chain.GetBlock(e.chain.GetHeaderHash(5))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blockReceiveRubles.Transactions))
+	txReceiveRublesHash := blockReceiveRubles.Transactions[1].Hash()
+	blockReceiveGAS, err := e.chain.GetBlock(e.chain.GetHeaderHash(1))
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blockReceiveGAS.Transactions))
+	txReceiveNEOHash := blockReceiveGAS.Transactions[0].Hash()
+	txReceiveGASHash := blockReceiveGAS.Transactions[1].Hash()
+	blockSendNEO, err := e.chain.GetBlock(e.chain.GetHeaderHash(4))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(blockSendNEO.Transactions))
+	txSendNEOHash := blockSendNEO.Transactions[0].Hash()
+	expected := result.NEP5Transfers{
+		Sent: []result.NEP5Transfer{
+			{
+				Timestamp:   blockSendRubles.Timestamp,
+				Asset:       rublesHash,
+				Address:     testchain.PrivateKeyByID(1).Address(),
+				Amount:      "1.23",
+				Index:       6,
+				NotifyIndex: 0,
+				TxHash:      txSendRublesHash,
+			},
+			{
+				Timestamp:   blockSendNEO.Timestamp,
+				Asset:       e.chain.GoverningTokenHash(),
+				Address:     testchain.PrivateKeyByID(1).Address(),
+				Amount:      "1000",
+				Index:       4,
+				NotifyIndex: 0,
+				TxHash:      txSendNEOHash,
+			},
+		},
+		Received: []result.NEP5Transfer{
+			{
+				Timestamp:   blockReceiveRubles.Timestamp,
+				Asset:       rublesHash,
+				Address:     address.Uint160ToString(rublesHash),
+				Amount:      "10",
+				Index:       5,
+				NotifyIndex: 0,
+				TxHash:      txReceiveRublesHash,
+			},
+			{
+				Timestamp:   blockSendNEO.Timestamp,
+				Asset:       e.chain.UtilityTokenHash(),
+				Address:     "", // Minted GAS.
+				Amount:      "23.99976000",
+				Index:       4,
+				NotifyIndex: 0,
+				TxHash:      txSendNEOHash,
+			},
+			{
+				Timestamp:   blockReceiveGAS.Timestamp,
+				Asset:       e.chain.UtilityTokenHash(),
+				Address:     testchain.MultisigAddress(),
+				Amount:      "1000",
+				Index:       1,
+				NotifyIndex: 0,
+				TxHash:      txReceiveGASHash,
+			},
+			{
+				Timestamp:   blockReceiveGAS.Timestamp,
+				Asset:       e.chain.GoverningTokenHash(),
+				Address:     testchain.MultisigAddress(),
+				Amount:      "99999000",
+				Index:       1,
+				NotifyIndex: 0,
+				TxHash:      txReceiveNEOHash,
+			},
+		},
+		Address: testchain.PrivateKeyByID(0).Address(),
+	}
+	require.Equal(t, expected.Address, res.Address)
+
+	arr := make([]result.NEP5Transfer, 0, len(expected.Sent))
+	for i := range expected.Sent {
+		for _, j := range sent {
+			if i == j {
+				arr = append(arr, expected.Sent[i])
+				break
+			}
+		}
+	}
+	require.Equal(t, arr, res.Sent)
+
+	arr = make([]result.NEP5Transfer, 0, len(expected.Received))
+	for i := range expected.Received {
+		for _, j := range received {
+			if i == j {
+				arr = append(arr, expected.Received[i])
+				break
+			}
+		}
+	}
+	require.Equal(t, arr, res.Received)
+}
+
 func (tc rpcTestCase) getResultPair(e *executor) (expected interface{}, res interface{}) {
 	expected = tc.result(e)
 	resVal := reflect.New(reflect.TypeOf(expected).Elem())
@@ -1033,6 +2076,7 @@ func doRPCCallOverWS(rpcCall string, url string, t *testing.T) []byte {
 	url = "ws" + strings.TrimPrefix(url, "http")
 	c, _, err := dialer.Dial(url+"/ws", nil)
 	require.NoError(t, err)
+	defer c.Close()
 	c.SetWriteDeadline(time.Now().Add(time.Second))
 	require.NoError(t, c.WriteMessage(1, []byte(rpcCall)))
 	c.SetReadDeadline(time.Now().Add(time.Second))
@@ -1049,3 +2093,48 @@ func doRPCCallOverHTTP(rpcCall string, url string, t *testing.T) []byte {
 	assert.NoErrorf(t, err, "could not read response from the request: %s", rpcCall)
 	return bytes.TrimSpace(body)
 }
+
+func checkContractNotifications(t *testing.T, e *executor, ns []result.ContractNotification) {
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+	for _, n := range ns {
+		require.Equal(t, h, n.Contract)
+		arr, ok := n.Item.Value.([]smartcontract.Parameter)
+		require.True(t, ok)
+		require.Equal(t, []byte("transfer"), arr[0].Value)
+
+		aer, err := e.chain.GetAppExecResult(n.TxHash)
+		require.NoError(t, err)
+		var found bool
+		for _, ev := range aer.Events {
+			found = found || ev.ScriptHash == h
+		}
+		require.True(t, found)
+		b, err := e.chain.GetBlock(e.chain.GetHeaderHash(int(n.BlockIndex)))
+		require.NoError(t, err)
+		var inBlock bool
+		for _, tx := range b.Transactions {
+			inBlock = inBlock || tx.Hash() == n.TxHash
+		}
+		require.True(t, inBlock)
+	}
+}
+
+func checkAccountTransactions(t *testing.T, e *executor, acc util.Uint160, txs []result.AccountTransaction) {
+	for i, at := range txs {
+		if i > 0 {
+			require.True(t, txs[i-1].BlockIndex >= at.BlockIndex)
+		}
+		tx, height, err := e.chain.GetTransaction(at.TxHash)
+		require.NoError(t, err)
+		require.Equal(t, height, at.BlockIndex)
+		found := tx.Sender == acc
+		for _, c := range tx.Cosigners {
+			found = found || c.Account == acc
+		}
+		require.True(t, found)
+		h, err := e.chain.GetHeader(e.chain.GetHeaderHash(int(at.BlockIndex)))
+		require.NoError(t, err)
+		require.Equal(t, h.Timestamp, at.Timestamp)
+	}
+}
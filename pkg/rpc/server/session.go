@@ -0,0 +1,104 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// session keeps iterators returned by some test invocation alive, they're
+// identified by their positions in the resulting stack.
+type session struct {
+	iterators map[int]stackitem.Item
+	timer     *time.Timer
+}
+
+// errSessionPoolFull is returned when there are too many active sessions.
+var errSessionPoolFull = errors.New("max session capacity reached")
+
+// newSession registers a new session for the given iterators and returns its
+// ID. The session is dropped after SessionExpirationTime since the last
+// access.
+func (s *Server) newSession(iterators map[int]stackitem.Item) (string, error) {
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+
+	if len(s.sessions) >= s.config.SessionPoolSize {
+		return "", errSessionPoolFull
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(b)
+	s.sessions[id] = &session{
+		iterators: iterators,
+		timer:     s.expireSession(id),
+	}
+	return id, nil
+}
+
+// expireSession returns a timer dropping the given session after
+// SessionExpirationTime. It must be called with sessionsLock held.
+func (s *Server) expireSession(id string) *time.Timer {
+	return time.AfterFunc(s.sessionExpiration(), func() {
+		s.sessionsLock.Lock()
+		delete(s.sessions, id)
+		s.sessionsLock.Unlock()
+	})
+}
+
+// traverseSession returns at most n next items of the given session iterator.
+// The second value is false if there is no such session or iterator.
+func (s *Server) traverseSession(id string, iterator int, n int) ([]stackitem.Item, bool) {
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	if !sess.timer.Stop() {
+		// The session has already expired, it's to be dropped as soon
+		// as the lock is released.
+		return nil, false
+	}
+	sess.timer = s.expireSession(id)
+	iter, ok := sess.iterators[iterator]
+	if !ok {
+		return nil, false
+	}
+	return vm.IterateNext(iter, n), true
+}
+
+// terminateSession drops the given session returning true if it existed.
+func (s *Server) terminateSession(id string) bool {
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+
+	sess, ok := s.sessions[id]
+	if ok {
+		sess.timer.Stop()
+		delete(s.sessions, id)
+	}
+	return ok
+}
+
+// dropSessions terminates all sessions.
+func (s *Server) dropSessions() {
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+
+	for id, sess := range s.sessions {
+		sess.timer.Stop()
+		delete(s.sessions, id)
+	}
+}
+
+func (s *Server) sessionExpiration() time.Duration {
+	return time.Duration(s.config.SessionExpirationTime) * time.Second
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionExpiration(t *testing.T) {
+	s := &Server{sessions: make(map[string]*session)}
+	s.config.SessionPoolSize = 2
+	s.config.SessionExpirationTime = 60
+
+	id, err := s.newSession(map[int]stackitem.Item{0: stackitem.Null{}})
+	require.NoError(t, err)
+	_, ok := s.traverseSession(id, 0, 1)
+	require.True(t, ok)
+	_, ok = s.traverseSession(id, 1, 1)
+	require.False(t, ok)
+
+	t.Run("expired while waiting for the lock", func(t *testing.T) {
+		fired := make(chan struct{})
+		s.sessionsLock.Lock()
+		s.sessions["expired"] = &session{
+			iterators: map[int]stackitem.Item{0: stackitem.Null{}},
+			timer:     time.AfterFunc(0, func() { close(fired) }),
+		}
+		s.sessionsLock.Unlock()
+		<-fired
+
+		_, ok := s.traverseSession("expired", 0, 1)
+		require.False(t, ok)
+	})
+
+	require.True(t, s.terminateSession(id))
+	_, ok = s.traverseSession(id, 0, 1)
+	require.False(t, ok)
+}
@@ -1,12 +1,15 @@
 package server
 
 import (
+	"encoding/json"
+
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"go.uber.org/atomic"
 )
 
@@ -25,6 +28,26 @@ type (
 	feed struct {
 		event  response.EventID
 		filter interface{}
+		// name is an optional notification name filter.
+		name *string
+		// replay is not nil while events from stored blocks are being
+		// sent to the subscriber.
+		replay *replay
+	}
+	// replay holds live events matching the feed that are received while
+	// stored blocks are being replayed, they're sent after the replay.
+	replay struct {
+		stop    chan struct{}
+		pending []pendingEvent
+		// missed is set when there are too many pending events.
+		missed bool
+		// execSub is set if an additional execution subscription was
+		// made to track notification blocks.
+		execSub bool
+	}
+	pendingEvent struct {
+		index uint32
+		msg   *websocket.PreparedMessage
 	}
 )
 
@@ -32,6 +55,9 @@ const (
 	// Maximum number of subscriptions per one client.
 	maxFeeds = 16
 
+	// Maximum number of concurrent event replays per one client.
+	maxClientReplays = 4
+
 	// This sets notification messages buffer depth, it may seem to be quite
 	// big, but there is a big gap in speed between internal event processing
 	// and networking communication that is combined with spiky nature of our
@@ -47,6 +73,13 @@ func (f *feed) Matches(r *response.Notification) bool {
 	if r.Event != f.event {
 		return false
 	}
+	if f.name != nil {
+		notification := r.Payload[0].(result.NotificationEvent)
+		name, ok := notificationName(notification.Item)
+		if !ok || name != *f.name {
+			return false
+		}
+	}
 	if f.filter == nil {
 		return true
 	}
@@ -55,7 +88,7 @@ func (f *feed) Matches(r *response.Notification) bool {
 		filt := f.filter.(request.BlockFilter)
 		b := r.Payload[0].(*block.Block)
 		return int(b.ConsensusData.PrimaryIndex) == filt.Primary
-	case response.TransactionEventID:
+	case response.TransactionEventID, response.MempoolAddedEventID, response.MempoolRemovedEventID:
 		filt := f.filter.(request.TxFilter)
 		tx := r.Payload[0].(*transaction.Transaction)
 		senderOK := filt.Sender == nil || tx.Sender.Equals(*filt.Sender)
@@ -81,3 +114,46 @@ func (f *feed) Matches(r *response.Notification) bool {
 	}
 	return false
 }
+
+// add stores live event to be sent after the replay.
+func (r *replay) add(index uint32, msg *websocket.PreparedMessage) {
+	if r.missed {
+		return
+	}
+	if len(r.pending) >= notificationBufSize {
+		r.missed = true
+		r.pending = nil
+		return
+	}
+	r.pending = append(r.pending, pendingEvent{index: index, msg: msg})
+}
+
+// notificationName returns the name of the notification, that is the first
+// element of notification's array.
+func notificationName(item smartcontract.Parameter) (string, bool) {
+	if item.Type != smartcontract.ArrayType {
+		return "", false
+	}
+	arr, ok := item.Value.([]smartcontract.Parameter)
+	if !ok || len(arr) == 0 {
+		return "", false
+	}
+	switch arr[0].Type {
+	case smartcontract.ByteArrayType:
+		name, ok := arr[0].Value.([]byte)
+		return string(name), ok
+	case smartcontract.StringType:
+		name, ok := arr[0].Value.(string)
+		return name, ok
+	}
+	return "", false
+}
+
+// newNotificationMessage marshals the event into a websocket message.
+func newNotificationMessage(resp *response.Notification) (*websocket.PreparedMessage, error) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	return websocket.NewPreparedMessage(websocket.TextMessage, b)
+}
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -9,9 +10,11 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 )
@@ -83,7 +86,7 @@ func callUnsubscribe(t *testing.T, ws *websocket.Conn, msgs <-chan []byte, id st
 
 func TestSubscriptions(t *testing.T) {
 	var subIDs = make([]string, 0)
-	var subFeeds = []string{"block_added", "transaction_added", "notification_from_execution", "transaction_executed"}
+	var subFeeds = []string{"block_added", "header_added", "transaction_added", "notification_from_execution", "transaction_executed"}
 
 	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
 
@@ -97,6 +100,8 @@ func TestSubscriptions(t *testing.T) {
 
 	for _, b := range getTestBlocks(t) {
 		require.NoError(t, chain.AddBlock(b))
+		resp := getNotification(t, respMsgs)
+		require.Equal(t, response.HeaderEventID, resp.Event)
 		for range b.Transactions {
 			resp := getNotification(t, respMsgs)
 			require.Equal(t, response.ExecutionEventID, resp.Event)
@@ -109,7 +114,7 @@ func TestSubscriptions(t *testing.T) {
 				break
 			}
 		}
-		resp := getNotification(t, respMsgs)
+		resp = getNotification(t, respMsgs)
 		require.Equal(t, response.BlockEventID, resp.Event)
 	}
 
@@ -170,6 +175,18 @@ func TestFilteredSubscriptions(t *testing.T) {
 				require.Equal(t, "0x"+testContractHash, c)
 			},
 		},
+		"notification matching name": {
+			params: `["notification_from_execution", {"contract":"` + testContractHash + `"}, {"name":"transfer"}]`,
+			check: func(t *testing.T, resp *response.Notification) {
+				rmap := resp.Payload[0].(map[string]interface{})
+				require.Equal(t, response.NotificationEventID, resp.Event)
+				c := rmap["contract"].(string)
+				require.Equal(t, "0x"+testContractHash, c)
+				st := rmap["state"].(map[string]interface{})
+				name := st["value"].([]interface{})[0].(map[string]interface{})
+				require.Equal(t, base64.StdEncoding.EncodeToString([]byte("transfer")), name["value"])
+			},
+		},
 		"execution matching": {
 			params: `["transaction_executed", {"state":"HALT"}]`,
 			check: func(t *testing.T, resp *response.Notification) {
@@ -193,6 +210,12 @@ func TestFilteredSubscriptions(t *testing.T) {
 				t.Fatal("unexpected match for contract 00112233445566778899aabbccddeeff00112233")
 			},
 		},
+		"notification non-matching name": {
+			params: `["notification_from_execution", {"name":"burn"}]`,
+			check: func(t *testing.T, _ *response.Notification) {
+				t.Fatal("unexpected match for notification name")
+			},
+		},
 		"execution non-matching": {
 			params: `["transaction_executed", {"state":"FAULT"}]`,
 			check: func(t *testing.T, _ *response.Notification) {
@@ -281,6 +304,122 @@ func TestFilteredBlockSubscriptions(t *testing.T) {
 	c.Close()
 }
 
+func TestMempoolSubscriptions(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	sender := testchain.PrivateKeyByID(0).GetScriptHash()
+	addedID := callSubscribe(t, c, respMsgs, `["mempool_added", {"sender":"`+sender.StringLE()+`"}]`)
+	removedID := callSubscribe(t, c, respMsgs, `["mempool_removed"]`)
+
+	mp := chain.GetMemPool()
+	other := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	require.NoError(t, mp.Add(other, &FeerStub{}))
+	tx := transaction.New([]byte{byte(opcode.PUSH2)}, 0)
+	tx.Sender = sender
+	require.NoError(t, mp.Add(tx, &FeerStub{}))
+
+	resp := getNotification(t, respMsgs)
+	require.Equal(t, response.MempoolAddedEventID, resp.Event)
+	rmap := resp.Payload[0].(map[string]interface{})
+	require.Equal(t, "0x"+tx.Hash().StringLE(), rmap["txid"])
+
+	mp.Remove(other.Hash())
+	resp = getNotification(t, respMsgs)
+	require.Equal(t, response.MempoolRemovedEventID, resp.Event)
+	rmap = resp.Payload[0].(map[string]interface{})
+	require.Equal(t, "0x"+other.Hash().StringLE(), rmap["txid"])
+
+	callUnsubscribe(t, c, respMsgs, addedID)
+	callUnsubscribe(t, c, respMsgs, removedID)
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
+
+func TestSubscriptionsFromBlock(t *testing.T) {
+	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
+
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	height := chain.BlockHeight()
+
+	t.Run("blocks", func(t *testing.T) {
+		const from = 3
+		subID := callSubscribe(t, c, respMsgs, `["block_added", {"fromBlock":3}]`)
+		for i := uint32(from); i <= height; i++ {
+			resp := getNotification(t, respMsgs)
+			require.Equal(t, response.BlockEventID, resp.Event)
+			rmap := resp.Payload[0].(map[string]interface{})
+			require.Equal(t, i, uint32(rmap["index"].(float64)))
+		}
+		// Live events follow the replayed ones.
+		require.NoError(t, chain.AddBlock(newBlock(t, chain, 1, 0)))
+		resp := getNotification(t, respMsgs)
+		require.Equal(t, response.BlockEventID, resp.Event)
+		rmap := resp.Payload[0].(map[string]interface{})
+		require.Equal(t, height+1, uint32(rmap["index"].(float64)))
+		callUnsubscribe(t, c, respMsgs, subID)
+	})
+	t.Run("notifications", func(t *testing.T) {
+		subID := callSubscribe(t, c, respMsgs, `["notification_from_execution", {"name":"transfer","fromBlock":0}]`)
+		// There are 3 transfers in test blocks.
+		for i := 0; i < 3; i++ {
+			resp := getNotification(t, respMsgs)
+			require.Equal(t, response.NotificationEventID, resp.Event)
+			rmap := resp.Payload[0].(map[string]interface{})
+			require.Equal(t, "0x"+testContractHash, rmap["contract"])
+		}
+		callUnsubscribe(t, c, respMsgs, subID)
+	})
+	t.Run("next block", func(t *testing.T) {
+		from := chain.BlockHeight() + 1
+		subID := callSubscribe(t, c, respMsgs, fmt.Sprintf(`["block_added", {"fromBlock":%d}]`, from))
+		require.NoError(t, chain.AddBlock(newBlock(t, chain, 1, 0)))
+		resp := getNotification(t, respMsgs)
+		require.Equal(t, response.BlockEventID, resp.Event)
+		rmap := resp.Payload[0].(map[string]interface{})
+		require.Equal(t, from, uint32(rmap["index"].(float64)))
+		callUnsubscribe(t, c, respMsgs, subID)
+	})
+	t.Run("limits", func(t *testing.T) {
+		subscribe := func(from uint32) *response.Raw {
+			return callWSGetRaw(t, c, fmt.Sprintf(`{"jsonrpc": "2.0","method": "subscribe","params": ["block_added", {"fromBlock":%d}],"id": 1}`, from), respMsgs)
+		}
+		rpcSrv.subsLock.Lock()
+		rpcSrv.config.MaxReplayBlocks = 2
+		rpcSrv.subsLock.Unlock()
+		height := chain.BlockHeight()
+		resp := subscribe(height - 2)
+		require.NotNil(t, resp.Error)
+
+		subID := callSubscribe(t, c, respMsgs, fmt.Sprintf(`["block_added", {"fromBlock":%d}]`, height-1))
+		for i := height - 1; i <= height; i++ {
+			resp := getNotification(t, respMsgs)
+			require.Equal(t, response.BlockEventID, resp.Event)
+			rmap := resp.Payload[0].(map[string]interface{})
+			require.Equal(t, i, uint32(rmap["index"].(float64)))
+		}
+		callUnsubscribe(t, c, respMsgs, subID)
+
+		rpcSrv.subsLock.Lock()
+		rpcSrv.replays += rpcSrv.config.MaxReplays
+		rpcSrv.subsLock.Unlock()
+		resp = subscribe(height)
+		require.NotNil(t, resp.Error)
+		rpcSrv.subsLock.Lock()
+		rpcSrv.replays -= rpcSrv.config.MaxReplays
+		rpcSrv.subsLock.Unlock()
+	})
+	finishedFlag.CAS(false, true)
+	c.Close()
+}
+
 func TestMaxSubscriptions(t *testing.T) {
 	var subIDs = make([]string, 0)
 	chain, rpcSrv, c, respMsgs, finishedFlag := initCleanServerAndWSClient(t)
@@ -322,6 +461,13 @@ func TestBadSubUnsub(t *testing.T) {
 		"notification filter":    `{"jsonrpc": "2.0", "method": "subscribe", "params": ["notification_from_execution", "contract"], "id": 1}`,
 		"execution filter 1":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", "FAULT"], "id": 1}`,
 		"execution filter 2":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", {"state": "STOP"}], "id": 1}`,
+		"header filter":          `{"jsonrpc": "2.0", "method": "subscribe", "params": ["header_added", {"primary": 1}], "id": 1}`,
+		"mempool filter":         `{"jsonrpc": "2.0", "method": "subscribe", "params": ["mempool_added", {"primary": 1}], "id": 1}`,
+		"mempool fromBlock":      `{"jsonrpc": "2.0", "method": "subscribe", "params": ["mempool_removed", {"fromBlock": 0}], "id": 1}`,
+		"fromBlock too high":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", {"fromBlock": 100500}], "id": 1}`,
+		"block name":             `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", {"name": "transfer"}], "id": 1}`,
+		"options before filter":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", {"fromBlock": 0}, {"primary": 1}], "id": 1}`,
+		"two filters":            `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", {"primary": 1}, {"primary": 1}], "id": 1}`,
 	}
 	var unsubCases = map[string]string{
 		"no params":         `{"jsonrpc": "2.0", "method": "unsubscribe", "params": [], "id": 1}`,
@@ -0,0 +1,529 @@
+package server
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/request"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/pkg/errors"
+)
+
+// transferOutput is a single transfer made by wallet methods, amount is
+// in token's minimal units.
+type transferOutput struct {
+	asset  util.Uint160
+	to     util.Uint160
+	amount int64
+}
+
+var (
+	errNoWallet          = response.NewRPCError("No wallet opened", "", nil)
+	errUnknownAccount    = response.NewRPCError("Unknown account", "", nil)
+	errInsufficientFunds = response.NewRPCError("Insufficient funds", "", nil)
+)
+
+// OpenWallet opens the wallet to be used by wallet methods closing the
+// previously opened one (if any). This wallet can also be reopened with
+// `openwallet` call later.
+func (s *Server) OpenWallet(cfg wallet.Config) error {
+	return s.openWallet(cfg, true)
+}
+
+// openWallet opens the wallet checking the password against all accounts with
+// keys (there must be at least one of them). If trusted is set the wallet
+// path is remembered as the one allowed for `openwallet` call.
+func (s *Server) openWallet(cfg wallet.Config, trusted bool) error {
+	w, err := wallet.NewWalletFromFile(cfg.Path)
+	if err != nil {
+		return err
+	}
+	var keys int
+	for _, acc := range w.Accounts {
+		if acc.EncryptedWIF == "" {
+			continue
+		}
+		if err := acc.Decrypt(cfg.Password); err != nil {
+			w.Close()
+			return errors.New("invalid password")
+		}
+		keys++
+	}
+	if keys == 0 {
+		w.Close()
+		return errors.New("wallet has no accounts with keys")
+	}
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	if s.wallet != nil {
+		s.wallet.Close()
+	}
+	s.wallet = w
+	s.walletPass = cfg.Password
+	if trusted {
+		s.walletPath = cfg.Path
+	}
+	return nil
+}
+
+// isWalletPathAllowed checks whether the wallet at the given path can be
+// opened with `openwallet` call, that is it's the one opened with OpenWallet
+// or it's located in the configured WalletDirectory.
+func (s *Server) isWalletPathAllowed(path string) bool {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	s.walletLock.Lock()
+	trusted := s.walletPath
+	s.walletLock.Unlock()
+	if trusted != "" {
+		if p, err := filepath.Abs(trusted); err == nil && p == path {
+			return true
+		}
+	}
+	if s.config.WalletDirectory == "" {
+		return false
+	}
+	dir, err := filepath.Abs(s.config.WalletDirectory)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && rel != ".." &&
+		!strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// closeWallet closes the opened wallet if there is any.
+func (s *Server) closeWallet() {
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	if s.wallet != nil {
+		s.wallet.Close()
+		s.wallet = nil
+		s.walletPass = ""
+	}
+}
+
+// openWalletCall implements the `openwallet` RPC call.
+func (s *Server) openWalletCall(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) != 2 {
+		return nil, response.ErrInvalidParams
+	}
+	path, err := ps[0].GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	password, err := ps[1].GetString()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	if !s.isWalletPathAllowed(path) {
+		return nil, response.NewRPCError("Can't open wallet", "wallet path is not allowed", nil)
+	}
+	if err := s.openWallet(wallet.Config{Path: path, Password: password}, false); err != nil {
+		return nil, response.NewRPCError("Can't open wallet", err.Error(), err)
+	}
+	return true, nil
+}
+
+// closeWalletCall implements the `closewallet` RPC call.
+func (s *Server) closeWalletCall(_ request.Params) (interface{}, *response.Error) {
+	s.closeWallet()
+	return true, nil
+}
+
+// getNewAddress creates a new account in the opened wallet.
+func (s *Server) getNewAddress(_ request.Params) (interface{}, *response.Error) {
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	if s.wallet == nil {
+		return nil, errNoWallet
+	}
+	acc, err := wallet.NewAccount()
+	if err != nil {
+		return nil, response.NewInternalServerError("Can't create account", err)
+	}
+	if err := acc.Encrypt(s.walletPass); err != nil {
+		return nil, response.NewInternalServerError("Can't encrypt account", err)
+	}
+	s.wallet.AddAccount(acc)
+	if err := s.wallet.Save(); err != nil {
+		return nil, response.NewInternalServerError("Can't save wallet", err)
+	}
+	return acc.Address, nil
+}
+
+// listAddress returns the accounts of the opened wallet.
+func (s *Server) listAddress(_ request.Params) (interface{}, *response.Error) {
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	if s.wallet == nil {
+		return nil, errNoWallet
+	}
+	res := make([]result.WalletAddress, 0, len(s.wallet.Accounts))
+	for _, acc := range s.wallet.Accounts {
+		res = append(res, result.WalletAddress{
+			Address:   acc.Address,
+			HasKey:    acc.EncryptedWIF != "",
+			Label:     acc.Label,
+			WatchOnly: acc.Contract == nil,
+		})
+	}
+	return res, nil
+}
+
+// getWalletBalance returns the balance of the given asset summed over all
+// accounts of the opened wallet.
+func (s *Server) getWalletBalance(ps request.Params) (interface{}, *response.Error) {
+	p, ok := ps.Value(0)
+	if !ok {
+		return nil, response.ErrInvalidParams
+	}
+	asset, err := p.GetUint160FromHex()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	dec, respErr := s.getDecimals(asset, make(map[util.Uint160]int64))
+	if respErr != nil {
+		return nil, respErr
+	}
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	if s.wallet == nil {
+		return nil, errNoWallet
+	}
+	var sum int64
+	for _, acc := range s.wallet.Accounts {
+		if acc.Contract != nil {
+			sum += s.getTokenBalance(acc.Contract.ScriptHash(), asset)
+		}
+	}
+	return result.WalletBalance{Balance: amountToString(sum, dec)}, nil
+}
+
+// getWalletUnclaimedGas returns the amount of GAS that can be claimed by all
+// accounts of the opened wallet.
+func (s *Server) getWalletUnclaimedGas(_ request.Params) (interface{}, *response.Error) {
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	if s.wallet == nil {
+		return nil, errNoWallet
+	}
+	var gas util.Fixed8
+	for _, acc := range s.wallet.Accounts {
+		if acc.Contract == nil {
+			continue
+		}
+		neo, neoHeight := s.chain.GetGoverningTokenBalance(acc.Contract.ScriptHash())
+		if neo == 0 {
+			continue
+		}
+		gas += s.chain.CalculateClaimable(int64(neo), neoHeight, s.chain.BlockHeight()+1)
+	}
+	return strconv.FormatInt(int64(gas), 10), nil
+}
+
+// dumpPrivKey returns WIF of the given wallet account.
+func (s *Server) dumpPrivKey(ps request.Params) (interface{}, *response.Error) {
+	p, ok := ps.Value(0)
+	if !ok {
+		return nil, response.ErrInvalidParams
+	}
+	u, err := p.GetUint160FromAddress()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	if s.wallet == nil {
+		return nil, errNoWallet
+	}
+	acc := s.wallet.GetAccount(u)
+	if acc == nil || acc.EncryptedWIF == "" {
+		return nil, errUnknownAccount
+	}
+	if err := s.unlockAccount(acc); err != nil {
+		return nil, response.NewRPCError("Can't unlock account", err.Error(), err)
+	}
+	return acc.PrivateKey().WIF(), nil
+}
+
+// sendFrom transfers asset from the given wallet account.
+func (s *Server) sendFrom(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) != 4 {
+		return nil, response.ErrInvalidParams
+	}
+	from, err := ps[1].GetUint160FromAddress()
+	if err != nil {
+		return nil, response.ErrInvalidParams
+	}
+	out, respErr := s.transferOutputFromParams(ps[0], ps[2], ps[3])
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.sendTransfers(&from, []transferOutput{out})
+}
+
+// sendToAddress transfers asset from any wallet accounts having it.
+func (s *Server) sendToAddress(ps request.Params) (interface{}, *response.Error) {
+	if len(ps) != 3 {
+		return nil, response.ErrInvalidParams
+	}
+	out, respErr := s.transferOutputFromParams(ps[0], ps[1], ps[2])
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.sendTransfers(nil, []transferOutput{out})
+}
+
+// sendMany makes several transfers in one transaction, the first parameter
+// is an optional address to transfer from.
+func (s *Server) sendMany(ps request.Params) (interface{}, *response.Error) {
+	var from *util.Uint160
+	if len(ps) == 2 {
+		u, err := ps[0].GetUint160FromAddress()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		from = &u
+		ps = ps[1:]
+	}
+	if len(ps) != 1 {
+		return nil, response.ErrInvalidParams
+	}
+	arr, err := ps[0].GetArray()
+	if err != nil || len(arr) == 0 {
+		return nil, response.ErrInvalidParams
+	}
+	outs := make([]transferOutput, 0, len(arr))
+	for i := range arr {
+		tr, err := arr[i].GetTransfer()
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		to, err := address.StringToUint160(tr.Address)
+		if err != nil {
+			return nil, response.ErrInvalidParams
+		}
+		out, respErr := s.newTransferOutput(tr.Asset, to, tr.Value)
+		if respErr != nil {
+			return nil, respErr
+		}
+		outs = append(outs, out)
+	}
+	return s.sendTransfers(from, outs)
+}
+
+// transferOutputFromParams parses asset hash, destination address and
+// decimal amount of the transfer.
+func (s *Server) transferOutputFromParams(assetP, toP, amountP request.Param) (transferOutput, *response.Error) {
+	asset, err := assetP.GetUint160FromHex()
+	if err != nil {
+		return transferOutput{}, response.ErrInvalidParams
+	}
+	to, err := toP.GetUint160FromAddress()
+	if err != nil {
+		return transferOutput{}, response.ErrInvalidParams
+	}
+	// Numbers are not accepted, fractional ones are truncated by the
+	// parameter parser and can't be represented precisely anyway.
+	amount, err := amountP.GetString()
+	if err != nil {
+		return transferOutput{}, response.NewInvalidParamsError("Invalid amount", errors.New("amount must be a decimal string"))
+	}
+	return s.newTransferOutput(asset, to, amount)
+}
+
+// newTransferOutput converts decimal amount string to token's minimal units
+// and returns the transfer output.
+func (s *Server) newTransferOutput(asset, to util.Uint160, amountStr string) (transferOutput, *response.Error) {
+	dec, respErr := s.getDecimals(asset, make(map[util.Uint160]int64))
+	if respErr != nil {
+		return transferOutput{}, response.NewInvalidParamsError("Unknown asset", nil)
+	}
+	if i := strings.IndexByte(amountStr, '.'); i >= 0 && len(amountStr)-i-1 > int(dec) {
+		return transferOutput{}, response.NewInvalidParamsError("Invalid amount", errors.New("too many decimal digits"))
+	}
+	amount, err := util.FixedNFromString(amountStr, int(dec))
+	if err != nil || amount <= 0 {
+		return transferOutput{}, response.NewInvalidParamsError("Invalid amount", err)
+	}
+	return transferOutput{asset: asset, to: to, amount: amount}, nil
+}
+
+// sendTransfers creates, signs and relays the transaction making the given
+// transfers from wallet accounts (or only from the given one if it's not
+// nil). Transaction sender is the first of the accounts (starting with the
+// ones transferring assets) that has enough GAS to pay the fees.
+func (s *Server) sendTransfers(from *util.Uint160, outs []transferOutput) (interface{}, *response.Error) {
+	s.walletLock.Lock()
+	defer s.walletLock.Unlock()
+	if s.wallet == nil {
+		return nil, errNoWallet
+	}
+	var accs []*wallet.Account
+	if from != nil {
+		acc := s.wallet.GetAccount(*from)
+		if acc == nil || !isSigningAccount(acc) {
+			return nil, errUnknownAccount
+		}
+		accs = append(accs, acc)
+	} else {
+		for _, acc := range s.wallet.Accounts {
+			if isSigningAccount(acc) {
+				accs = append(accs, acc)
+			}
+		}
+	}
+
+	// Transfers are made from accounts in the wallet order until the
+	// amount is collected.
+	var (
+		bw      = io.NewBufBinWriter()
+		signers []*wallet.Account
+		spent   = make(map[*wallet.Account]map[util.Uint160]int64)
+	)
+	for _, out := range outs {
+		left := out.amount
+		for _, acc := range accs {
+			h := acc.Contract.ScriptHash()
+			if spent[acc] == nil {
+				spent[acc] = make(map[util.Uint160]int64)
+			}
+			balance := s.getTokenBalance(h, out.asset) - spent[acc][out.asset]
+			if balance <= 0 {
+				continue
+			}
+			amount := left
+			if balance < amount {
+				amount = balance
+			}
+			emit.AppCallWithOperationAndArgs(bw.BinWriter, out.asset, "transfer", h, out.to, amount)
+			emit.Opcode(bw.BinWriter, opcode.ASSERT)
+			if len(spent[acc]) == 0 {
+				signers = append(signers, acc)
+			}
+			spent[acc][out.asset] += amount
+			left -= amount
+			if left == 0 {
+				break
+			}
+		}
+		if left != 0 {
+			return nil, errInsufficientFunds
+		}
+	}
+	script := bw.Bytes()
+
+	// Transaction hash is cached on first use, so every fee calculation
+	// step gets its own copy and the one to be signed is created last.
+	validUntil := s.chain.BlockHeight() + transaction.MaxValidUntilBlockIncrement
+	newTx := func(sender util.Uint160) *transaction.Transaction {
+		tx := transaction.New(script, 0)
+		tx.ValidUntilBlock = validUntil
+		tx.Sender = sender
+		for _, acc := range signers {
+			tx.Cosigners = append(tx.Cosigners, transaction.Cosigner{
+				Account: acc.Contract.ScriptHash(),
+				Scopes:  transaction.CalledByEntry,
+			})
+		}
+		return tx
+	}
+	res := s.chain.TestInvoke(script, newTx(signers[0].Contract.ScriptHash()), s.config.MaxGasInvoke)
+	if res.VMState != "HALT" {
+		return nil, response.NewRPCError("Transfer failed", res.FaultException, nil)
+	}
+	sysFee := res.GasConsumed
+
+	// Accounts making transfers are preferred as senders, because they
+	// sign the transaction anyway.
+	senders := signers
+	for _, acc := range accs {
+		if len(spent[acc]) == 0 {
+			senders = append(senders, acc)
+		}
+	}
+	var (
+		sender *wallet.Account
+		netFee util.Fixed8
+	)
+	for _, acc := range senders {
+		tx := newTx(acc.Contract.ScriptHash())
+		tx.SystemFee = sysFee
+		if len(spent[acc]) == 0 {
+			tx.Scripts = append(tx.Scripts, transaction.Witness{VerificationScript: acc.Contract.Script})
+		}
+		for _, signer := range signers {
+			tx.Scripts = append(tx.Scripts, transaction.Witness{VerificationScript: signer.Contract.Script})
+		}
+		fee, err := s.chain.CalculateTxNetworkFee(tx)
+		if err != nil {
+			return nil, response.NewInternalServerError("Can't calculate network fee", err)
+		}
+		// GAS transferred by the sender can't be used to pay fees.
+		gas := s.chain.GetUtilityTokenBalance(tx.Sender) - util.Fixed8(spent[acc][s.chain.UtilityTokenHash()])
+		if gas >= sysFee+fee {
+			sender, netFee = acc, fee
+			break
+		}
+	}
+	if sender == nil {
+		return nil, response.NewRPCError("Insufficient GAS", "not enough GAS to pay fees", nil)
+	}
+
+	tx := newTx(sender.Contract.ScriptHash())
+	tx.SystemFee = sysFee
+	tx.NetworkFee = netFee
+	if len(spent[sender]) == 0 {
+		signers = append(signers, sender)
+	}
+	for _, acc := range signers {
+		if err := s.unlockAccount(acc); err != nil {
+			return nil, response.NewRPCError("Can't unlock account", err.Error(), err)
+		}
+		if err := acc.SignTx(tx); err != nil {
+			return nil, response.NewInternalServerError("Can't sign transaction", err)
+		}
+	}
+	if respErr := s.relayTx(tx); respErr != nil {
+		return nil, respErr
+	}
+	return tx, nil
+}
+
+// getTokenBalance returns the balance of acc in the given token.
+func (s *Server) getTokenBalance(acc util.Uint160, token util.Uint160) int64 {
+	bs := s.chain.GetNEP5Balances(acc)
+	if bs == nil {
+		return 0
+	}
+	return bs.Trackers[token].Balance
+}
+
+// unlockAccount decrypts the account key with the wallet password if it's
+// not decrypted yet. It's supposed to be called with s.walletLock taken.
+func (s *Server) unlockAccount(acc *wallet.Account) error {
+	if acc.PrivateKey() != nil {
+		return nil
+	}
+	return acc.Decrypt(s.walletPass)
+}
+
+// isSigningAccount checks whether the account can be used to sign
+// transactions, that is it has a key and a standard signature contract.
+func isSigningAccount(acc *wallet.Account) bool {
+	return acc.EncryptedWIF != "" && !acc.Locked && acc.Contract != nil &&
+		vm.IsSignatureContract(acc.Contract.Script)
+}
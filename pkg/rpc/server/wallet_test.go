@@ -0,0 +1,199 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/rpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response"
+	"github.com/nspcc-dev/neo-go/pkg/rpc/response/result"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletMethods(t *testing.T) {
+	const (
+		walletPass   = "one"
+		receiver     = "AKkkumHbBipZ46UMZJoFynJMXzSRnBvKcs"
+		walletMethod = `{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": %s}`
+	)
+	dir, err := ioutil.TempDir("", "rpcwallet")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	walletPath := filepath.Join(dir, "wallet.json")
+	data, err := ioutil.ReadFile("../../consensus/testdata/wallet1.json")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(walletPath, data, 0644))
+
+	t.Run("disabled", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		body := doRPCCallOverHTTP(fmt.Sprintf(walletMethod, "listaddress", `[]`), httpSrv.URL, t)
+		var resp response.Raw
+		require.NoError(t, json.Unmarshal(body, &resp))
+		require.NotNil(t, resp.Error)
+		require.Equal(t, response.NewMethodNotFoundError("", nil).Code, resp.Error.Code)
+	})
+	t.Run("read-only", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithConfig(t, func(c *rpc.Config) {
+			c.EnableWalletMethods = true
+			c.ReadOnly = true
+			c.WalletDirectory = dir
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		for method, params := range map[string]string{
+			"openwallet":    `["` + walletPath + `", "` + walletPass + `"]`,
+			"getnewaddress": `[]`,
+			"dumpprivkey":   `["` + receiver + `"]`,
+		} {
+			body := doRPCCallOverHTTP(fmt.Sprintf(walletMethod, method, params), httpSrv.URL, t)
+			var resp response.Raw
+			require.NoError(t, json.Unmarshal(body, &resp))
+			require.NotNil(t, resp.Error, method)
+			require.Equal(t, response.NewForbiddenMethodError("").Code, resp.Error.Code, method)
+		}
+	})
+
+	chain, rpcSrv, httpSrv := initClearServerWithConfig(t, func(c *rpc.Config) {
+		c.EnableWalletMethods = true
+		c.WalletDirectory = dir
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	priv0 := testchain.PrivateKeyByID(0)
+
+	// Key decryption is slow, especially with race detector enabled, so
+	// the timeout is bigger than the one of doRPCCallOverHTTP.
+	cl := http.Client{Timeout: 30 * time.Second}
+	call := func(t *testing.T, method string, params string, fail bool, res interface{}) {
+		resp, err := cl.Post(httpSrv.URL, "application/json", strings.NewReader(fmt.Sprintf(walletMethod, method, params)))
+		require.NoError(t, err)
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		raw := checkErrGetResult(t, bytes.TrimSpace(body), fail)
+		if !fail && res != nil {
+			require.NoError(t, json.Unmarshal(raw, res))
+		}
+	}
+	checkSent := func(t *testing.T, method string, params string) *transaction.Transaction {
+		tx := new(transaction.Transaction)
+		call(t, method, params, false, tx)
+		require.True(t, chain.GetMemPool().ContainsKey(tx.Hash()))
+		return tx
+	}
+
+	t.Run("no wallet", func(t *testing.T) {
+		call(t, "listaddress", `[]`, true, nil)
+		call(t, "sendtoaddress", `["`+testContractHash+`", "`+receiver+`", "1"]`, true, nil)
+	})
+	t.Run("openwallet", func(t *testing.T) {
+		call(t, "openwallet", `[]`, true, nil)
+		call(t, "openwallet", `["`+walletPath+`", "two"]`, true, nil)
+		call(t, "openwallet", `["`+filepath.Join(dir, "nonexistent.json")+`", "one"]`, true, nil)
+		// Outside of WalletDirectory.
+		call(t, "openwallet", `["../../consensus/testdata/wallet1.json", "`+walletPass+`"]`, true, nil)
+		call(t, "openwallet", `["`+filepath.Join(dir, "..", "wallet.json")+`", "`+walletPass+`"]`, true, nil)
+		// No accounts with keys to check the password against.
+		emptyPath := filepath.Join(dir, "empty.json")
+		require.NoError(t, ioutil.WriteFile(emptyPath, []byte(`{"version":"1.0","accounts":[],"scrypt":{"n":16384,"r":8,"p":8},"extra":{"Tokens":null}}`), 0644))
+		call(t, "openwallet", `["`+emptyPath+`", "any"]`, true, nil)
+		var ok bool
+		call(t, "openwallet", `["`+walletPath+`", "`+walletPass+`"]`, false, &ok)
+		require.True(t, ok)
+	})
+	t.Run("listaddress", func(t *testing.T) {
+		var res []result.WalletAddress
+		call(t, "listaddress", `[]`, false, &res)
+		require.Equal(t, 2, len(res))
+		require.Equal(t, result.WalletAddress{Address: priv0.Address(), HasKey: true}, res[0])
+	})
+	t.Run("getwalletbalance", func(t *testing.T) {
+		call(t, "getwalletbalance", `[]`, true, nil)
+		call(t, "getwalletbalance", `["notahash"]`, true, nil)
+		var res result.WalletBalance
+		call(t, "getwalletbalance", `["`+testContractHash+`"]`, false, &res)
+		require.Equal(t, "8.77", res.Balance)
+	})
+	t.Run("getwalletunclaimedgas", func(t *testing.T) {
+		var res string
+		call(t, "getwalletunclaimedgas", `[]`, false, &res)
+		require.NotEqual(t, "0", res)
+	})
+	t.Run("dumpprivkey", func(t *testing.T) {
+		call(t, "dumpprivkey", `["`+receiver+`"]`, true, nil)
+		var res string
+		call(t, "dumpprivkey", `["`+priv0.Address()+`"]`, false, &res)
+		require.Equal(t, priv0.WIF(), res)
+	})
+	t.Run("getnewaddress", func(t *testing.T) {
+		var addr string
+		call(t, "getnewaddress", `[]`, false, &addr)
+		var res []result.WalletAddress
+		call(t, "listaddress", `[]`, false, &res)
+		require.Equal(t, 3, len(res))
+		require.Equal(t, addr, res[2].Address)
+	})
+	t.Run("sendtoaddress", func(t *testing.T) {
+		call(t, "sendtoaddress", `["`+testContractHash+`", "`+receiver+`"]`, true, nil)
+		call(t, "sendtoaddress", `["`+testContractHash+`", "`+receiver+`", "-1"]`, true, nil)
+		call(t, "sendtoaddress", `["`+testContractHash+`", "`+receiver+`", "1.001"]`, true, nil)
+		call(t, "sendtoaddress", `["`+testContractHash+`", "`+receiver+`", "1000"]`, true, nil)
+		tx := checkSent(t, "sendtoaddress", `["`+testContractHash+`", "`+receiver+`", "1.5"]`)
+		require.Equal(t, priv0.GetScriptHash(), tx.Sender)
+		require.Equal(t, 1, len(tx.Scripts))
+		require.True(t, tx.SystemFee > 0)
+		require.True(t, tx.NetworkFee > 0)
+	})
+	t.Run("sendfrom", func(t *testing.T) {
+		gas := chain.UtilityTokenHash().StringLE()
+		call(t, "sendfrom", `["`+testContractHash+`", "`+receiver+`", "`+receiver+`", "1"]`, true, nil)
+		// Numeric amounts are rejected.
+		call(t, "sendfrom", `["`+gas+`", "`+priv0.Address()+`", "`+receiver+`", 1]`, true, nil)
+		call(t, "sendfrom", `["`+gas+`", "`+priv0.Address()+`", "`+receiver+`", 1.5]`, true, nil)
+		// GAS transferred can't be used to pay fees.
+		balance := chain.GetUtilityTokenBalance(priv0.GetScriptHash())
+		resp, err := cl.Post(httpSrv.URL, "application/json", strings.NewReader(fmt.Sprintf(walletMethod,
+			"sendfrom", `["`+gas+`", "`+priv0.Address()+`", "`+receiver+`", "`+balance.String()+`"]`)))
+		require.NoError(t, err)
+		var raw response.Raw
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&raw))
+		resp.Body.Close()
+		require.NotNil(t, raw.Error)
+		require.Equal(t, "Insufficient GAS", raw.Error.Message)
+
+		tx := checkSent(t, "sendfrom", `["`+gas+`", "`+priv0.Address()+`", "`+receiver+`", "1.5"]`)
+		require.Equal(t, priv0.GetScriptHash(), tx.Sender)
+	})
+	t.Run("sendmany", func(t *testing.T) {
+		call(t, "sendmany", `[[]]`, true, nil)
+		call(t, "sendmany", `[[{"asset": "`+testContractHash+`", "address": "`+receiver+`", "value": 1}]]`, true, nil)
+		tx := checkSent(t, "sendmany", `["`+priv0.Address()+`", [
+			{"asset": "`+testContractHash+`", "address": "`+receiver+`", "value": "0.01"},
+			{"asset": "`+chain.GoverningTokenHash().StringLE()+`", "address": "`+receiver+`", "value": "10"}]]`)
+		require.Equal(t, priv0.GetScriptHash(), tx.Sender)
+		require.Equal(t, 1, len(tx.Cosigners))
+		require.Equal(t, priv0.GetScriptHash(), tx.Cosigners[0].Account)
+	})
+	t.Run("closewallet", func(t *testing.T) {
+		var ok bool
+		call(t, "closewallet", `[]`, false, &ok)
+		require.True(t, ok)
+		call(t, "listaddress", `[]`, true, nil)
+	})
+}
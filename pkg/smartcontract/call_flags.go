@@ -12,3 +12,8 @@ const (
 	ReadOnly = AllowCall | AllowNotify
 	All      = AllowModifyStates | AllowCall | AllowNotify
 )
+
+// Has returns true iff all bits set in cf are also set in f.
+func (f CallFlag) Has(cf CallFlag) bool {
+	return f&cf == cf
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -37,11 +38,17 @@ type Context struct {
 	local     *Slot
 	arguments *Slot
 
+	// Exception handling contexts of the TRY blocks being executed.
+	tryStack []*exceptionHandlingContext
+
 	// Script hash of the prog.
 	scriptHash util.Uint160
 
 	// Whether it's allowed to make dynamic calls from this context.
 	hasDynamicInvoke bool
+
+	// Call flags this context was created with.
+	callFlag smartcontract.CallFlag
 }
 
 var errNoInstParam = errors.New("failed to read instruction parameter")
@@ -104,14 +111,16 @@ func (c *Context) Next() (opcode.Opcode, []byte, error) {
 		}
 	case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
 		opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
-		opcode.CALL, opcode.ISTYPE, opcode.CONVERT, opcode.NEWARRAYT,
+		opcode.CALL, opcode.ISTYPE, opcode.CONVERT, opcode.NEWARRAYT, opcode.ENDTRY,
 		opcode.INITSSLOT, opcode.LDSFLD, opcode.STSFLD, opcode.LDARG, opcode.STARG, opcode.LDLOC, opcode.STLOC:
 		numtoread = 1
-	case opcode.INITSLOT:
+	case opcode.INITSLOT, opcode.TRY:
 		numtoread = 2
+	case opcode.TRYL:
+		numtoread = 8
 	case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
 		opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL,
-		opcode.CALLL, opcode.SYSCALL, opcode.PUSHA:
+		opcode.CALLL, opcode.SYSCALL, opcode.PUSHA, opcode.ENDTRYL:
 		numtoread = 4
 	default:
 		if instr <= opcode.PUSHINT256 {
@@ -157,6 +166,11 @@ func (c *Context) Copy() *Context {
 	return ctx
 }
 
+// GetCallFlags returns calling flags context was created with.
+func (c *Context) GetCallFlags() smartcontract.CallFlag {
+	return c.callFlag
+}
+
 // Program returns the loaded program.
 func (c *Context) Program() []byte {
 	return c.prog
@@ -171,7 +171,7 @@ func AppCallWithOperation(w *io.BinWriter, scriptHash util.Uint160, operation st
 }
 
 func isInstructionJmp(op opcode.Opcode) bool {
-	return opcode.JMP <= op && op <= opcode.CALLL
+	return opcode.JMP <= op && op <= opcode.CALLL || op == opcode.ENDTRYL
 }
 
 // InteropNameToID returns an identificator of the method based on its name.
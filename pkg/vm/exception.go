@@ -0,0 +1,114 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// MaxTryNestingDepth is the maximum level of TRY nesting allowed within
+// single execution context.
+const MaxTryNestingDepth = 16
+
+// exceptionHandlingState represents state of the exception handling process.
+type exceptionHandlingState byte
+
+const (
+	eTry exceptionHandlingState = iota
+	eCatch
+	eFinally
+)
+
+// exceptionHandlingContext represents single TRY block of some execution
+// context.
+type exceptionHandlingContext struct {
+	CatchOffset   int
+	FinallyOffset int
+	EndOffset     int
+	State         exceptionHandlingState
+}
+
+// newExceptionHandlingContext returns new TRY block context with the given
+// catch and finally offsets, -1 means there is no such block.
+func newExceptionHandlingContext(cOffset, fOffset int) *exceptionHandlingContext {
+	return &exceptionHandlingContext{
+		CatchOffset:   cOffset,
+		FinallyOffset: fOffset,
+		EndOffset:     -1,
+		State:         eTry,
+	}
+}
+
+// HasCatch returns true if the context has `catch` block.
+func (c *exceptionHandlingContext) HasCatch() bool {
+	return c.CatchOffset >= 0
+}
+
+// HasFinally returns true if the context has `finally` block.
+func (c *exceptionHandlingContext) HasFinally() bool {
+	return c.FinallyOffset >= 0
+}
+
+// getTryParams splits TRY and TRY_L instruction parameter into catch and
+// finally parts.
+func getTryParams(parameter []byte) ([]byte, []byte) {
+	n := len(parameter) / 2
+	return parameter[:n], parameter[n:]
+}
+
+// throw sets the uncaught exception and starts looking for a handler for it.
+func (v *VM) throw(item stackitem.Item) {
+	v.uncaughtException = item
+	v.handleException()
+}
+
+// handleException looks for the nearest TRY block able to process current
+// exception walking the invocation stack from top to bottom and unloading
+// contexts without appropriate handlers. It panics if there is none.
+func (v *VM) handleException() {
+	for pop := 0; pop < v.istack.Len(); pop++ {
+		ctx := v.istack.Peek(pop).Value().(*Context)
+		for len(ctx.tryStack) > 0 {
+			eCtx := ctx.tryStack[len(ctx.tryStack)-1]
+			if eCtx.State == eFinally || (eCtx.State == eCatch && !eCtx.HasFinally()) {
+				ctx.tryStack = ctx.tryStack[:len(ctx.tryStack)-1]
+				continue
+			}
+			for i := 0; i < pop; i++ {
+				v.unloadContext()
+			}
+			if eCtx.State == eTry && eCtx.HasCatch() {
+				eCtx.State = eCatch
+				v.estack.PushVal(v.uncaughtException)
+				v.uncaughtException = nil
+				v.jumpIf(ctx, eCtx.CatchOffset, true)
+			} else {
+				eCtx.State = eFinally
+				v.jumpIf(ctx, eCtx.FinallyOffset, true)
+			}
+			return
+		}
+	}
+	panic(fmt.Sprintf("unhandled exception: %s", exceptionMessage(v.uncaughtException)))
+}
+
+// unloadContext removes current context from the invocation stack without
+// passing any results to the previous one.
+func (v *VM) unloadContext() {
+	v.istack.Pop()
+	if ctx := v.Context(); ctx != nil {
+		v.estack = ctx.estack
+		v.astack = ctx.astack
+	}
+}
+
+// exceptionMessage returns printable representation of an exception item.
+func exceptionMessage(item stackitem.Item) string {
+	if item == nil {
+		return "null"
+	}
+	if b, err := item.TryBytes(); err == nil {
+		return fmt.Sprintf("%q", b)
+	}
+	return item.Type().String()
+}
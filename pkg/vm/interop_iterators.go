@@ -125,3 +125,34 @@ func (e *valuesWrapper) Next() bool {
 func (e *valuesWrapper) Value() stackitem.Item {
 	return e.iter.Value()
 }
+
+// IsIterator returns whether the given item is an interop item containing
+// iterator or enumerator.
+func IsIterator(item stackitem.Item) bool {
+	iop, ok := item.(*stackitem.Interop)
+	if !ok {
+		return false
+	}
+	_, ok = iop.Value().(enumerator)
+	return ok
+}
+
+// IterateNext advances iterator (or enumerator) contained in the given
+// interop item at most n times and returns values traversed. Iterators having
+// keys return them along with values as two-element structs. Nil is returned
+// if the item is not an iterator.
+func IterateNext(item stackitem.Item, n int) []stackitem.Item {
+	if !IsIterator(item) {
+		return nil
+	}
+	e := item.(*stackitem.Interop).Value().(enumerator)
+	res := make([]stackitem.Item, 0)
+	for len(res) < n && e.Next() {
+		if it, ok := e.(iterator); ok {
+			res = append(res, stackitem.NewStruct([]stackitem.Item{it.Key(), it.Value()}))
+		} else {
+			res = append(res, e.Value())
+		}
+	}
+	return res
+}
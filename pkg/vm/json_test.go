@@ -92,11 +92,21 @@ const (
 	typeStruct     vmUTStackItemType = "struct"
 
 	testsDir = "testdata/neo-vm/tests/neo-vm.Tests/Tests/"
+	// localTestsDir contains tests in the same format for the features that
+	// are not covered by neo-vm tests yet.
+	localTestsDir = "testdata/tests/"
 )
 
 func TestUT(t *testing.T) {
+	require.Equal(t, true, testDir(t, localTestsDir), "local tests should be available")
+	require.Equal(t, true, testDir(t, testsDir), "neo-vm tests should be available (check submodules)")
+}
+
+// testDir runs tests from all JSON files found in dir and returns true if
+// there were any.
+func testDir(t *testing.T, dir string) bool {
 	testsRan := false
-	err := filepath.Walk(testsDir, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if !strings.HasSuffix(path, ".json") {
 			return nil
 		}
@@ -107,15 +117,15 @@ func TestUT(t *testing.T) {
 	})
 
 	require.NoError(t, err)
-	require.Equal(t, true, testsRan, "neo-vm tests should be available (check submodules)")
+	return testsRan
 }
 
 func getTestingInterop(id uint32) *InteropFuncPrice {
 	if id == binary.LittleEndian.Uint32([]byte{0x77, 0x77, 0x77, 0x77}) {
-		return &InteropFuncPrice{InteropFunc(func(v *VM) error {
+		return &InteropFuncPrice{Func: func(v *VM) error {
 			v.estack.PushVal(stackitem.NewInterop(new(int)))
 			return nil
-		}), 0}
+		}}
 	}
 	return nil
 }
@@ -68,9 +68,14 @@ const (
 	CALLA     Opcode = 0x36
 
 	// Exceptions
-	ABORT  Opcode = 0x37
-	ASSERT Opcode = 0x38
-	THROW  Opcode = 0x3A
+	ABORT      Opcode = 0x37
+	ASSERT     Opcode = 0x38
+	THROW      Opcode = 0x3A
+	TRY        Opcode = 0x3B
+	TRYL       Opcode = 0x3C // TRY_L
+	ENDTRY     Opcode = 0x3D
+	ENDTRYL    Opcode = 0x3E // ENDTRY_L
+	ENDFINALLY Opcode = 0x3F
 
 	RET     Opcode = 0x40
 	SYSCALL Opcode = 0x41
@@ -171,6 +176,10 @@ const (
 	MUL         Opcode = 0xA0
 	DIV         Opcode = 0xA1
 	MOD         Opcode = 0xA2
+	POW         Opcode = 0xA3
+	SQRT        Opcode = 0xA4
+	MODMUL      Opcode = 0xA5
+	MODPOW      Opcode = 0xA6
 	SHL         Opcode = 0xA8
 	SHR         Opcode = 0xA9
 	NOT         Opcode = 0xAA
@@ -46,6 +46,8 @@ func serializeItemTo(item Item, w *io.BinWriter, seen map[Item]bool) {
 		w.WriteVarBytes(bigint.ToBytes(t.Value().(*big.Int)))
 	case *Interop:
 		w.Err = errors.New("interop item can't be serialized")
+	case Null:
+		w.WriteB(byte(AnyT))
 	case *Array, *Struct:
 		seen[item] = true
 
@@ -94,9 +96,14 @@ func DecodeBinaryStackItem(r *io.BinReader) Item {
 	}
 
 	switch t {
+	case AnyT:
+		return Null{}
 	case ByteArrayT:
 		data := r.ReadVarBytes()
 		return NewByteArray(data)
+	case BufferT:
+		data := r.ReadVarBytes()
+		return NewBuffer(data)
 	case BooleanT:
 		var b = r.ReadBool()
 		return NewBool(b)
@@ -0,0 +1,24 @@
+package vm
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// TraceStep describes VM state right before the execution of some instruction.
+type TraceStep struct {
+	// IP is the offset of the instruction in the script.
+	IP int
+	// Opcode is the instruction opcode.
+	Opcode opcode.Opcode
+	// ScriptHash is the hash of the script being executed.
+	ScriptHash util.Uint160
+	// GasConsumed is the amount of GAS consumed before the instruction.
+	GasConsumed util.Fixed8
+	// StackDepth is the number of items on the evaluation stack.
+	StackDepth int
+}
+
+// Tracer is a function which is called by VM before executing every
+// instruction.
+type Tracer func(TraceStep)
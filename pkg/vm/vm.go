@@ -13,6 +13,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -70,6 +71,9 @@ type VM struct {
 	// callback to get interop price
 	getPrice func(*VM, opcode.Opcode, []byte) util.Fixed8
 
+	// callback to trace execution
+	tracer Tracer
+
 	istack *Stack // invocation stack.
 	estack *Stack // execution stack.
 	astack *Stack // alt stack.
@@ -84,6 +88,9 @@ type VM struct {
 	gasConsumed util.Fixed8
 	gasLimit    util.Fixed8
 
+	// Exception being currently processed, nil if there is none.
+	uncaughtException stackitem.Item
+
 	// Public keys cache.
 	keys map[string]*keys.PublicKey
 }
@@ -125,6 +132,11 @@ func (v *VM) SetPriceGetter(f func(*VM, opcode.Opcode, []byte) util.Fixed8) {
 	v.getPrice = f
 }
 
+// SetTracer registers the given Tracer in v, nil disables tracing.
+func (v *VM) SetTracer(t Tracer) {
+	v.tracer = t
+}
+
 // GasConsumed returns the amount of GAS consumed during execution.
 func (v *VM) GasConsumed() util.Fixed8 {
 	return v.gasConsumed
@@ -136,6 +148,13 @@ func (v *VM) SetGasLimit(max util.Fixed8) {
 	v.gasLimit = max
 }
 
+// AddGas consumes specified amount of gas. It returns true if gas limit wasn't
+// exceeded.
+func (v *VM) AddGas(gas util.Fixed8) bool {
+	v.gasConsumed += gas
+	return v.gasLimit <= 0 || v.gasConsumed <= v.gasLimit
+}
+
 // Estack returns the evaluation stack so interop hooks can utilize this.
 func (v *VM) Estack() *Stack {
 	return v.estack
@@ -249,6 +268,7 @@ func (v *VM) Load(prog []byte) {
 	v.astack.Clear()
 	v.state = noneState
 	v.gasConsumed = 0
+	v.uncaughtException = nil
 	v.LoadScript(prog)
 }
 
@@ -256,9 +276,15 @@ func (v *VM) Load(prog []byte) {
 // will immediately push a new context created from this script to
 // the invocation stack and starts executing it.
 func (v *VM) LoadScript(b []byte) {
+	v.LoadScriptWithFlags(b, smartcontract.All)
+}
+
+// LoadScriptWithFlags loads script and sets call flag to f.
+func (v *VM) LoadScriptWithFlags(b []byte, f smartcontract.CallFlag) {
 	ctx := NewContext(b)
 	ctx.estack = v.estack
 	ctx.astack = v.astack
+	ctx.callFlag = f
 	v.istack.PushVal(ctx)
 }
 
@@ -503,6 +529,16 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		}
 	}()
 
+	if v.tracer != nil {
+		v.tracer(TraceStep{
+			IP:          ctx.ip,
+			Opcode:      op,
+			ScriptHash:  ctx.ScriptHash(),
+			GasConsumed: v.gasConsumed,
+			StackDepth:  v.estack.Len(),
+		})
+	}
+
 	if v.getPrice != nil && ctx.ip < len(ctx.prog) {
 		v.gasConsumed += v.getPrice(v, op, parameter)
 		if v.gasLimit > 0 && v.gasConsumed > v.gasLimit {
@@ -883,6 +919,63 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 
 		v.estack.PushVal(new(big.Int).Rem(a, b))
 
+	case opcode.POW:
+		exp := v.estack.Pop().BigInt()
+		a := v.estack.Pop().BigInt()
+		if ei := exp.Int64(); !exp.IsInt64() || ei > maxSHLArg || ei < 0 {
+			panic("invalid exponent")
+		}
+		v.estack.PushVal(new(big.Int).Exp(a, exp, nil))
+
+	case opcode.SQRT:
+		a := v.estack.Pop().BigInt()
+		if a.Sign() == -1 {
+			panic("negative value")
+		}
+
+		v.estack.PushVal(new(big.Int).Sqrt(a))
+
+	case opcode.MODMUL:
+		modulus := v.estack.Pop().BigInt()
+		if modulus.Sign() == 0 {
+			panic("zero modulus")
+		}
+		x2 := v.estack.Pop().BigInt()
+		x1 := v.estack.Pop().BigInt()
+
+		res := new(big.Int).Mul(x1, x2)
+		v.estack.PushVal(res.Rem(res, modulus))
+
+	case opcode.MODPOW:
+		modulus := v.estack.Pop().BigInt()
+		exponent := v.estack.Pop().BigInt()
+		base := v.estack.Pop().BigInt()
+		res := new(big.Int)
+		switch exponent.Cmp(big.NewInt(-1)) {
+		case -1:
+			panic("exponent should be >= -1")
+		case 0:
+			if base.Sign() <= 0 {
+				panic("invalid base")
+			}
+			if modulus.Cmp(big.NewInt(2)) < 0 {
+				panic("invalid modulus")
+			}
+			if res.ModInverse(base, modulus) == nil {
+				panic("invalid base")
+			}
+		case 1:
+			if modulus.Sign() == 0 {
+				panic("zero modulus")
+			}
+			// Result has the sign of the base like the Rem does.
+			res.Exp(new(big.Int).Abs(base), exponent, new(big.Int).Abs(modulus))
+			if base.Sign() == -1 && exponent.Bit(0) == 1 {
+				res.Neg(res)
+			}
+		}
+		v.estack.PushVal(res)
+
 	case opcode.SHL, opcode.SHR:
 		b := v.estack.Pop().BigInt().Int64()
 		if b == 0 {
@@ -1227,6 +1320,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		newCtx.local = nil
 		newCtx.arguments = nil
 		newCtx.rvcount = -1
+		newCtx.tryStack = nil
 		v.istack.PushVal(newCtx)
 
 		offset := v.getJumpOffset(newCtx, parameter, 0)
@@ -1242,6 +1336,7 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		newCtx.local = nil
 		newCtx.arguments = nil
 		newCtx.rvcount = -1
+		newCtx.tryStack = nil
 		v.istack.PushVal(newCtx)
 		v.jumpIf(newCtx, ptr.Position(), true)
 
@@ -1252,6 +1347,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		if ifunc == nil {
 			panic(fmt.Sprintf("interop hook (%q/0x%x) not registered", parameter, interopID))
 		}
+		if !ctx.callFlag.Has(ifunc.RequiredFlags) {
+			panic(fmt.Sprintf("missing call flags: %05b vs %05b", ctx.callFlag, ifunc.RequiredFlags))
+		}
 		if err := ifunc.Func(v); err != nil {
 			panic(fmt.Sprintf("failed to invoke syscall: %s", err))
 		}
@@ -1358,7 +1456,58 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		// unlucky ^^
 
 	case opcode.THROW:
-		panic("THROW")
+		v.throw(v.estack.Pop().Item())
+
+	case opcode.TRY, opcode.TRYL:
+		if len(ctx.tryStack) >= MaxTryNestingDepth {
+			panic("maximum TRY depth exceeded")
+		}
+		catchP, finallyP := getTryParams(parameter)
+		cOffset := v.getJumpOffset(ctx, catchP, 0)
+		fOffset := v.getJumpOffset(ctx, finallyP, 0)
+		if cOffset == ctx.ip && fOffset == ctx.ip {
+			panic("TRY without catch and finally blocks")
+		}
+		if cOffset == ctx.ip {
+			cOffset = -1
+		}
+		if fOffset == ctx.ip {
+			fOffset = -1
+		}
+		ctx.tryStack = append(ctx.tryStack, newExceptionHandlingContext(cOffset, fOffset))
+
+	case opcode.ENDTRY, opcode.ENDTRYL:
+		if len(ctx.tryStack) == 0 {
+			panic("ENDTRY outside of TRY block")
+		}
+		eCtx := ctx.tryStack[len(ctx.tryStack)-1]
+		if eCtx.State == eFinally {
+			panic("ENDTRY in finally block")
+		}
+		eOffset := v.getJumpOffset(ctx, parameter, 0)
+		if eCtx.HasFinally() {
+			eCtx.State = eFinally
+			eCtx.EndOffset = eOffset
+			eOffset = eCtx.FinallyOffset
+		} else {
+			ctx.tryStack = ctx.tryStack[:len(ctx.tryStack)-1]
+		}
+		v.jumpIf(ctx, eOffset, true)
+
+	case opcode.ENDFINALLY:
+		if len(ctx.tryStack) == 0 {
+			panic("ENDFINALLY outside of TRY block")
+		}
+		eCtx := ctx.tryStack[len(ctx.tryStack)-1]
+		if eCtx.State != eFinally {
+			panic("ENDFINALLY outside of finally block")
+		}
+		ctx.tryStack = ctx.tryStack[:len(ctx.tryStack)-1]
+		if v.uncaughtException != nil {
+			v.handleException()
+			return
+		}
+		v.jumpIf(ctx, eCtx.EndOffset, true)
 
 	case opcode.ABORT:
 		panic("ABORT")
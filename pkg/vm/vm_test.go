@@ -9,9 +9,11 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
@@ -22,10 +24,10 @@ import (
 
 func fooInteropGetter(id uint32) *InteropFuncPrice {
 	if id == emit.InteropNameToID([]byte("foo")) {
-		return &InteropFuncPrice{func(evm *VM) error {
+		return &InteropFuncPrice{Func: func(evm *VM) error {
 			evm.Estack().PushVal(1)
 			return nil
-		}, 1}
+		}, Price: 1}
 	}
 	return nil
 }
@@ -43,6 +45,36 @@ func TestInteropHook(t *testing.T) {
 	assert.Equal(t, big.NewInt(1), v.estack.Pop().value.Value())
 }
 
+func TestInteropHookCallFlags(t *testing.T) {
+	getter := func(id uint32) *InteropFuncPrice {
+		if id == emit.InteropNameToID([]byte("foo")) {
+			return &InteropFuncPrice{Func: func(evm *VM) error {
+				evm.Estack().PushVal(1)
+				return nil
+			}, RequiredFlags: smartcontract.AllowNotify}
+		}
+		return nil
+	}
+
+	buf := io.NewBufBinWriter()
+	emit.Syscall(buf.BinWriter, "foo")
+	emit.Opcode(buf.BinWriter, opcode.RET)
+	prog := buf.Bytes()
+
+	t.Run("Allowed", func(t *testing.T) {
+		v := New()
+		v.RegisterInteropGetter(getter)
+		v.LoadScriptWithFlags(prog, smartcontract.AllowNotify)
+		runVM(t, v)
+	})
+	t.Run("Missing", func(t *testing.T) {
+		v := New()
+		v.RegisterInteropGetter(getter)
+		v.LoadScriptWithFlags(prog, smartcontract.AllowModifyStates|smartcontract.AllowCall)
+		checkVMFailed(t, v)
+	})
+}
+
 func TestRegisterInteropGetter(t *testing.T) {
 	v := New()
 	currRegistered := len(v.getInterop)
@@ -98,6 +130,29 @@ func TestVM_SetPriceGetter(t *testing.T) {
 	})
 }
 
+func TestVM_SetTracer(t *testing.T) {
+	prog := []byte{byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.ADD)}
+	v := load(prog)
+	v.SetPriceGetter(func(_ *VM, op opcode.Opcode, _ []byte) util.Fixed8 {
+		if op == opcode.ADD {
+			return 3
+		}
+		return 1
+	})
+
+	var steps []TraceStep
+	v.SetTracer(func(s TraceStep) { steps = append(steps, s) })
+	runVM(t, v)
+
+	h := hash.Hash160(prog)
+	require.Equal(t, []TraceStep{
+		{IP: 0, Opcode: opcode.PUSH1, ScriptHash: h, GasConsumed: 0, StackDepth: 0},
+		{IP: 1, Opcode: opcode.PUSH2, ScriptHash: h, GasConsumed: 1, StackDepth: 1},
+		{IP: 2, Opcode: opcode.ADD, ScriptHash: h, GasConsumed: 2, StackDepth: 2},
+		{IP: 3, Opcode: opcode.RET, ScriptHash: h, GasConsumed: 5, StackDepth: 1},
+	}, steps)
+}
+
 func TestBytesToPublicKey(t *testing.T) {
 	v := New()
 	cache := v.GetPublicKeys()
@@ -544,6 +599,38 @@ func TestIteratorCreate(t *testing.T) {
 	testIterableCreate(t, "Iterator")
 }
 
+func TestIterateNext(t *testing.T) {
+	arr := []stackitem.Item{stackitem.Make(1), stackitem.Make(2), stackitem.Make(3)}
+	require.False(t, IsIterator(stackitem.NewArray(arr)))
+	require.Nil(t, IterateNext(stackitem.NewArray(arr), 1))
+
+	t.Run("iterator", func(t *testing.T) {
+		m := stackitem.NewMap()
+		m.Add(stackitem.Make("a"), arr[0])
+		m.Add(stackitem.Make("b"), arr[1])
+		iter := NewMapIterator(m)
+		require.True(t, IsIterator(iter))
+		require.Equal(t, []stackitem.Item{
+			stackitem.NewStruct([]stackitem.Item{stackitem.Make("a"), arr[0]}),
+		}, IterateNext(iter, 1))
+		require.Equal(t, []stackitem.Item{
+			stackitem.NewStruct([]stackitem.Item{stackitem.Make("b"), arr[1]}),
+		}, IterateNext(iter, 5))
+		require.Equal(t, []stackitem.Item{}, IterateNext(iter, 5))
+	})
+	t.Run("enumerator", func(t *testing.T) {
+		enum := stackitem.NewInterop(&keysWrapper{NewMapIterator(stackitem.NewMap()).Value().(iterator)})
+		require.True(t, IsIterator(enum))
+		require.Equal(t, []stackitem.Item{}, IterateNext(enum, 5))
+
+		enum = stackitem.NewInterop(&concatEnum{
+			current: &arrayWrapper{index: -1, value: arr[:1]},
+			second:  &arrayWrapper{index: -1, value: arr[1:]},
+		})
+		require.Equal(t, arr, IterateNext(enum, 3))
+	})
+}
+
 func testIterableConcat(t *testing.T, typ string) {
 	isIter := typ == "Iterator"
 	prog := getSyscallProg("Neo." + typ + ".Create")
@@ -682,6 +769,17 @@ func TestSerializeByteArray(t *testing.T) {
 	require.Equal(t, value, vm.estack.Top().Bytes())
 }
 
+func TestSerializeBuffer(t *testing.T) {
+	vm := load(getSerializeProg())
+	value := []byte{1, 2, 3}
+	vm.estack.Push(&Element{value: stackitem.NewBuffer(value)})
+
+	testSerialize(t, vm)
+
+	require.IsType(t, (*stackitem.Buffer)(nil), vm.estack.Top().value)
+	require.Equal(t, value, vm.estack.Top().Bytes())
+}
+
 func TestSerializeInteger(t *testing.T) {
 	vm := load(getSerializeProg())
 	value := int64(123)
@@ -931,6 +1029,92 @@ func TestCALLA(t *testing.T) {
 	t.Run("Good", getTestFuncForVM(prog, 5, stackitem.NewPointer(4, prog)))
 }
 
+// getTRYProgram returns a program with TRY block containing given code blocks,
+// nil catch or finally block means there is no such block.
+func getTRYProgram(tryBlock, catchBlock, finallyBlock []byte) []byte {
+	var catchLen, finallyLen, catchOffset, finallyOffset int
+	tryLen := 3 + len(tryBlock) + 2 // TRY and ENDTRY instructions
+	if catchBlock != nil {
+		catchLen = len(catchBlock) + 2 // ENDTRY
+		catchOffset = tryLen
+	}
+	if finallyBlock != nil {
+		finallyLen = len(finallyBlock) + 1 // ENDFINALLY
+		finallyOffset = tryLen + catchLen
+	}
+	prog := []byte{byte(opcode.TRY), byte(catchOffset), byte(finallyOffset)}
+	prog = append(prog, tryBlock...)
+	prog = append(prog, byte(opcode.ENDTRY), byte(2+catchLen+finallyLen))
+	if catchBlock != nil {
+		prog = append(prog, catchBlock...)
+		prog = append(prog, byte(opcode.ENDTRY), byte(2+finallyLen))
+	}
+	if finallyBlock != nil {
+		prog = append(prog, finallyBlock...)
+		prog = append(prog, byte(opcode.ENDFINALLY))
+	}
+	return prog
+}
+
+func TestTRY(t *testing.T) {
+	throw := []byte{byte(opcode.PUSH1), byte(opcode.THROW)}
+	push1 := []byte{byte(opcode.PUSH1)}
+	add2 := []byte{byte(opcode.PUSH2), byte(opcode.ADD)}
+	add3 := []byte{byte(opcode.PUSH3), byte(opcode.ADD)}
+
+	t.Run("NoException", func(t *testing.T) {
+		t.Run("Catch", getTestFuncForVM(getTRYProgram(push1, add2, nil), 1))
+		t.Run("Finally", getTestFuncForVM(getTRYProgram(push1, nil, add3), 4))
+		t.Run("CatchFinally", getTestFuncForVM(getTRYProgram(push1, add2, add3), 4))
+	})
+	t.Run("Exception", func(t *testing.T) {
+		t.Run("Catch", getTestFuncForVM(getTRYProgram(throw, add2, nil), 3))
+		t.Run("Finally", getTestFuncForVM(getTRYProgram(throw, nil, add3), nil))
+		t.Run("CatchFinally", getTestFuncForVM(getTRYProgram(throw, add2, add3), 6))
+		t.Run("RethrowInCatch", getTestFuncForVM(getTRYProgram(throw, throw, add3), nil))
+	})
+	t.Run("Nested", func(t *testing.T) {
+		t.Run("RethrowFromFinally", func(t *testing.T) {
+			inner := getTRYProgram(throw, nil, []byte{byte(opcode.PUSH5), byte(opcode.DROP)})
+			getTestFuncForVM(getTRYProgram(inner, add3, nil), 4)(t)
+		})
+		t.Run("CatchInCatch", func(t *testing.T) {
+			inner := getTRYProgram(throw, add2, nil)
+			getTestFuncForVM(getTRYProgram(append(inner, byte(opcode.THROW)), add3, add2), 8)(t)
+		})
+	})
+	t.Run("CrossContext", func(t *testing.T) {
+		prog := getTRYProgram([]byte{byte(opcode.CALL), 0}, add2, nil)
+		prog[4] = byte(len(prog) + 1 - 3) // CALL is at 3, function follows RET
+		prog = append(prog, byte(opcode.RET), byte(opcode.PUSH7), byte(opcode.THROW))
+		v := load(prog)
+		runVM(t, v)
+		require.Equal(t, 1, v.estack.Len())
+		require.EqualValues(t, 9, v.estack.Pop().BigInt().Int64())
+		require.Equal(t, 0, v.istack.Len())
+	})
+	t.Run("ExceptionItem", func(t *testing.T) {
+		prog := getTRYProgram([]byte{byte(opcode.PUSHDATA1), 2, 'o', 'k', byte(opcode.THROW)}, []byte{}, nil)
+		getTestFuncForVM(prog, []byte("ok"))(t)
+	})
+	t.Run("NoBlocks", getTestFuncForVM(makeProgram(opcode.TRY, 0, 0), nil))
+	t.Run("ENDTRYWithoutTRY", getTestFuncForVM(makeProgram(opcode.ENDTRY, 2), nil))
+	t.Run("ENDFINALLYWithoutTRY", getTestFuncForVM(makeProgram(opcode.ENDFINALLY), nil))
+	t.Run("ENDFINALLYInTRY", getTestFuncForVM(makeProgram(opcode.TRY, 0, 4, opcode.ENDFINALLY, opcode.ENDFINALLY), nil))
+	t.Run("MaxDepth", func(t *testing.T) {
+		prog := make([]byte, 0, 3*(MaxTryNestingDepth+1))
+		for i := 0; i < MaxTryNestingDepth; i++ {
+			prog = append(prog, byte(opcode.TRY), 3, 0)
+		}
+		v := load(prog)
+		runVM(t, v)
+
+		prog = append(prog, byte(opcode.TRY), 3, 0)
+		v = load(prog)
+		checkVMFailed(t, v)
+	})
+}
+
 func TestNOT(t *testing.T) {
 	prog := makeProgram(opcode.NOT)
 	t.Run("Bool", getTestFuncForVM(prog, true, false))
@@ -1007,6 +1191,50 @@ func TestArithNegativeArguments(t *testing.T) {
 	})
 }
 
+func TestPOW(t *testing.T) {
+	prog := makeProgram(opcode.POW)
+	t.Run("Good", getTestFuncForVM(prog, 9, 3, 2))
+	t.Run("Zero", getTestFuncForVM(prog, 1, 3, 0))
+	t.Run("NegativeBase", getTestFuncForVM(prog, -27, -3, 3))
+	t.Run("NegativeExponent", getTestFuncForVM(prog, nil, 3, -1))
+	t.Run("BigExponent", getTestFuncForVM(prog, nil, 1, maxSHLArg+1))
+	t.Run("BigResult", getTestFuncForVM(prog, nil, 2, stackitem.MaxBigIntegerSizeBits))
+}
+
+func TestSQRT(t *testing.T) {
+	prog := makeProgram(opcode.SQRT)
+	t.Run("Good", getTestFuncForVM(prog, 3, 9))
+	t.Run("Floor", getTestFuncForVM(prog, 3, 15))
+	t.Run("Zero", getTestFuncForVM(prog, 0, 0))
+	t.Run("Negative", getTestFuncForVM(prog, nil, -1))
+}
+
+func TestMODMUL(t *testing.T) {
+	prog := makeProgram(opcode.MODMUL)
+	t.Run("Good", getTestFuncForVM(prog, 1, 3, 4, 11))
+	t.Run("NegativeMultiplier", getTestFuncForVM(prog, -1, -3, 4, 11))
+	t.Run("NegativeModulus", getTestFuncForVM(prog, 1, 3, 4, -11))
+	t.Run("ZeroModulus", getTestFuncForVM(prog, nil, 3, 4, 0))
+	t.Run("BigIntermediate", getTestFuncForVM(prog, 1,
+		getBigInt(stackitem.MaxBigIntegerSizeBits-2, 0), getBigInt(stackitem.MaxBigIntegerSizeBits-2, 0), 3))
+}
+
+func TestMODPOW(t *testing.T) {
+	prog := makeProgram(opcode.MODPOW)
+	t.Run("Good", getTestFuncForVM(prog, 4, 5, 3, 11))
+	t.Run("ZeroExponent", getTestFuncForVM(prog, 1, 5, 0, 11))
+	t.Run("NegativeBase", getTestFuncForVM(prog, -4, -5, 3, 11))
+	t.Run("NegativeModulus", getTestFuncForVM(prog, 4, 5, 3, -11))
+	t.Run("ZeroModulus", getTestFuncForVM(prog, nil, 5, 3, 0))
+	t.Run("BadExponent", getTestFuncForVM(prog, nil, 5, -2, 11))
+	t.Run("Inverse", func(t *testing.T) {
+		t.Run("Good", getTestFuncForVM(prog, 9, 5, -1, 11))
+		t.Run("NotCoprime", getTestFuncForVM(prog, nil, 2, -1, 4))
+		t.Run("NegativeBase", getTestFuncForVM(prog, nil, -5, -1, 11))
+		t.Run("SmallModulus", getTestFuncForVM(prog, nil, 5, -1, 1))
+	})
+}
+
 func TestSUBBigResult(t *testing.T) {
 	prog := makeProgram(opcode.SUB)
 	runWithArgs(t, prog, nil, getBigInt(stackitem.MaxBigIntegerSizeBits, -1), -1)